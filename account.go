@@ -2,13 +2,44 @@ package govultr
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"net/http"
+	"strings"
+)
+
+// ACLs that can be granted to a user and the API keys that belong to it
+const (
+	ACLManageUsers       = "manage_users"
+	ACLSubscriptionsView = "subscriptions_view"
+	ACLSubscriptions     = "subscriptions"
+	ACLProvisioning      = "provisioning"
+	ACLBilling           = "billing"
+	ACLSupport           = "support"
+	ACLAbuse             = "abuse"
+	ACLDNS               = "dns"
+	ACLUpgrade           = "upgrade"
+	ACLObjectStorage     = "objstore"
+	ACLLoadBalancer      = "loadbalancer"
+	ACLFirewall          = "firewall"
+	ACLAlerts            = "alerts"
+)
+
+// Resource names reported in AccountPreflightViolation
+const (
+	AccountResourceInstances    = "instances"
+	AccountResourceBareMetals   = "bare_metals"
+	AccountResourceBlockStorage = "block_storage_gb"
+	AccountResourceReservedIPs  = "reserved_ips"
 )
 
 // AccountService is the interface to interact with Accounts endpoint on the Vultr API
 // Link : https://www.vultr.com/api/#tag/account
 type AccountService interface {
 	Get(ctx context.Context) (*Account, *http.Response, error)
+	GetAuthInfo(ctx context.Context) (*AccountAuthInfo, *http.Response, error)
+	Require(ctx context.Context, acls ...string) error
+	Preflight(ctx context.Context, limits *AccountLimits, planned *AccountPreflightReq) ([]AccountPreflightViolation, error)
 }
 
 // AccountServiceHandler handles interaction with the account methods for the Vultr API
@@ -47,3 +78,171 @@ func (a *AccountServiceHandler) Get(ctx context.Context) (*Account, *http.Respon
 
 	return account.Account, resp, nil
 }
+
+// AccountAuthInfo represents the identity and permissions of the API key in use
+type AccountAuthInfo struct {
+	Name  string
+	Email string
+	ACLs  []string
+}
+
+// MissingACLError is returned by Require when the API key lacks permissions
+type MissingACLError struct {
+	Missing []string
+}
+
+func (e *MissingACLError) Error() string {
+	return fmt.Sprintf("API key is missing required ACLs: %s", strings.Join(e.Missing, ", "))
+}
+
+// HasACL reports whether the API key has been granted acl
+func (a *AccountAuthInfo) HasACL(acl string) bool {
+	for _, granted := range a.ACLs {
+		if granted == acl {
+			return true
+		}
+	}
+	return false
+}
+
+// GetAuthInfo returns the identity and ACLs of the API key in use, which are
+// those of the user it belongs to
+func (a *AccountServiceHandler) GetAuthInfo(ctx context.Context) (*AccountAuthInfo, *http.Response, error) {
+	account, resp, err := a.Get(ctx)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return &AccountAuthInfo{Name: account.Name, Email: account.Email, ACLs: account.ACL}, resp, nil
+}
+
+// Require returns a *MissingACLError listing any of acls that the API key has
+// not been granted. Call it at startup to fail fast instead of partway through
+// a run.
+func (a *AccountServiceHandler) Require(ctx context.Context, acls ...string) error {
+	info, _, err := a.GetAuthInfo(ctx)
+	if err != nil {
+		return err
+	}
+
+	var missing []string
+	for _, acl := range acls {
+		if !info.HasACL(acl) {
+			missing = append(missing, acl)
+		}
+	}
+
+	if len(missing) > 0 {
+		return &MissingACLError{Missing: missing}
+	}
+
+	return nil
+}
+
+// AccountLimits represents the resource limits on a Vultr account. The API does
+// not currently expose these so they are supplied by the caller, typically from
+// the limits shown in the customer portal. A zero value means no limit.
+type AccountLimits struct {
+	MaxInstances      int
+	MaxBareMetals     int
+	MaxBlockStorageGB int
+	MaxReservedIPs    int
+}
+
+// AccountPreflightReq represents the resources a batch create intends to add
+type AccountPreflightReq struct {
+	Instances      int
+	BareMetals     int
+	BlockStorageGB int
+	ReservedIPs    int
+}
+
+// AccountPreflightViolation represents a resource that would exceed its limit
+type AccountPreflightViolation struct {
+	Resource  string
+	Limit     int
+	Current   int
+	Requested int
+}
+
+// Preflight checks a planned batch create against current usage and the given
+// limits without making any changes. The returned slice is empty when
+// everything fits.
+func (a *AccountServiceHandler) Preflight(ctx context.Context, limits *AccountLimits, planned *AccountPreflightReq) ([]AccountPreflightViolation, error) { //nolint:lll
+	if limits == nil || planned == nil {
+		return nil, errors.New("preflight requires limits and a planned request")
+	}
+
+	var violations []AccountPreflightViolation
+	check := func(resource string, limit, requested int, current func() (int, error)) error {
+		if limit <= 0 || requested <= 0 {
+			return nil
+		}
+
+		count, err := current()
+		if err != nil {
+			return err
+		}
+
+		if count+requested > limit {
+			violations = append(violations, AccountPreflightViolation{
+				Resource:  resource,
+				Limit:     limit,
+				Current:   count,
+				Requested: requested,
+			})
+		}
+		return nil
+	}
+
+	total := func(list func(*ListOptions) (*Meta, error)) func() (int, error) {
+		return func() (int, error) {
+			meta, err := list(&ListOptions{PerPage: 1})
+			if err != nil {
+				return 0, err
+			}
+			if meta == nil {
+				return 0, errors.New("list response has no meta to count resources from")
+			}
+			return meta.Total, nil
+		}
+	}
+
+	if err := check(AccountResourceInstances, limits.MaxInstances, planned.Instances, total(func(o *ListOptions) (*Meta, error) {
+		_, meta, _, err := a.client.Instance.List(ctx, o)
+		return meta, err
+	})); err != nil {
+		return nil, err
+	}
+
+	if err := check(AccountResourceBareMetals, limits.MaxBareMetals, planned.BareMetals, total(func(o *ListOptions) (*Meta, error) {
+		_, meta, _, err := a.client.BareMetalServer.List(ctx, o)
+		return meta, err
+	})); err != nil {
+		return nil, err
+	}
+
+	if err := check(AccountResourceReservedIPs, limits.MaxReservedIPs, planned.ReservedIPs, total(func(o *ListOptions) (*Meta, error) {
+		_, meta, _, err := a.client.ReservedIP.List(ctx, o)
+		return meta, err
+	})); err != nil {
+		return nil, err
+	}
+
+	if err := check(AccountResourceBlockStorage, limits.MaxBlockStorageGB, planned.BlockStorageGB, func() (int, error) {
+		volumes, err := collectPages(ctx, a.client.BlockStorage.List)
+		if err != nil {
+			return 0, err
+		}
+
+		size := 0
+		for i := range volumes {
+			size += volumes[i].SizeGB
+		}
+		return size, nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return violations, nil
+}
@@ -1,6 +1,7 @@
 package govultr
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 	"reflect"
@@ -45,3 +46,95 @@ func TestAccountServiceHandler_Get(t *testing.T) {
 		t.Errorf("Account.Get returned %+v, expected %+v", account, expected)
 	}
 }
+
+func TestAccountServiceHandler_Preflight(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v2/instances", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"instances": [{"id": "1"}], "meta": {"total": 9, "links": {"next": "", "prev": ""}}}`)
+	})
+
+	mux.HandleFunc("/v2/blocks", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("cursor") == "" {
+			fmt.Fprint(w, `{"blocks": [{"id": "1", "size_gb": 100}], "meta": {"total": 2, "links": {"next": "next", "prev": ""}}}`)
+			return
+		}
+		fmt.Fprint(w, `{"blocks": [{"id": "2", "size_gb": 50}], "meta": {"total": 2, "links": {"next": "", "prev": ""}}}`)
+	})
+
+	limits := &AccountLimits{MaxInstances: 10, MaxBlockStorageGB: 1000}
+	planned := &AccountPreflightReq{Instances: 2, BlockStorageGB: 100}
+
+	violations, err := client.Account.Preflight(ctx, limits, planned)
+	if err != nil {
+		t.Errorf("Account.Preflight returned error: %v", err)
+	}
+
+	expected := []AccountPreflightViolation{
+		{Resource: AccountResourceInstances, Limit: 10, Current: 9, Requested: 2},
+	}
+
+	if !reflect.DeepEqual(violations, expected) {
+		t.Errorf("Account.Preflight returned %+v, expected %+v", violations, expected)
+	}
+
+	if _, err := client.Account.Preflight(ctx, nil, &AccountPreflightReq{}); err == nil {
+		t.Error("Account.Preflight expected an error for nil limits")
+	}
+
+	if _, err := client.Account.Preflight(ctx, &AccountLimits{}, nil); err == nil {
+		t.Error("Account.Preflight expected an error for a nil planned request")
+	}
+}
+
+func TestAccountServiceHandler_PreflightNoMeta(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v2/instances", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"instances": []}`)
+	})
+
+	limits := &AccountLimits{MaxInstances: 10}
+	planned := &AccountPreflightReq{Instances: 2}
+
+	if _, err := client.Account.Preflight(ctx, limits, planned); err == nil {
+		t.Error("Account.Preflight expected an error for a list response without meta")
+	}
+}
+
+func TestAccountServiceHandler_Require(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v2/account", func(writer http.ResponseWriter, request *http.Request) {
+		response := `{"account": {"name": "Example Account", "email": "admin@example.com", "acls": ["subscriptions_view", "dns"]}}`
+		fmt.Fprint(writer, response)
+	})
+
+	info, _, err := client.Account.GetAuthInfo(ctx)
+	if err != nil {
+		t.Errorf("Account.GetAuthInfo returned error: %v", err)
+	}
+
+	expected := &AccountAuthInfo{Name: "Example Account", Email: "admin@example.com", ACLs: []string{ACLSubscriptionsView, ACLDNS}}
+	if !reflect.DeepEqual(info, expected) {
+		t.Errorf("Account.GetAuthInfo returned %+v, expected %+v", info, expected)
+	}
+
+	if err = client.Account.Require(ctx, ACLDNS); err != nil {
+		t.Errorf("Account.Require returned error: %v", err)
+	}
+
+	err = client.Account.Require(ctx, ACLDNS, ACLProvisioning, ACLFirewall)
+	var missingErr *MissingACLError
+	if !errors.As(err, &missingErr) {
+		t.Fatalf("Account.Require returned %v, expected a *MissingACLError", err)
+	}
+
+	expectedMissing := []string{ACLProvisioning, ACLFirewall}
+	if !reflect.DeepEqual(missingErr.Missing, expectedMissing) {
+		t.Errorf("Account.Require missing %+v, expected %+v", missingErr.Missing, expectedMissing)
+	}
+}
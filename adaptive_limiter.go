@@ -0,0 +1,119 @@
+package govultr
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// AdaptiveLimiter is a concurrency-safe limit on the number of requests in
+// flight that adjusts itself using additive increase, multiplicative decrease.
+// The limit grows by about one for every limit requests that complete within
+// the target latency, and halves when a request is throttled with a 429 or
+// takes longer than the target. One limiter may be shared by several clients
+// using the same API key.
+type AdaptiveLimiter struct {
+	mu           sync.Mutex
+	limit        float64
+	minLimit     float64
+	maxLimit     float64
+	target       time.Duration
+	inFlight     int
+	lastDecrease time.Time
+	released     chan struct{}
+}
+
+// NewAdaptiveLimiter returns an AdaptiveLimiter starting at minLimit requests
+// in flight that adjusts between minLimit and maxLimit. minLimit must be at
+// least one.
+func NewAdaptiveLimiter(minLimit, maxLimit int, target time.Duration) *AdaptiveLimiter {
+	return &AdaptiveLimiter{
+		limit:    float64(minLimit),
+		minLimit: float64(minLimit),
+		maxLimit: float64(maxLimit),
+		target:   target,
+		released: make(chan struct{}),
+	}
+}
+
+// Limit returns the current number of requests allowed in flight
+func (a *AdaptiveLimiter) Limit() int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return int(a.limit)
+}
+
+// Acquire blocks until a request may be sent or ctx is done
+func (a *AdaptiveLimiter) Acquire(ctx context.Context) error {
+	for {
+		a.mu.Lock()
+		if a.inFlight < int(a.limit) {
+			a.inFlight++
+			a.mu.Unlock()
+			return nil
+		}
+		released := a.released
+		a.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-released:
+		}
+	}
+}
+
+// Release returns a slot taken by Acquire and adjusts the limit from the
+// latency of the request and whether it was throttled
+func (a *AdaptiveLimiter) Release(latency time.Duration, throttled bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.inFlight--
+
+	now := time.Now()
+	if throttled || latency > a.target {
+		// Requests in flight when the API starts pushing back all see it, so
+		// only the first of them within one round trip lowers the limit
+		if now.Sub(a.lastDecrease) > latency {
+			a.limit /= 2
+			if a.limit < a.minLimit {
+				a.limit = a.minLimit
+			}
+			a.lastDecrease = now
+		}
+	} else {
+		a.limit += 1 / a.limit
+		if a.limit > a.maxLimit {
+			a.limit = a.maxLimit
+		}
+	}
+
+	close(a.released)
+	a.released = make(chan struct{})
+}
+
+// SetConcurrencyLimiter bounds the number of requests the client has in flight
+// with the given AdaptiveLimiter, so goroutines fanning out bulk operations are
+// paced to what the API is currently accepting.
+func (c *Client) SetConcurrencyLimiter(limiter *AdaptiveLimiter) {
+	c.concurrencyLimiter = limiter
+}
+
+// sendLimited sends the request through the concurrency limiter when one is set
+func (c *Client) sendLimited(ctx context.Context, send func() (*http.Response, error)) (*http.Response, error) {
+	if c.concurrencyLimiter == nil {
+		return send()
+	}
+
+	if err := c.concurrencyLimiter.Acquire(ctx); err != nil {
+		return nil, err
+	}
+
+	start := time.Now()
+	res, err := send()
+	c.concurrencyLimiter.Release(time.Since(start), err == nil && res.StatusCode == http.StatusTooManyRequests)
+
+	return res, err
+}
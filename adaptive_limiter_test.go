@@ -0,0 +1,81 @@
+package govultr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestAdaptiveLimiter(t *testing.T) {
+	limiter := NewAdaptiveLimiter(1, 4, time.Second)
+
+	for i := 0; i < 10; i++ {
+		if err := limiter.Acquire(ctx); err != nil {
+			t.Fatalf("AdaptiveLimiter.Acquire returned %+v", err)
+		}
+		limiter.Release(time.Millisecond, false)
+	}
+
+	if limit := limiter.Limit(); limit != 4 {
+		t.Errorf("AdaptiveLimiter.Limit = %d after fast requests, expected 4", limit)
+	}
+
+	limiter.Release(time.Second, true)
+	limiter.Release(time.Second, true)
+	if limit := limiter.Limit(); limit != 2 {
+		t.Errorf("AdaptiveLimiter.Limit = %d after throttled requests, expected 2", limit)
+	}
+
+	limiter = NewAdaptiveLimiter(1, 1, time.Second)
+	if err := limiter.Acquire(ctx); err != nil {
+		t.Fatalf("AdaptiveLimiter.Acquire returned %+v", err)
+	}
+
+	c, cancel := context.WithTimeout(ctx, time.Millisecond)
+	defer cancel()
+	if err := limiter.Acquire(c); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("AdaptiveLimiter.Acquire returned %+v, expected %+v", err, context.DeadlineExceeded)
+	}
+}
+
+func TestClient_SetConcurrencyLimiter(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var inFlight, peak atomic.Int32
+	mux.HandleFunc("/v2/ssh-keys/1", func(writer http.ResponseWriter, request *http.Request) {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		fmt.Fprint(writer, `{"ssh_key": {"id": "1"}}`)
+	})
+
+	client.SetConcurrencyLimiter(NewAdaptiveLimiter(2, 2, time.Second))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, _, err := client.SSHKey.Get(ctx, "1"); err != nil {
+				t.Errorf("SSHKey.Get returned %+v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if p := peak.Load(); p > 2 {
+		t.Errorf("SSHKey.Get had %d requests in flight, expected at most 2", p)
+	}
+}
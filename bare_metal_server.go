@@ -3,21 +3,36 @@ package govultr
 import (
 	"context"
 	"fmt"
+	"net"
 	"net/http"
+	"slices"
 
 	"github.com/google/go-querystring/query"
 )
 
-const bmPath = "/v2/bare-metals"
+const (
+	bmPath         = "/v2/bare-metals"
+	bmStatusActive = "active"
+)
+
+// Network interface types reported by GetNetworkInterfaces
+const (
+	BareMetalInterfacePublic = "public"
+	BareMetalInterfaceVPC    = "vpc"
+	BareMetalInterfaceVPC2   = "vpc2"
+)
 
 // BareMetalServerService is the interface to interact with the Bare Metal endpoints on the Vultr API
 // Link : https://www.vultr.com/api/#tag/baremetal
 type BareMetalServerService interface {
 	Create(ctx context.Context, bmCreate *BareMetalCreate) (*BareMetalServer, *http.Response, error)
+	CreateAndWait(ctx context.Context, bmCreate *BareMetalCreate, options *WaitOptions) (*BareMetalServer, *http.Response, error)
 	Get(ctx context.Context, serverID string) (*BareMetalServer, *http.Response, error)
+	WaitForStatus(ctx context.Context, serverID, status string, options *WaitOptions) (*BareMetalServer, *http.Response, error)
 	Update(ctx context.Context, serverID string, bmReq *BareMetalUpdate) (*BareMetalServer, *http.Response, error)
 	Delete(ctx context.Context, serverID string) error
 	List(ctx context.Context, options *ListOptions) ([]BareMetalServer, *Meta, *http.Response, error)
+	ListFiltered(ctx context.Context, options *ListOptions) ([]BareMetalServer, error)
 
 	GetBandwidth(ctx context.Context, serverID string) (*Bandwidth, *http.Response, error)
 	GetUserData(ctx context.Context, serverID string) (*UserData, *http.Response, error)
@@ -36,12 +51,14 @@ type BareMetalServerService interface {
 	MassReboot(ctx context.Context, serverList []string) error
 
 	GetUpgrades(ctx context.Context, serverID string) (*Upgrades, *http.Response, error)
+	ValidateUpgrade(ctx context.Context, serverID string, bmReq *BareMetalUpdate) error
 
 	ListVPCInfo(ctx context.Context, serverID string) ([]VPCInfo, *http.Response, error)
 	AttachVPC(ctx context.Context, serverID, vpcID string) error
 	DetachVPC(ctx context.Context, serverID, vpcID string) error
 
 	ListVPC2Info(ctx context.Context, serverID string) ([]VPC2Info, *http.Response, error)
+	GetNetworkInterfaces(ctx context.Context, serverID string) ([]BareMetalNetworkInterface, error)
 	AttachVPC2(ctx context.Context, serverID string, vpc2Req *AttachVPC2Req) error
 	DetachVPC2(ctx context.Context, serverID, vpcID string) error
 }
@@ -80,6 +97,29 @@ type BareMetalServer struct {
 	Tags     []string `json:"tags"`
 }
 
+// BareMetalNetworkInterface represents a NIC of a Bare Metal server with the
+// addresses assigned to it. VPCID is only set for VPC interfaces.
+type BareMetalNetworkInterface struct {
+	Type       string
+	MacAddress string
+	VPCID      string
+	Addresses  []string
+}
+
+// MAC returns the MacAddress of the server formatted as a hardware address,
+// or an empty string if it is not set
+func (b *BareMetalServer) MAC() string {
+	if b.MacAddress == 0 {
+		return ""
+	}
+
+	mac := make(net.HardwareAddr, 6)
+	for i := range mac {
+		mac[i] = byte(b.MacAddress >> (8 * (5 - i)))
+	}
+	return mac.String()
+}
+
 // BareMetalCreate represents the optional parameters that can be set when creating a Bare Metal server
 type BareMetalCreate struct {
 	Region          string   `json:"region,omitempty"`
@@ -169,6 +209,32 @@ func (b *BareMetalServerServiceHandler) Create(ctx context.Context, bmCreate *Ba
 	return bm.BareMetal, resp, nil
 }
 
+// CreateAndWait creates a new Bare Metal server and polls until it has finished
+// provisioning. Provisioning can take tens of minutes so a Timeout should be
+// set on the options to suit your pipeline. Progress, if set, is called with
+// the server status after every poll.
+func (b *BareMetalServerServiceHandler) CreateAndWait(ctx context.Context, bmCreate *BareMetalCreate, options *WaitOptions) (*BareMetalServer, *http.Response, error) { //nolint:lll
+	bm, resp, err := b.Create(ctx, bmCreate)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	err = waitFor(ctx, options, func(ctx context.Context) (string, bool, error) {
+		var errGet error
+		bm, resp, errGet = b.Get(ctx, bm.ID)
+		if errGet != nil {
+			return "", false, errGet
+		}
+
+		return bm.Status, bm.Status == bmStatusActive, nil
+	})
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return bm, resp, nil
+}
+
 // Get information for a Bare Metal instance.
 func (b *BareMetalServerServiceHandler) Get(ctx context.Context, serverID string) (*BareMetalServer, *http.Response, error) {
 	uri := fmt.Sprintf("%s/%s", bmPath, serverID)
@@ -186,6 +252,16 @@ func (b *BareMetalServerServiceHandler) Get(ctx context.Context, serverID string
 	return bms.BareMetal, resp, nil
 }
 
+// WaitForStatus polls a Bare Metal server until its status matches status,
+// such as "active"
+func (b *BareMetalServerServiceHandler) WaitForStatus(ctx context.Context, serverID, status string, options *WaitOptions) (*BareMetalServer, *http.Response, error) { //nolint:lll
+	return waitForStatus(ctx, options, status, func(ctx context.Context) (*BareMetalServer, *http.Response, error) {
+		return b.Get(ctx, serverID)
+	}, func(r *BareMetalServer) []string {
+		return []string{r.Status}
+	})
+}
+
 // Update a Bare Metal server
 func (b *BareMetalServerServiceHandler) Update(ctx context.Context, serverID string, bmReq *BareMetalUpdate) (*BareMetalServer, *http.Response, error) { //nolint:lll
 	uri := fmt.Sprintf("%s/%s", bmPath, serverID)
@@ -237,6 +313,57 @@ func (b *BareMetalServerServiceHandler) List(ctx context.Context, options *ListO
 	return bms.BareMetals, bms.Meta, resp, nil
 }
 
+// ListFiltered returns every Bare Metal server matching the MainIP, Label, Tag
+// and Region of options, the same filters the instance list supports. The
+// filters are sent to the API and also applied to the results, so they work
+// whether or not the API honors them for Bare Metal.
+func (b *BareMetalServerServiceHandler) ListFiltered(ctx context.Context, options *ListOptions) ([]BareMetalServer, error) {
+	filter := ListOptions{}
+	if options != nil {
+		filter = *options
+	}
+
+	servers, err := collectPages(ctx, func(ctx context.Context, pageOptions *ListOptions) ([]BareMetalServer, *Meta, *http.Response, error) {
+		pageOptions.MainIP = filter.MainIP
+		pageOptions.Label = filter.Label
+		pageOptions.Tag = filter.Tag
+		pageOptions.Region = filter.Region
+		return b.List(ctx, pageOptions)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var filtered []BareMetalServer
+	for i := range servers {
+		if servers[i].matches(&filter) {
+			filtered = append(filtered, servers[i])
+		}
+	}
+
+	return filtered, nil
+}
+
+func (b *BareMetalServer) matches(filter *ListOptions) bool {
+	if filter.MainIP != "" && b.MainIP != filter.MainIP {
+		return false
+	}
+
+	if filter.Label != "" && b.Label != filter.Label {
+		return false
+	}
+
+	if filter.Region != "" && b.Region != filter.Region {
+		return false
+	}
+
+	if filter.Tag != "" && !slices.Contains(b.Tags, filter.Tag) {
+		return false
+	}
+
+	return true
+}
+
 // GetBandwidth  used by a Bare Metal server.
 func (b *BareMetalServerServiceHandler) GetBandwidth(ctx context.Context, serverID string) (*Bandwidth, *http.Response, error) {
 	uri := fmt.Sprintf("%s/%s/bandwidth", bmPath, serverID)
@@ -452,6 +579,89 @@ func (b *BareMetalServerServiceHandler) GetUpgrades(ctx context.Context, serverI
 	return upgrades.Upgrades, resp, nil
 }
 
+// GetNetworkInterfaces returns the public interface of a Bare Metal server
+// along with an interface for each attached VPC and VPC 2.0 network. The API
+// does not expose switch or port information.
+func (b *BareMetalServerServiceHandler) GetNetworkInterfaces(ctx context.Context, serverID string) ([]BareMetalNetworkInterface, error) { //nolint:lll
+	bm, _, err := b.Get(ctx, serverID)
+	if err != nil {
+		return nil, err
+	}
+
+	ipv4s, err := collectPages(ctx, func(ctx context.Context, options *ListOptions) ([]IPv4, *Meta, *http.Response, error) {
+		return b.ListIPv4s(ctx, serverID, options)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ipv6s, err := collectPages(ctx, func(ctx context.Context, options *ListOptions) ([]IPv6, *Meta, *http.Response, error) {
+		return b.ListIPv6s(ctx, serverID, options)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	public := BareMetalNetworkInterface{Type: BareMetalInterfacePublic, MacAddress: bm.MAC()}
+	for i := range ipv4s {
+		public.Addresses = append(public.Addresses, ipv4s[i].IP)
+	}
+	for i := range ipv6s {
+		public.Addresses = append(public.Addresses, ipv6s[i].IP)
+	}
+
+	vpcs, _, err := b.ListVPCInfo(ctx, serverID)
+	if err != nil {
+		return nil, err
+	}
+
+	vpc2s, _, err := b.ListVPC2Info(ctx, serverID)
+	if err != nil {
+		return nil, err
+	}
+
+	interfaces := []BareMetalNetworkInterface{public}
+	for i := range vpcs {
+		interfaces = append(interfaces, BareMetalNetworkInterface{
+			Type:       BareMetalInterfaceVPC,
+			MacAddress: vpcs[i].MacAddress,
+			VPCID:      vpcs[i].ID,
+			Addresses:  []string{vpcs[i].IPAddress},
+		})
+	}
+
+	for i := range vpc2s {
+		interfaces = append(interfaces, BareMetalNetworkInterface{
+			Type:       BareMetalInterfaceVPC2,
+			MacAddress: vpc2s[i].MacAddress,
+			VPCID:      vpc2s[i].ID,
+			Addresses:  []string{vpc2s[i].IPAddress},
+		})
+	}
+
+	return interfaces, nil
+}
+
+// ValidateUpgrade checks that the operating system and application in an
+// update are available upgrades for a Bare Metal server, so a re-image can be
+// rejected before Update triggers the reinstall
+func (b *BareMetalServerServiceHandler) ValidateUpgrade(ctx context.Context, serverID string, bmReq *BareMetalUpdate) error {
+	upgrades, _, err := b.GetUpgrades(ctx, serverID)
+	if err != nil {
+		return err
+	}
+
+	if bmReq.OsID != 0 && !upgrades.HasOS(bmReq.OsID) {
+		return fmt.Errorf("os %d is not an available upgrade for bare metal server %s", bmReq.OsID, serverID)
+	}
+
+	if bmReq.AppID != 0 && !upgrades.HasApplication(bmReq.AppID) {
+		return fmt.Errorf("application %d is not an available upgrade for bare metal server %s", bmReq.AppID, serverID)
+	}
+
+	return nil
+}
+
 // ListVPCInfo will list all currently attached VPC IP information for the
 // given bare metal server.
 func (b *BareMetalServerServiceHandler) ListVPCInfo(ctx context.Context, serverID string) ([]VPCInfo, *http.Response, error) {
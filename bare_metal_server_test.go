@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"reflect"
 	"testing"
+	"time"
 )
 
 func TestBareMetalServerServiceHandler_GetServer(t *testing.T) {
@@ -852,3 +853,137 @@ func TestBareMetalServerServiceHandler_DetachVPC2(t *testing.T) {
 		t.Errorf("BareMetalServer.DetachVPC2 returned %+v", err)
 	}
 }
+
+func TestBareMetalServerServiceHandler_CreateAndWait(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v2/bare-metals", func(writer http.ResponseWriter, request *http.Request) {
+		fmt.Fprint(writer, `{"bare_metal": {"id": "900000", "status": "pending"}}`)
+	})
+
+	polls := 0
+	mux.HandleFunc("/v2/bare-metals/900000", func(writer http.ResponseWriter, request *http.Request) {
+		polls++
+		status := "pending"
+		if polls > 1 {
+			status = "active"
+		}
+		fmt.Fprintf(writer, `{"bare_metal": {"id": "900000", "status": %q}}`, status)
+	})
+
+	var progress []string
+	options := &WaitOptions{
+		Interval: time.Millisecond,
+		Progress: func(status string, elapsed time.Duration) {
+			progress = append(progress, status)
+		},
+	}
+
+	bm, _, err := client.BareMetalServer.CreateAndWait(ctx, &BareMetalCreate{Region: "ewr"}, options)
+	if err != nil {
+		t.Errorf("BareMetalServer.CreateAndWait returned %+v", err)
+	}
+
+	expected := &BareMetalServer{ID: "900000", Status: "active"}
+	if !reflect.DeepEqual(bm, expected) {
+		t.Errorf("BareMetalServer.CreateAndWait returned %+v, expected %+v", bm, expected)
+	}
+
+	expectedProgress := []string{"pending", "active"}
+	if !reflect.DeepEqual(progress, expectedProgress) {
+		t.Errorf("BareMetalServer.CreateAndWait progress returned %+v, expected %+v", progress, expectedProgress)
+	}
+}
+
+func TestBareMetalServerServiceHandler_ValidateUpgrade(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v2/bare-metals/dev-preview-abc123/upgrades", func(writer http.ResponseWriter, request *http.Request) {
+		response := `{"upgrades": {"os": [{"id": 127}], "applications": [{"id": 1}]}}`
+		fmt.Fprint(writer, response)
+	})
+
+	if err := client.BareMetalServer.ValidateUpgrade(ctx, "dev-preview-abc123", &BareMetalUpdate{OsID: 127}); err != nil {
+		t.Errorf("BareMetalServer.ValidateUpgrade returned %+v", err)
+	}
+
+	if err := client.BareMetalServer.ValidateUpgrade(ctx, "dev-preview-abc123", &BareMetalUpdate{AppID: 1}); err != nil {
+		t.Errorf("BareMetalServer.ValidateUpgrade returned %+v", err)
+	}
+
+	if err := client.BareMetalServer.ValidateUpgrade(ctx, "dev-preview-abc123", &BareMetalUpdate{OsID: 387}); err == nil {
+		t.Error("BareMetalServer.ValidateUpgrade expected an error for an unavailable os")
+	}
+
+	if err := client.BareMetalServer.ValidateUpgrade(ctx, "dev-preview-abc123", &BareMetalUpdate{AppID: 2}); err == nil {
+		t.Error("BareMetalServer.ValidateUpgrade expected an error for an unavailable application")
+	}
+}
+
+func TestBareMetalServerServiceHandler_GetNetworkInterfaces(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v2/bare-metals/900000", func(writer http.ResponseWriter, request *http.Request) {
+		fmt.Fprint(writer, `{"bare_metal": {"id": "900000", "mac_address": 2199756823533}}`)
+	})
+
+	mux.HandleFunc("/v2/bare-metals/900000/ipv4", func(writer http.ResponseWriter, request *http.Request) {
+		fmt.Fprint(writer, `{"ipv4s": [{"ip": "192.0.2.10"}], "meta": {"total": 1, "links": {"next": "", "prev": ""}}}`)
+	})
+
+	mux.HandleFunc("/v2/bare-metals/900000/ipv6", func(writer http.ResponseWriter, request *http.Request) {
+		fmt.Fprint(writer, `{"ipv6s": [{"ip": "2001:db8::1"}], "meta": {"total": 1, "links": {"next": "", "prev": ""}}}`)
+	})
+
+	mux.HandleFunc("/v2/bare-metals/900000/vpcs", func(writer http.ResponseWriter, request *http.Request) {
+		fmt.Fprint(writer, `{"vpcs": [{"id": "vpc-1", "mac_address": "5a:02:00:00:24:e8", "ip_address": "10.99.0.2"}]}`)
+	})
+
+	mux.HandleFunc("/v2/bare-metals/900000/vpc2", func(writer http.ResponseWriter, request *http.Request) {
+		fmt.Fprint(writer, `{"vpcs": [{"id": "vpc2-1", "mac_address": "5a:02:00:00:24:e9", "ip_address": "10.98.0.3"}]}`)
+	})
+
+	interfaces, err := client.BareMetalServer.GetNetworkInterfaces(ctx, "900000")
+	if err != nil {
+		t.Errorf("BareMetalServer.GetNetworkInterfaces returned %+v", err)
+	}
+
+	expected := []BareMetalNetworkInterface{
+		{Type: BareMetalInterfacePublic, MacAddress: "02:00:2b:b9:5b:ed", Addresses: []string{"192.0.2.10", "2001:db8::1"}},
+		{Type: BareMetalInterfaceVPC, MacAddress: "5a:02:00:00:24:e8", VPCID: "vpc-1", Addresses: []string{"10.99.0.2"}},
+		{Type: BareMetalInterfaceVPC2, MacAddress: "5a:02:00:00:24:e9", VPCID: "vpc2-1", Addresses: []string{"10.98.0.3"}},
+	}
+
+	if !reflect.DeepEqual(interfaces, expected) {
+		t.Errorf("BareMetalServer.GetNetworkInterfaces returned %+v, expected %+v", interfaces, expected)
+	}
+}
+
+func TestBareMetalServerServiceHandler_ListFiltered(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v2/bare-metals", func(writer http.ResponseWriter, request *http.Request) {
+		if tag := request.URL.Query().Get("tag"); tag != "web" {
+			t.Errorf("BareMetalServer.ListFiltered sent tag %q, expected web", tag)
+		}
+		fmt.Fprint(writer, `{"bare_metals": [
+			{"id": "1", "region": "ewr", "label": "web-1", "tags": ["web", "prod"]},
+			{"id": "2", "region": "ewr", "label": "db-1", "tags": ["db"]},
+			{"id": "3", "region": "lax", "label": "web-2", "tags": ["web"]}
+		], "meta": {"total": 3, "links": {"next": "", "prev": ""}}}`)
+	})
+
+	servers, err := client.BareMetalServer.ListFiltered(ctx, &ListOptions{Tag: "web", Region: "ewr"})
+	if err != nil {
+		t.Errorf("BareMetalServer.ListFiltered returned %+v", err)
+	}
+
+	expected := []BareMetalServer{{ID: "1", Region: "ewr", Label: "web-1", Tags: []string{"web", "prod"}}}
+	if !reflect.DeepEqual(servers, expected) {
+		t.Errorf("BareMetalServer.ListFiltered returned %+v, expected %+v", servers, expected)
+	}
+}
@@ -2,8 +2,14 @@ package govultr
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
 
 	"github.com/google/go-querystring/query"
 )
@@ -13,10 +19,20 @@ import (
 type BillingService interface {
 	ListHistory(ctx context.Context, options *ListOptions) ([]History, *Meta, *http.Response, error)
 	ListInvoices(ctx context.Context, options *ListOptions) ([]Invoice, *Meta, *http.Response, error)
+	ListAllInvoices(ctx context.Context) ([]Invoice, error)
 	GetInvoice(ctx context.Context, invoiceID string) (*Invoice, *http.Response, error)
 	ListInvoiceItems(ctx context.Context, invoiceID int, options *ListOptions) ([]InvoiceItem, *Meta, *http.Response, error)
+
+	AttributeInvoice(ctx context.Context, invoiceID int) (*CostReport, error)
+	ArchiveInvoices(ctx context.Context, dir string, since time.Time) (*InvoiceArchive, error)
+
+	WatchPendingCharges(ctx context.Context, threshold float32, interval time.Duration, callback PendingChargesCallback) error
 }
 
+// PendingChargesCallback defines the type of the function called by
+// WatchPendingCharges when pending charges cross the threshold
+type PendingChargesCallback func(account *Account)
+
 // BillingServiceHandler handles interaction with the billing methods for the Vultr API
 type BillingServiceHandler struct {
 	client *Client
@@ -53,6 +69,47 @@ type InvoiceItem struct {
 	Total       float32 `json:"total"`
 }
 
+// ResourceCost represents the invoice items attributed to a live resource
+type ResourceCost struct {
+	ResourceType string
+	ResourceID   string
+	Label        string
+	Total        float32
+	Items        []InvoiceItem
+}
+
+// CostReport represents the spend on an invoice broken down by resource.
+// Orphaned holds the items that could not be matched to a live resource,
+// typically because it has since been destroyed.
+type CostReport struct {
+	InvoiceID     int
+	Resources     []ResourceCost
+	Orphaned      []InvoiceItem
+	OrphanedTotal float32
+}
+
+// InvoiceArchiveEntry is the document written to disk for each invoice by
+// ArchiveInvoices
+type InvoiceArchiveEntry struct {
+	Invoice Invoice       `json:"invoice"`
+	Items   []InvoiceItem `json:"invoice_items"`
+}
+
+// InvoiceArchive reports the outcome of ArchiveInvoices. Skipped holds the
+// invoices already present in the archive directory.
+type InvoiceArchive struct {
+	Archived []int
+	Skipped  []int
+}
+
+// costKey is a string identifying a resource in invoice item descriptions.
+// Keys with a lower rank are more specific and are matched first.
+type costKey struct {
+	value string
+	rank  int
+	index int
+}
+
 type billingHistoryBase struct {
 	History []History `json:"billing_history"`
 	Meta    *Meta     `json:"meta"`
@@ -120,6 +177,12 @@ func (b *BillingServiceHandler) ListInvoices(ctx context.Context, options *ListO
 	return invoices.Invoice, invoices.Meta, resp, nil
 }
 
+// ListAllInvoices retrieves every invoice on the current account, following
+// pagination
+func (b *BillingServiceHandler) ListAllInvoices(ctx context.Context) ([]Invoice, error) {
+	return collectPages(ctx, b.ListInvoices)
+}
+
 // GetInvoice retrieves an invoice that matches the given invoiceID
 func (b *BillingServiceHandler) GetInvoice(ctx context.Context, invoiceID string) (*Invoice, *http.Response, error) {
 	uri := fmt.Sprintf("/v2/billing/invoices/%s", invoiceID)
@@ -161,3 +224,196 @@ func (b *BillingServiceHandler) ListInvoiceItems(ctx context.Context, invoiceID
 
 	return invoice.InvoiceItems, invoice.Meta, resp, nil
 }
+
+// AttributeInvoice joins the items on an invoice to the instances, bare metal
+// servers, block storage and load balancers on the account. Invoice items are
+// not linked to resources by the API, so an item is matched when its
+// description contains a resource's ID, main IP or label, in that order of
+// preference.
+func (b *BillingServiceHandler) AttributeInvoice(ctx context.Context, invoiceID int) (*CostReport, error) {
+	items, err := collectPages(ctx, func(ctx context.Context, options *ListOptions) ([]InvoiceItem, *Meta, *http.Response, error) {
+		return b.ListInvoiceItems(ctx, invoiceID, options)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	report := &CostReport{InvoiceID: invoiceID}
+	var keys []costKey
+	add := func(resourceType, id, label string, ips ...string) {
+		index := len(report.Resources)
+		report.Resources = append(report.Resources, ResourceCost{ResourceType: resourceType, ResourceID: id, Label: label})
+		keys = append(keys, costKey{value: id, rank: 0, index: index})
+		for _, ip := range ips {
+			if ip != "" {
+				keys = append(keys, costKey{value: ip, rank: 1, index: index})
+			}
+		}
+		if label != "" {
+			keys = append(keys, costKey{value: label, rank: 2, index: index})
+		}
+	}
+
+	instances, err := collectPages(ctx, b.client.Instance.List)
+	if err != nil {
+		return nil, err
+	}
+	for i := range instances {
+		add("instance", instances[i].ID, instances[i].Label, instances[i].MainIP)
+	}
+
+	servers, err := collectPages(ctx, b.client.BareMetalServer.List)
+	if err != nil {
+		return nil, err
+	}
+	for i := range servers {
+		add("bare_metal", servers[i].ID, servers[i].Label, servers[i].MainIP)
+	}
+
+	blocks, err := collectPages(ctx, b.client.BlockStorage.List)
+	if err != nil {
+		return nil, err
+	}
+	for i := range blocks {
+		add("block_storage", blocks[i].ID, blocks[i].Label)
+	}
+
+	loadBalancers, err := collectPages(ctx, b.client.LoadBalancer.List)
+	if err != nil {
+		return nil, err
+	}
+	for i := range loadBalancers {
+		add("load_balancer", loadBalancers[i].ID, loadBalancers[i].Label, loadBalancers[i].IPV4)
+	}
+
+	for i := range items {
+		index := -1
+		for rank := 0; rank <= 2 && index < 0; rank++ {
+			for _, key := range keys {
+				if key.rank == rank && strings.Contains(items[i].Description, key.value) {
+					index = key.index
+					break
+				}
+			}
+		}
+
+		if index < 0 {
+			report.Orphaned = append(report.Orphaned, items[i])
+			report.OrphanedTotal += items[i].Total
+			continue
+		}
+
+		report.Resources[index].Items = append(report.Resources[index].Items, items[i])
+		report.Resources[index].Total += items[i].Total
+	}
+
+	resources := report.Resources[:0]
+	for i := range report.Resources {
+		if len(report.Resources[i].Items) > 0 {
+			resources = append(resources, report.Resources[i])
+		}
+	}
+	report.Resources = resources
+
+	return report, nil
+}
+
+// ArchiveInvoices writes every invoice dated at or after since, along with its
+// line items, to dir as invoice-<id>.json. Invoices already in dir are skipped
+// so an interrupted archive can be resumed by calling it again. Files are
+// written to a temporary name first so a partial file is never mistaken for
+// a complete one.
+func (b *BillingServiceHandler) ArchiveInvoices(ctx context.Context, dir string, since time.Time) (*InvoiceArchive, error) {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, err
+	}
+
+	invoices, err := b.ListAllInvoices(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	archive := &InvoiceArchive{}
+	for i := range invoices {
+		date, err := time.Parse(time.RFC3339, invoices[i].Date)
+		if err != nil {
+			return nil, fmt.Errorf("invoice %d: %w", invoices[i].ID, err)
+		}
+
+		if date.Before(since) {
+			continue
+		}
+
+		name := filepath.Join(dir, fmt.Sprintf("invoice-%d.json", invoices[i].ID))
+		if _, err := os.Stat(name); err == nil {
+			archive.Skipped = append(archive.Skipped, invoices[i].ID)
+			continue
+		} else if !errors.Is(err, os.ErrNotExist) {
+			return nil, err
+		}
+
+		invoiceID := invoices[i].ID
+		items, err := collectPages(ctx, func(ctx context.Context, options *ListOptions) ([]InvoiceItem, *Meta, *http.Response, error) {
+			return b.ListInvoiceItems(ctx, invoiceID, options)
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		data, err := json.MarshalIndent(InvoiceArchiveEntry{Invoice: invoices[i], Items: items}, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+
+		if err := os.WriteFile(name+".tmp", data, 0o600); err != nil {
+			return nil, err
+		}
+
+		if err := os.Rename(name+".tmp", name); err != nil {
+			return nil, err
+		}
+
+		archive.Archived = append(archive.Archived, invoiceID)
+	}
+
+	return archive, nil
+}
+
+// WatchPendingCharges polls the account every interval and calls callback each
+// time the pending charges cross from below the threshold to at or above it.
+// The API has no billing alert configuration so this blocks until ctx is done
+// or a request fails, returning the error that stopped it.
+func (b *BillingServiceHandler) WatchPendingCharges(ctx context.Context, threshold float32, interval time.Duration, callback PendingChargesCallback) error { //nolint:lll
+	if interval <= 0 {
+		return fmt.Errorf("watch interval must be positive, got %s", interval)
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	above := false
+	for {
+		account, _, err := b.client.Account.Get(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return err
+		}
+
+		if account.PendingCharges >= threshold {
+			if !above {
+				callback(account)
+			}
+			above = true
+		} else {
+			above = false
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
@@ -1,10 +1,16 @@
 package govultr
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"os"
+	"path/filepath"
 	"reflect"
 	"testing"
+	"time"
 )
 
 func TestBillingServiceHandler_ListHistory(t *testing.T) {
@@ -315,3 +321,181 @@ func TestBillingServiceHandler_ListInvoiceItems(t *testing.T) {
 		t.Errorf("Billing.ListInvoiceItems returned %+v, expected %+v", meta, expectedMeta)
 	}
 }
+
+func TestBillingServiceHandler_WatchPendingCharges(t *testing.T) {
+	setup()
+	defer teardown()
+
+	watchCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	charges := []float32{10, 60, 70, 20, 80}
+	polls := 0
+	mux.HandleFunc("/v2/account", func(writer http.ResponseWriter, request *http.Request) {
+		fmt.Fprintf(writer, `{"account": {"pending_charges": %v}}`, charges[polls])
+		polls++
+	})
+
+	var notified []float32
+	err := client.Billing.WatchPendingCharges(watchCtx, 50, time.Millisecond, func(account *Account) {
+		notified = append(notified, account.PendingCharges)
+		if len(notified) == 2 {
+			cancel()
+		}
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Billing.WatchPendingCharges returned %+v, expected %+v", err, context.Canceled)
+	}
+
+	expected := []float32{60, 80}
+	if !reflect.DeepEqual(notified, expected) {
+		t.Errorf("Billing.WatchPendingCharges notified %+v, expected %+v", notified, expected)
+	}
+
+	if err := client.Billing.WatchPendingCharges(ctx, 50, 0, func(account *Account) {}); err == nil {
+		t.Error("Billing.WatchPendingCharges expected an error for a zero interval")
+	}
+}
+
+func TestBillingServiceHandler_AttributeInvoice(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v2/billing/invoices/123456/items", func(writer http.ResponseWriter, request *http.Request) {
+		response := `{"invoice_items": [
+			{"description": "web-1 (1 vCPU, 1GB RAM) 192.0.2.10", "product": "Vultr Cloud Compute", "total": 5},
+			{"description": "Block Storage 4442ba2f-f5b2-44ea-9b7e-7a7a0d3a1b0d", "product": "Block Storage", "total": 2.5},
+			{"description": "Load Balancer edge", "product": "Load Balancer", "total": 10},
+			{"description": "old-box (2 vCPU, 4GB RAM) 192.0.2.99", "product": "Vultr Cloud Compute", "total": 12}
+		], "meta": {"total": 4, "links": {"next": "", "prev": ""}}}`
+		fmt.Fprint(writer, response)
+	})
+
+	mux.HandleFunc("/v2/instances", func(writer http.ResponseWriter, request *http.Request) {
+		fmt.Fprint(writer, `{"instances": [{"id": "i1", "label": "web", "main_ip": "192.0.2.10"}, {"id": "i2", "label": "web-1", "main_ip": "192.0.2.11"}]}`)
+	})
+
+	mux.HandleFunc("/v2/bare-metals", func(writer http.ResponseWriter, request *http.Request) {
+		fmt.Fprint(writer, `{"bare_metals": []}`)
+	})
+
+	mux.HandleFunc("/v2/blocks", func(writer http.ResponseWriter, request *http.Request) {
+		fmt.Fprint(writer, `{"blocks": [{"id": "4442ba2f-f5b2-44ea-9b7e-7a7a0d3a1b0d", "label": "data"}]}`)
+	})
+
+	mux.HandleFunc("/v2/load-balancers", func(writer http.ResponseWriter, request *http.Request) {
+		fmt.Fprint(writer, `{"load_balancers": [{"id": "lb1", "label": "edge"}]}`)
+	})
+
+	report, err := client.Billing.AttributeInvoice(ctx, 123456)
+	if err != nil {
+		t.Errorf("Billing.AttributeInvoice returned error: %v", err)
+	}
+
+	expected := &CostReport{
+		InvoiceID: 123456,
+		Resources: []ResourceCost{
+			{
+				ResourceType: "instance",
+				ResourceID:   "i1",
+				Label:        "web",
+				Total:        5,
+				Items:        []InvoiceItem{{Description: "web-1 (1 vCPU, 1GB RAM) 192.0.2.10", Product: "Vultr Cloud Compute", Total: 5}},
+			},
+			{
+				ResourceType: "block_storage",
+				ResourceID:   "4442ba2f-f5b2-44ea-9b7e-7a7a0d3a1b0d",
+				Label:        "data",
+				Total:        2.5,
+				Items:        []InvoiceItem{{Description: "Block Storage 4442ba2f-f5b2-44ea-9b7e-7a7a0d3a1b0d", Product: "Block Storage", Total: 2.5}},
+			},
+			{
+				ResourceType: "load_balancer",
+				ResourceID:   "lb1",
+				Label:        "edge",
+				Total:        10,
+				Items:        []InvoiceItem{{Description: "Load Balancer edge", Product: "Load Balancer", Total: 10}},
+			},
+		},
+		Orphaned:      []InvoiceItem{{Description: "old-box (2 vCPU, 4GB RAM) 192.0.2.99", Product: "Vultr Cloud Compute", Total: 12}},
+		OrphanedTotal: 12,
+	}
+
+	if !reflect.DeepEqual(report, expected) {
+		t.Errorf("Billing.AttributeInvoice returned %+v, expected %+v", report, expected)
+	}
+}
+
+func TestBillingServiceHandler_ArchiveInvoices(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v2/billing/invoices", func(writer http.ResponseWriter, request *http.Request) {
+		if request.URL.Query().Get("cursor") == "" {
+			fmt.Fprint(writer, `{"billing_invoices": [
+				{"id": 3, "date": "2024-03-01T00:00:00+00:00", "amount": 10},
+				{"id": 2, "date": "2024-02-01T00:00:00+00:00", "amount": 20}
+			], "meta": {"total": 3, "links": {"next": "page2", "prev": ""}}}`)
+			return
+		}
+		fmt.Fprint(writer, `{"billing_invoices": [
+			{"id": 1, "date": "2024-01-01T00:00:00+00:00", "amount": 30}
+		], "meta": {"total": 3, "links": {"next": "", "prev": ""}}}`)
+	})
+
+	var itemRequests []string
+	for _, id := range []string{"1", "2", "3"} {
+		id := id
+		mux.HandleFunc("/v2/billing/invoices/"+id+"/items", func(writer http.ResponseWriter, request *http.Request) {
+			itemRequests = append(itemRequests, id)
+			fmt.Fprint(writer, `{"invoice_items": [{"description": "instance", "total": 10}], "meta": {"total": 1, "links": {"next": "", "prev": ""}}}`)
+		})
+	}
+
+	invoices, err := client.Billing.ListAllInvoices(ctx)
+	if err != nil {
+		t.Errorf("Billing.ListAllInvoices returned %+v", err)
+	}
+
+	if len(invoices) != 3 {
+		t.Errorf("Billing.ListAllInvoices returned %d invoices, expected 3", len(invoices))
+	}
+
+	dir := t.TempDir()
+	since := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	if err = os.WriteFile(filepath.Join(dir, "invoice-3.json"), []byte("{}"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	archive, err := client.Billing.ArchiveInvoices(ctx, dir, since)
+	if err != nil {
+		t.Fatalf("Billing.ArchiveInvoices returned %+v", err)
+	}
+
+	expected := &InvoiceArchive{Archived: []int{2}, Skipped: []int{3}}
+	if !reflect.DeepEqual(archive, expected) {
+		t.Errorf("Billing.ArchiveInvoices returned %+v, expected %+v", archive, expected)
+	}
+
+	if !reflect.DeepEqual(itemRequests, []string{"2"}) {
+		t.Errorf("Billing.ArchiveInvoices requested items for %+v, expected [2]", itemRequests)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "invoice-2.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	entry := InvoiceArchiveEntry{}
+	if err = json.Unmarshal(data, &entry); err != nil {
+		t.Fatal(err)
+	}
+
+	expectedEntry := InvoiceArchiveEntry{
+		Invoice: Invoice{ID: 2, Date: "2024-02-01T00:00:00+00:00", Amount: 20},
+		Items:   []InvoiceItem{{Description: "instance", Total: 10}},
+	}
+	if !reflect.DeepEqual(entry, expectedEntry) {
+		t.Errorf("Billing.ArchiveInvoices wrote %+v, expected %+v", entry, expectedEntry)
+	}
+}
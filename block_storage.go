@@ -2,23 +2,61 @@ package govultr
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 
 	"github.com/google/go-querystring/query"
 )
 
+// Block storage types accepted by the BlockStorageCreate BlockType field
+const (
+	BlockTypeHighPerf   = "high_perf"
+	BlockTypeStorageOpt = "storage_opt"
+)
+
+const blockStatusActive = "active"
+
+// BlockStorageClass describes the documented characteristics of a block storage
+// type. Vultr does not publish per-size IOPS or throughput figures, so only the
+// storage media and size limits are described.
+type BlockStorageClass struct {
+	Type      string
+	Media     string
+	MinSizeGB int
+	MaxSizeGB int
+}
+
+var blockStorageClasses = map[string]BlockStorageClass{
+	BlockTypeHighPerf:   {Type: BlockTypeHighPerf, Media: "nvme", MinSizeGB: 10, MaxSizeGB: 10000},
+	BlockTypeStorageOpt: {Type: BlockTypeStorageOpt, Media: "hdd", MinSizeGB: 40, MaxSizeGB: 40000},
+}
+
+// BlockStorageClasses returns the block storage types offered by Vultr keyed by
+// type. The map is a copy and may be modified by the caller.
+func BlockStorageClasses() map[string]BlockStorageClass {
+	classes := make(map[string]BlockStorageClass, len(blockStorageClasses))
+	for blockType, class := range blockStorageClasses {
+		classes[blockType] = class
+	}
+	return classes
+}
+
 // BlockStorageService is the interface to interact with Block-Storage endpoint on the Vultr API
 // Link : https://www.vultr.com/api/#tag/block
 type BlockStorageService interface {
 	Create(ctx context.Context, blockReq *BlockStorageCreate) (*BlockStorage, *http.Response, error)
 	Get(ctx context.Context, blockID string) (*BlockStorage, *http.Response, error)
+	WaitForStatus(ctx context.Context, blockID, status string, options *WaitOptions) (*BlockStorage, *http.Response, error)
+	WaitForAttachment(ctx context.Context, blockID, instanceID string, options *WaitOptions) (*BlockStorage, *http.Response, error) //nolint:lll
 	Update(ctx context.Context, blockID string, blockReq *BlockStorageUpdate) error
 	Delete(ctx context.Context, blockID string) error
 	List(ctx context.Context, options *ListOptions) ([]BlockStorage, *Meta, *http.Response, error)
 
 	Attach(ctx context.Context, blockID string, attach *BlockStorageAttach) error
 	Detach(ctx context.Context, blockID string, detach *BlockStorageDetach) error
+
+	Migrate(ctx context.Context, blockID string, migrateReq *BlockStorageMigrateReq, options *WaitOptions) (*BlockStorage, error)
 }
 
 // BlockStorageServiceHandler handles interaction with the block-storage methods for the Vultr API
@@ -40,6 +78,21 @@ type BlockStorage struct {
 	BlockType          string  `json:"block_type"`
 }
 
+// Class returns the characteristics of the block storage type. The second value
+// is false if the type is unknown.
+func (b *BlockStorage) Class() (BlockStorageClass, bool) {
+	class, ok := blockStorageClasses[b.BlockType]
+	return class, ok
+}
+
+// ValidateSize returns an error if sizeGB is outside of the limits of the class
+func (c BlockStorageClass) ValidateSize(sizeGB int) error {
+	if sizeGB < c.MinSizeGB || sizeGB > c.MaxSizeGB {
+		return fmt.Errorf("%s block storage must be between %d and %d GB, got %d", c.Type, c.MinSizeGB, c.MaxSizeGB, sizeGB)
+	}
+	return nil
+}
+
 // BlockStorageCreate struct is used for creating Block Storage.
 type BlockStorageCreate struct {
 	Region    string `json:"region"`
@@ -65,6 +118,23 @@ type BlockStorageDetach struct {
 	Live *bool `json:"live,omitempty"`
 }
 
+// BlockStorageCopyFunc defines the type of the function Migrate calls to copy
+// the data of the source volume onto the target volume, typically by running a
+// tool such as rsync or dd on the instances the volumes are attached to
+type BlockStorageCopyFunc func(ctx context.Context, source, target *BlockStorage) error
+
+// BlockStorageMigrateReq is used by Migrate. Label defaults to the label of the
+// source volume. When SourceInstanceID or TargetInstanceID is set the volume in
+// that region is live attached to the instance for the copy and detached
+// afterwards.
+type BlockStorageMigrateReq struct {
+	Region           string
+	Label            string
+	SourceInstanceID string
+	TargetInstanceID string
+	Copy             BlockStorageCopyFunc
+}
+
 type blockStoragesBase struct {
 	Blocks []BlockStorage `json:"blocks"`
 	Meta   *Meta          `json:"meta"`
@@ -110,6 +180,40 @@ func (b *BlockStorageServiceHandler) Get(ctx context.Context, blockID string) (*
 	return block.Block, resp, nil
 }
 
+// WaitForStatus polls a block storage volume until its status matches
+// status, such as "active"
+func (b *BlockStorageServiceHandler) WaitForStatus(ctx context.Context, blockID, status string, options *WaitOptions) (*BlockStorage, *http.Response, error) { //nolint:lll
+	return waitForStatus(ctx, options, status, func(ctx context.Context) (*BlockStorage, *http.Response, error) {
+		return b.Get(ctx, blockID)
+	}, func(r *BlockStorage) []string {
+		return []string{r.Status}
+	})
+}
+
+// WaitForAttachment polls a block storage volume until it is attached to
+// instanceID, or detached when instanceID is empty
+func (b *BlockStorageServiceHandler) WaitForAttachment(ctx context.Context, blockID, instanceID string, options *WaitOptions) (*BlockStorage, *http.Response, error) { //nolint:lll
+	var block *BlockStorage
+	var resp *http.Response
+	err := waitFor(ctx, options, func(ctx context.Context) (string, bool, error) {
+		var errGet error
+		block, resp, errGet = b.Get(ctx, blockID)
+		if errGet != nil {
+			return "", false, errGet
+		}
+
+		if block.AttachedToInstance == "" {
+			return "detached", instanceID == "", nil
+		}
+		return "attached to " + block.AttachedToInstance, block.AttachedToInstance == instanceID, nil
+	})
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return block, resp, nil
+}
+
 // Update a block storage subscription.
 func (b *BlockStorageServiceHandler) Update(ctx context.Context, blockID string, blockReq *BlockStorageUpdate) error {
 	uri := fmt.Sprintf("/v2/blocks/%s", blockID)
@@ -184,3 +288,100 @@ func (b *BlockStorageServiceHandler) Detach(ctx context.Context, blockID string,
 	_, err = b.client.DoWithContext(ctx, req, nil)
 	return err
 }
+
+// Migrate copies a volume to another region. Block storage has no snapshots, so
+// a volume of the same size and type is created in the target region and the
+// data is moved by migrateReq.Copy over the attach and copy path once both
+// volumes are attached. The source volume is left in place, and the target volume is returned along with any
+// error so a failed copy can be inspected or retried.
+func (b *BlockStorageServiceHandler) Migrate(ctx context.Context, blockID string, migrateReq *BlockStorageMigrateReq, options *WaitOptions) (*BlockStorage, error) { //nolint:lll
+	if migrateReq == nil {
+		return nil, errors.New("a migrate request is required")
+	}
+
+	if migrateReq.Copy == nil {
+		return nil, errors.New("a copy function is required")
+	}
+
+	source, _, err := b.Get(ctx, blockID)
+	if err != nil {
+		return nil, err
+	}
+
+	if source.Region == migrateReq.Region {
+		return nil, fmt.Errorf("block storage %s is already in %s", blockID, migrateReq.Region)
+	}
+
+	if migrateReq.SourceInstanceID != "" && source.AttachedToInstance != "" && source.AttachedToInstance != migrateReq.SourceInstanceID {
+		return nil, fmt.Errorf("block storage %s is attached to instance %s", blockID, source.AttachedToInstance)
+	}
+
+	label := migrateReq.Label
+	if label == "" {
+		label = source.Label
+	}
+
+	target, _, err := b.Create(ctx, &BlockStorageCreate{
+		Region:    migrateReq.Region,
+		SizeGB:    source.SizeGB,
+		Label:     label,
+		BlockType: source.BlockType,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	targetID := target.ID
+	err = waitFor(ctx, options, func(ctx context.Context) (string, bool, error) {
+		var errGet error
+		target, _, errGet = b.Get(ctx, targetID)
+		if errGet != nil {
+			return "", false, errGet
+		}
+		return target.Status, target.Status == blockStatusActive, nil
+	})
+	if err != nil {
+		return target, err
+	}
+
+	live := true
+	var attached []string
+	if migrateReq.SourceInstanceID != "" && source.AttachedToInstance == "" {
+		if err = b.Attach(ctx, source.ID, &BlockStorageAttach{InstanceID: migrateReq.SourceInstanceID, Live: &live}); err != nil {
+			return target, err
+		}
+		attached = append(attached, source.ID)
+
+		if source, _, err = b.WaitForAttachment(ctx, source.ID, migrateReq.SourceInstanceID, options); err != nil {
+			return target, errors.Join(err, b.detachAll(ctx, attached))
+		}
+	}
+
+	if migrateReq.TargetInstanceID != "" {
+		if err = b.Attach(ctx, target.ID, &BlockStorageAttach{InstanceID: migrateReq.TargetInstanceID, Live: &live}); err != nil {
+			return target, errors.Join(err, b.detachAll(ctx, attached))
+		}
+		attached = append(attached, target.ID)
+
+		attachedTarget, _, errWait := b.WaitForAttachment(ctx, target.ID, migrateReq.TargetInstanceID, options)
+		if errWait != nil {
+			return target, errors.Join(errWait, b.detachAll(ctx, attached))
+		}
+		target = attachedTarget
+	}
+
+	err = migrateReq.Copy(ctx, source, target)
+	return target, errors.Join(err, b.detachAll(ctx, attached))
+}
+
+// detachAll live detaches each volume, returning every error
+func (b *BlockStorageServiceHandler) detachAll(ctx context.Context, blockIDs []string) error {
+	live := true
+	var errs []error
+	for _, blockID := range blockIDs {
+		if err := b.Detach(ctx, blockID, &BlockStorageDetach{Live: &live}); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -1,10 +1,13 @@
 package govultr
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"reflect"
 	"testing"
+	"time"
 )
 
 func TestBlockStorageServiceHandler_Create(t *testing.T) {
@@ -184,3 +187,147 @@ func TestBlockStorageServiceHandler_Detach(t *testing.T) {
 		t.Errorf("BlockStorage.Detach returned %+v, expected %+v", err, nil)
 	}
 }
+
+func TestBlockStorage_Class(t *testing.T) {
+	block := &BlockStorage{ID: "123456", SizeGB: 50, BlockType: BlockTypeStorageOpt}
+
+	class, ok := block.Class()
+	if !ok {
+		t.Fatal("BlockStorage.Class returned no class for storage_opt")
+	}
+
+	expected := BlockStorageClass{Type: BlockTypeStorageOpt, Media: "hdd", MinSizeGB: 40, MaxSizeGB: 40000}
+	if !reflect.DeepEqual(class, expected) {
+		t.Errorf("BlockStorage.Class returned %+v, expected %+v", class, expected)
+	}
+
+	if err := class.ValidateSize(block.SizeGB); err != nil {
+		t.Errorf("BlockStorageClass.ValidateSize returned %+v", err)
+	}
+
+	classes := BlockStorageClasses()
+	delete(classes, BlockTypeHighPerf)
+	if _, ok = (&BlockStorage{BlockType: BlockTypeHighPerf}).Class(); !ok {
+		t.Error("BlockStorage.Class was affected by a change to the map returned by BlockStorageClasses")
+	}
+
+	if err := BlockStorageClasses()[BlockTypeHighPerf].ValidateSize(20000); err == nil {
+		t.Error("BlockStorageClass.ValidateSize expected an error for a size above the maximum")
+	}
+
+	block.BlockType = "unknown"
+	if _, ok = block.Class(); ok {
+		t.Error("BlockStorage.Class returned a class for an unknown type")
+	}
+}
+
+func TestBlockStorageServiceHandler_Migrate(t *testing.T) {
+	setup()
+	defer teardown()
+
+	// Attachments show up on the second GET after the attach request
+	attachedTo := map[string]string{}
+	pending := map[string]int{}
+	attachment := func(id string) string {
+		if pending[id] > 0 {
+			pending[id]--
+			return ""
+		}
+		return attachedTo[id]
+	}
+
+	mux.HandleFunc("/v2/blocks/src", func(writer http.ResponseWriter, request *http.Request) {
+		fmt.Fprintf(writer, `{"block": {"id": "src", "status": "active", "size_gb": 50, "region": "ewr", "label": "data", "block_type": "high_perf", "attached_to_instance": %q}}`, attachment("src")) //nolint:lll
+	})
+
+	var created BlockStorageCreate
+	mux.HandleFunc("/v2/blocks", func(writer http.ResponseWriter, request *http.Request) {
+		json.NewDecoder(request.Body).Decode(&created)
+		fmt.Fprint(writer, `{"block": {"id": "dst", "status": "pending", "region": "lax"}}`)
+	})
+
+	mux.HandleFunc("/v2/blocks/dst", func(writer http.ResponseWriter, request *http.Request) {
+		fmt.Fprintf(writer, `{"block": {"id": "dst", "status": "active", "size_gb": 50, "region": "lax", "label": "data", "block_type": "high_perf", "attached_to_instance": %q}}`, attachment("dst")) //nolint:lll
+	})
+
+	var calls []string
+	for _, id := range []string{"src", "dst"} {
+		id := id
+		for _, action := range []string{"attach", "detach"} {
+			action := action
+			mux.HandleFunc("/v2/blocks/"+id+"/"+action, func(writer http.ResponseWriter, request *http.Request) {
+				calls = append(calls, action+" "+id)
+				if action == "attach" {
+					var attach BlockStorageAttach
+					json.NewDecoder(request.Body).Decode(&attach)
+					attachedTo[id], pending[id] = attach.InstanceID, 1
+				}
+			})
+		}
+	}
+
+	migrateReq := &BlockStorageMigrateReq{
+		Region:           "lax",
+		SourceInstanceID: "ewr-instance",
+		TargetInstanceID: "lax-instance",
+		Copy: func(ctx context.Context, source, target *BlockStorage) error {
+			if source.AttachedToInstance != "ewr-instance" || target.AttachedToInstance != "lax-instance" {
+				t.Errorf("BlockStorage.Migrate copied before the volumes were attached: %+v, %+v", source, target)
+			}
+			calls = append(calls, "copy "+source.ID+" "+target.ID)
+			return nil
+		},
+	}
+
+	if _, err := client.BlockStorage.Migrate(ctx, "src", nil, nil); err == nil {
+		t.Error("BlockStorage.Migrate expected an error for a nil request")
+	}
+
+	target, err := client.BlockStorage.Migrate(ctx, "src", migrateReq, &WaitOptions{Interval: time.Millisecond})
+	if err != nil {
+		t.Errorf("BlockStorage.Migrate returned %+v", err)
+	}
+
+	if target.ID != "dst" || target.Status != "active" {
+		t.Errorf("BlockStorage.Migrate returned %+v, expected active block dst", target)
+	}
+
+	expectedCreate := BlockStorageCreate{Region: "lax", SizeGB: 50, Label: "data", BlockType: "high_perf"}
+	if !reflect.DeepEqual(created, expectedCreate) {
+		t.Errorf("BlockStorage.Migrate created %+v, expected %+v", created, expectedCreate)
+	}
+
+	expectedCalls := []string{"attach src", "attach dst", "copy src dst", "detach src", "detach dst"}
+	if !reflect.DeepEqual(calls, expectedCalls) {
+		t.Errorf("BlockStorage.Migrate made calls %+v, expected %+v", calls, expectedCalls)
+	}
+}
+
+func TestBlockStorageServiceHandler_WaitForAttachment(t *testing.T) {
+	setup()
+	defer teardown()
+
+	gets := 0
+	mux.HandleFunc("/v2/blocks/123456", func(writer http.ResponseWriter, request *http.Request) {
+		gets++
+		attached := ""
+		if gets > 1 {
+			attached = "instance"
+		}
+		fmt.Fprintf(writer, `{"block": {"id": "123456", "status": "active", "attached_to_instance": "%s"}}`, attached)
+	})
+
+	block, _, err := client.BlockStorage.WaitForAttachment(ctx, "123456", "instance", &WaitOptions{Interval: time.Millisecond})
+	if err != nil {
+		t.Fatalf("BlockStorage.WaitForAttachment returned %+v", err)
+	}
+
+	if block.AttachedToInstance != "instance" || gets != 2 {
+		t.Errorf("BlockStorage.WaitForAttachment returned %+v after %d polls", block, gets)
+	}
+
+	block, _, err = client.BlockStorage.WaitForStatus(ctx, "123456", "active", &WaitOptions{Interval: time.Millisecond})
+	if err != nil || block.Status != "active" {
+		t.Errorf("BlockStorage.WaitForStatus returned %+v, %+v", block, err)
+	}
+}
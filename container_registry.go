@@ -2,8 +2,16 @@ package govultr
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
+	gopath "path"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/google/go-querystring/query"
 )
@@ -11,11 +19,23 @@ import (
 const vcrPath = "/v2/registry"
 const vcrListPath = "/v2/registries"
 
+// Statuses reported to WaitOptions.Progress by CreateAndWait
+const (
+	vcrStatusProvisioning = "provisioning"
+	vcrStatusCredentials  = "awaiting credentials"
+	vcrStatusReady        = "ready"
+)
+
+// vcrProbeExpiry is the lifetime in seconds of the read-only credentials
+// minted by CreateAndWait to check that a registry is usable
+const vcrProbeExpiry = 60
+
 // ContainerRegistryService is the interface to interact with the container
 // registry endpoints on the Vultr API.  Link :
 // https://www.vultr.com/api/#tag/Container-Registry
 type ContainerRegistryService interface {
 	Create(ctx context.Context, createReq *ContainerRegistryReq) (*ContainerRegistry, *http.Response, error)
+	CreateAndWait(ctx context.Context, createReq *ContainerRegistryReq, options *WaitOptions) (*ContainerRegistry, *http.Response, error) //nolint:lll
 	Get(ctx context.Context, vcrID string) (*ContainerRegistry, *http.Response, error)
 	Update(ctx context.Context, vcrID string, updateReq *ContainerRegistryUpdateReq) (*ContainerRegistry, *http.Response, error)
 	Delete(ctx context.Context, vcrID string) error
@@ -24,9 +44,16 @@ type ContainerRegistryService interface {
 	GetRepository(ctx context.Context, vcrID, imageName string) (*ContainerRegistryRepo, *http.Response, error)
 	UpdateRepository(ctx context.Context, vcrID, imageName string, updateReq *ContainerRegistryRepoUpdateReq) (*ContainerRegistryRepo, *http.Response, error) //nolint:lll
 	DeleteRepository(ctx context.Context, vcrID, imageName string) error
+	ListRobots(ctx context.Context, vcrID string, options *ListOptions) ([]ContainerRegistryRobot, *Meta, *http.Response, error)
+	GetRobot(ctx context.Context, vcrID, robotName string) (*ContainerRegistryRobot, *http.Response, error)
+	UpdateRobot(ctx context.Context, vcrID, robotName string, updateReq *ContainerRegistryRobotReq) (*ContainerRegistryRobot, *http.Response, error) //nolint:lll
+	DeleteRobot(ctx context.Context, vcrID, robotName string) error
+	PruneRepositories(ctx context.Context, vcrID string, policy *ContainerRegistryRetentionPolicy) ([]ContainerRegistryRepo, error)
 	CreateDockerCredentials(ctx context.Context, vcrID string, createOptions *DockerCredentialsOpt) (*ContainerRegistryDockerCredentials, *http.Response, error) //nolint:lll
 	ListRegions(ctx context.Context) ([]ContainerRegistryRegion, *Meta, *http.Response, error)
 	ListPlans(ctx context.Context) (*ContainerRegistryPlans, *http.Response, error)
+
+	CreateDockerCredentialsKubernetes(ctx context.Context, vcrID string, createOptions *KubernetesCredentialsOpt) (*ContainerRegistryKubernetesCredentials, *http.Response, error) //nolint:lll
 }
 
 // ContainerRegistryServiceHandler handles interaction between the container
@@ -121,11 +148,170 @@ type ContainerRegistryRepo struct {
 	ArtifactCount int    `json:"artifact_count"`
 }
 
+// Vulnerability severities reported in a ContainerRegistryScanSummary, from
+// least to most severe
+const (
+	ScanSeverityUnknown  = "unknown"
+	ScanSeverityLow      = "low"
+	ScanSeverityMedium   = "medium"
+	ScanSeverityHigh     = "high"
+	ScanSeverityCritical = "critical"
+)
+
+var scanSeverityRank = map[string]int{
+	ScanSeverityUnknown:  0,
+	ScanSeverityLow:      1,
+	ScanSeverityMedium:   2,
+	ScanSeverityHigh:     3,
+	ScanSeverityCritical: 4,
+}
+
+// ContainerRegistryScanSummary represents the results of a vulnerability scan
+// of an image. The API does not expose image scanning yet; this is defined so
+// scan results can be attached to repositories and artifacts once it does
+// without a breaking change.
+type ContainerRegistryScanSummary struct {
+	Status          string                               `json:"status"`
+	DateScanned     string                               `json:"scanned_at"`
+	SeverityCounts  map[string]int                       `json:"severity_counts"`
+	Vulnerabilities []ContainerRegistryScanVulnerability `json:"vulnerabilities,omitempty"`
+}
+
+// ContainerRegistryScanVulnerability represents a single CVE found by a scan
+type ContainerRegistryScanVulnerability struct {
+	ID             string   `json:"id"`
+	Severity       string   `json:"severity"`
+	Package        string   `json:"package"`
+	Version        string   `json:"version"`
+	FixVersion     string   `json:"fix_version,omitempty"`
+	Description    string   `json:"description,omitempty"`
+	ReferenceLinks []string `json:"links,omitempty"`
+}
+
+// AtLeast returns the number of vulnerabilities of the given severity or worse,
+// e.g. to fail a pipeline when any high or critical CVEs are found
+func (s *ContainerRegistryScanSummary) AtLeast(severity string) int {
+	count := 0
+	for sev, n := range s.SeverityCounts {
+		if scanSeverityRank[sev] >= scanSeverityRank[severity] {
+			count += n
+		}
+	}
+	return count
+}
+
+// ContainerRegistryRetentionPolicy selects the repositories PruneRepositories
+// deletes. Only repositories whose image matches NameGlob, or all of them if
+// it is empty, are considered. A zero MaxAgeDays or KeepLatestN selects
+// nothing rather than everything, so a policy with neither set is refused.
+type ContainerRegistryRetentionPolicy struct {
+	// NameGlob is a path.Match pattern for the repository image name
+	NameGlob string
+	// MaxAgeDays deletes repositories not updated within this many days, 0 disables the age limit
+	MaxAgeDays int
+	// KeepLatestN deletes all but the N most recently updated repositories, 0 disables the count limit
+	KeepLatestN int
+	// DryRun reports the repositories that would be deleted without deleting them
+	DryRun bool
+}
+
 type containerRegistryRepos struct {
 	Repositories []ContainerRegistryRepo `json:"repositories"`
 	Meta         *Meta                   `json:"meta"`
 }
 
+// Robot permission actions
+const (
+	RobotActionPull = "pull"
+	RobotActionPush = "push"
+)
+
+// ContainerRegistryRobot represents a robot account of a registry, used by
+// automation to pull or push images with scoped permissions
+type ContainerRegistryRobot struct {
+	Name        string                             `json:"name"`
+	Description string                             `json:"description"`
+	Secret      string                             `json:"secret"`
+	Disable     bool                               `json:"disable"`
+	Duration    int                                `json:"duration"`
+	DateCreated string                             `json:"creation_time"`
+	Permissions []ContainerRegistryRobotPermission `json:"permissions"`
+}
+
+// ContainerRegistryRobotPermission represents the access of a robot to a
+// registry namespace
+type ContainerRegistryRobotPermission struct {
+	Kind      string                         `json:"kind"`
+	Namespace string                         `json:"namespace"`
+	Access    []ContainerRegistryRobotAccess `json:"access"`
+}
+
+// ContainerRegistryRobotAccess represents a single action a robot may take
+type ContainerRegistryRobotAccess struct {
+	Action   string `json:"action"`
+	Resource string `json:"resource"`
+	Effect   string `json:"effect,omitempty"`
+}
+
+// ContainerRegistryRobotReq is the data to update a registry robot
+type ContainerRegistryRobotReq struct {
+	Description string                             `json:"description,omitempty"`
+	Disable     *bool                              `json:"disable,omitempty"`
+	Duration    *int                               `json:"duration,omitempty"`
+	Permissions []ContainerRegistryRobotPermission `json:"permissions,omitempty"`
+}
+
+type containerRegistryRobots struct {
+	Robots []ContainerRegistryRobot `json:"robots"`
+	Meta   *Meta                    `json:"meta"`
+}
+
+// NewRobotPermission returns a permission to pull from the repositories of a
+// registry namespace, usually the registry name, and to push to them when
+// write is set. The API scopes robots per namespace rather than per
+// repository.
+func NewRobotPermission(namespace string, write bool) ContainerRegistryRobotPermission {
+	permission := ContainerRegistryRobotPermission{
+		Kind:      "project",
+		Namespace: namespace,
+		Access:    []ContainerRegistryRobotAccess{{Action: RobotActionPull, Resource: "repository"}},
+	}
+	if write {
+		permission.Access = append(permission.Access, ContainerRegistryRobotAccess{Action: RobotActionPush, Resource: "repository"})
+	}
+
+	return permission
+}
+
+// CanPush reports whether the robot may push to namespace
+func (r *ContainerRegistryRobot) CanPush(namespace string) bool {
+	return r.allows(namespace, RobotActionPush)
+}
+
+// CanPull reports whether the robot may pull from namespace
+func (r *ContainerRegistryRobot) CanPull(namespace string) bool {
+	return r.allows(namespace, RobotActionPull)
+}
+
+func (r *ContainerRegistryRobot) allows(namespace, action string) bool {
+	if r.Disable {
+		return false
+	}
+
+	for _, permission := range r.Permissions {
+		if permission.Namespace != namespace {
+			continue
+		}
+		for _, access := range permission.Access {
+			if access.Action == action && access.Resource == "repository" && access.Effect != "deny" {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
 // ContainerRegistryRepoUpdateReq is the data to update a registry repository
 type ContainerRegistryRepoUpdateReq struct {
 	Description string `json:"description"`
@@ -137,6 +323,22 @@ type DockerCredentialsOpt struct {
 	WriteAccess   *bool
 }
 
+// KubernetesCredentialsOpt contains the options used to create Docker
+// credentials as a Kubernetes Secret
+type KubernetesCredentialsOpt struct {
+	ExpirySeconds *int
+	WriteAccess   *bool
+	Base64Encode  *bool
+}
+
+// ContainerRegistryKubernetesCredentials represents the YAML manifest of a
+// Kubernetes Secret holding Docker credentials, ready to be applied
+type ContainerRegistryKubernetesCredentials []byte
+
+func (c *ContainerRegistryKubernetesCredentials) String() string {
+	return string(*c)
+}
+
 // ContainerRegistryDockerCredentials represents the byte array of character
 // data returned after creating a Docker credential
 type ContainerRegistryDockerCredentials []byte
@@ -153,6 +355,78 @@ func (c *ContainerRegistryDockerCredentials) String() string {
 	return string(*c)
 }
 
+// Decode parses the Docker config JSON of the credentials into the registry
+// they are for and the login they hold
+func (c *ContainerRegistryDockerCredentials) Decode() (*ContainerRegistryDockerAuth, error) {
+	config := new(dockerConfig)
+	if err := json.Unmarshal(*c, config); err != nil {
+		return nil, fmt.Errorf("decoding docker credentials: %w", err)
+	}
+
+	if len(config.Auths) > 1 {
+		return nil, fmt.Errorf("decoding docker credentials: expected one registry, got %d", len(config.Auths))
+	}
+
+	for registry, entry := range config.Auths {
+		return newDockerAuth(registry, entry)
+	}
+
+	return nil, fmt.Errorf("decoding docker credentials: no registry")
+}
+
+func newDockerAuth(registry string, entry dockerConfigAuth) (*ContainerRegistryDockerAuth, error) {
+	auth := &ContainerRegistryDockerAuth{Registry: registry, Username: entry.Username, Password: entry.Password, Auth: entry.Auth}
+	if auth.Username == "" && auth.Auth != "" {
+		login, err := base64.StdEncoding.DecodeString(auth.Auth)
+		if err != nil {
+			return nil, fmt.Errorf("decoding docker credentials auth: %w", err)
+		}
+
+		username, password, ok := strings.Cut(string(login), ":")
+		if !ok {
+			return nil, fmt.Errorf("decoding docker credentials auth: expected username:password")
+		}
+		auth.Username, auth.Password = username, password
+	}
+
+	if auth.Auth == "" {
+		auth.Auth = base64.StdEncoding.EncodeToString([]byte(auth.Username + ":" + auth.Password))
+	}
+
+	return auth, nil
+}
+
+// ContainerRegistryDockerAuth represents Docker credentials for a single
+// registry. Auth is the base64 encoded "username:password" token docker
+// login stores. The API does not say when the credentials expire, that is
+// the ExpirySeconds they were created with.
+type ContainerRegistryDockerAuth struct {
+	Registry string
+	Username string
+	Password string
+	Auth     string
+}
+
+// DockerConfigJSON returns the credentials as a Docker config.json, as used
+// for the .dockerconfigjson key of a Kubernetes imagePullSecret
+func (a *ContainerRegistryDockerAuth) DockerConfigJSON() ([]byte, error) {
+	return json.Marshal(&dockerConfig{
+		Auths: map[string]dockerConfigAuth{
+			a.Registry: {Username: a.Username, Password: a.Password, Auth: a.Auth},
+		},
+	})
+}
+
+type dockerConfig struct {
+	Auths map[string]dockerConfigAuth `json:"auths"`
+}
+
+type dockerConfigAuth struct {
+	Username string `json:"username,omitempty"`
+	Password string `json:"password,omitempty"`
+	Auth     string `json:"auth,omitempty"`
+}
+
 // ContainerRegistryRegion represents the region data
 type ContainerRegistryRegion struct {
 	ID           int                               `json:"id"`
@@ -257,6 +531,46 @@ func (h *ContainerRegistryServiceHandler) Create(ctx context.Context, createReq
 	return vcr, resp, nil
 }
 
+// CreateAndWait creates a new container registry and polls until it has a URN
+// and docker credentials can be created for it, so it is ready to be pushed
+// to. The credentials created to check this are read-only and expire after a
+// minute. Progress, if set, is called with the registry status after every
+// poll.
+func (h *ContainerRegistryServiceHandler) CreateAndWait(ctx context.Context, createReq *ContainerRegistryReq, options *WaitOptions) (*ContainerRegistry, *http.Response, error) { //nolint:lll
+	vcr, resp, err := h.Create(ctx, createReq)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	vcrID := vcr.ID
+	err = waitFor(ctx, options, func(ctx context.Context) (string, bool, error) {
+		var errGet error
+		vcr, resp, errGet = h.Get(ctx, vcrID)
+		if errGet != nil {
+			return "", false, errGet
+		}
+
+		if vcr.URN == "" {
+			return vcrStatusProvisioning, false, nil
+		}
+
+		expiry, writeAccess := vcrProbeExpiry, false
+		_, _, errCreds := h.CreateDockerCredentials(ctx, vcrID, &DockerCredentialsOpt{ExpirySeconds: &expiry, WriteAccess: &writeAccess})
+		switch category := ErrorCategoryOf(errCreds); {
+		case errCreds == nil:
+			return vcrStatusReady, true, nil
+		case category == ErrorCategoryUnknown || category == ErrorCategoryAuthZ:
+			return "", false, errCreds
+		}
+		return vcrStatusCredentials, false, nil
+	})
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return vcr, resp, nil
+}
+
 // Update will update an existing container registry
 func (h *ContainerRegistryServiceHandler) Update(ctx context.Context, vcrID string, updateReq *ContainerRegistryUpdateReq) (*ContainerRegistry, *http.Response, error) { //nolint:lll
 	req, errReq := h.client.NewRequest(ctx, http.MethodPut, fmt.Sprintf("%s/%s", vcrPath, vcrID), updateReq)
@@ -361,6 +675,142 @@ func (h *ContainerRegistryServiceHandler) DeleteRepository(ctx context.Context,
 	return nil
 }
 
+// ListRobots will get a list of the robot accounts of a registry
+func (h *ContainerRegistryServiceHandler) ListRobots(ctx context.Context, vcrID string, options *ListOptions) ([]ContainerRegistryRobot, *Meta, *http.Response, error) { //nolint:lll,dupl
+	req, errReq := h.client.NewRequest(ctx, http.MethodGet, fmt.Sprintf("%s/%s/robots", vcrPath, vcrID), nil)
+	if errReq != nil {
+		return nil, nil, nil, errReq
+	}
+
+	qStrings, errQ := query.Values(options)
+	if errQ != nil {
+		return nil, nil, nil, errQ
+	}
+
+	req.URL.RawQuery = qStrings.Encode()
+
+	vcrRobots := new(containerRegistryRobots)
+	resp, errResp := h.client.DoWithContext(ctx, req, &vcrRobots)
+	if errResp != nil {
+		return nil, nil, resp, errResp
+	}
+
+	return vcrRobots.Robots, vcrRobots.Meta, resp, nil
+}
+
+// GetRobot will get a robot account of a registry
+func (h *ContainerRegistryServiceHandler) GetRobot(ctx context.Context, vcrID, robotName string) (*ContainerRegistryRobot, *http.Response, error) { //nolint:lll
+	req, errReq := h.client.NewRequest(ctx, http.MethodGet, fmt.Sprintf("%s/%s/robot/%s", vcrPath, vcrID, robotName), nil)
+	if errReq != nil {
+		return nil, nil, errReq
+	}
+
+	vcrRobot := new(ContainerRegistryRobot)
+	resp, errResp := h.client.DoWithContext(ctx, req, &vcrRobot)
+	if errResp != nil {
+		return nil, resp, errResp
+	}
+
+	return vcrRobot, resp, nil
+}
+
+// UpdateRobot will update the description, expiry, permissions or disabled
+// state of a robot account of a registry
+func (h *ContainerRegistryServiceHandler) UpdateRobot(ctx context.Context, vcrID, robotName string, updateReq *ContainerRegistryRobotReq) (*ContainerRegistryRobot, *http.Response, error) { //nolint:lll
+	req, errReq := h.client.NewRequest(ctx, http.MethodPut, fmt.Sprintf("%s/%s/robot/%s", vcrPath, vcrID, robotName), updateReq)
+	if errReq != nil {
+		return nil, nil, errReq
+	}
+
+	vcrRobot := new(ContainerRegistryRobot)
+	resp, errResp := h.client.DoWithContext(ctx, req, &vcrRobot)
+	if errResp != nil {
+		return nil, resp, errResp
+	}
+
+	return vcrRobot, resp, nil
+}
+
+// DeleteRobot will delete a robot account of a registry
+func (h *ContainerRegistryServiceHandler) DeleteRobot(ctx context.Context, vcrID, robotName string) error {
+	req, errReq := h.client.NewRequest(ctx, http.MethodDelete, fmt.Sprintf("%s/%s/robot/%s", vcrPath, vcrID, robotName), nil)
+	if errReq != nil {
+		return errReq
+	}
+
+	_, errResp := h.client.DoWithContext(ctx, req, nil)
+	if errResp != nil {
+		return errResp
+	}
+
+	return nil
+}
+
+// PruneRepositories deletes the repositories of a registry selected by a
+// retention policy and returns them. The API does not expose individual
+// artifacts yet, so whole repositories are pruned.
+func (h *ContainerRegistryServiceHandler) PruneRepositories(ctx context.Context, vcrID string, policy *ContainerRegistryRetentionPolicy) ([]ContainerRegistryRepo, error) { //nolint:lll
+	if policy == nil {
+		return nil, errors.New("prune repositories: retention policy is required")
+	}
+
+	if policy.MaxAgeDays <= 0 && policy.KeepLatestN <= 0 {
+		return nil, errors.New("prune repositories: retention policy must set MaxAgeDays or KeepLatestN")
+	}
+
+	repos, err := collectPages(ctx, func(ctx context.Context, options *ListOptions) ([]ContainerRegistryRepo, *Meta, *http.Response, error) {
+		return h.ListRepositories(ctx, vcrID, options)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	type candidate struct {
+		repo    ContainerRegistryRepo
+		updated time.Time
+	}
+
+	var candidates []candidate
+	for i := range repos {
+		if policy.NameGlob != "" {
+			matched, err := gopath.Match(policy.NameGlob, repos[i].Image)
+			if err != nil {
+				return nil, err
+			}
+			if !matched {
+				continue
+			}
+		}
+
+		updated, err := time.Parse(time.RFC3339, repos[i].DateModified)
+		if err != nil {
+			return nil, err
+		}
+		candidates = append(candidates, candidate{repo: repos[i], updated: updated})
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].updated.After(candidates[j].updated)
+	})
+
+	cutoff := time.Now().AddDate(0, 0, -policy.MaxAgeDays)
+	var pruned []ContainerRegistryRepo
+	for i := range candidates {
+		if i < policy.KeepLatestN || (policy.MaxAgeDays > 0 && candidates[i].updated.After(cutoff)) {
+			continue
+		}
+
+		if !policy.DryRun {
+			if err := h.DeleteRepository(ctx, vcrID, candidates[i].repo.Image); err != nil {
+				return pruned, err
+			}
+		}
+		pruned = append(pruned, candidates[i].repo)
+	}
+
+	return pruned, nil
+}
+
 // CreateDockerCredentials will create new Docker credentials used by the
 // Docker CLI
 func (h *ContainerRegistryServiceHandler) CreateDockerCredentials(ctx context.Context, vcrID string, createOptions *DockerCredentialsOpt) (*ContainerRegistryDockerCredentials, *http.Response, error) { //nolint:lll
@@ -372,7 +822,7 @@ func (h *ContainerRegistryServiceHandler) CreateDockerCredentials(ctx context.Co
 
 	queryParam := req.URL.Query()
 	if createOptions.ExpirySeconds != nil {
-		queryParam.Add("expiry_seconds", fmt.Sprintf("%d", createOptions.ExpirySeconds))
+		queryParam.Add("expiry_seconds", fmt.Sprintf("%d", *createOptions.ExpirySeconds))
 	}
 
 	if createOptions.WriteAccess != nil {
@@ -390,6 +840,49 @@ func (h *ContainerRegistryServiceHandler) CreateDockerCredentials(ctx context.Co
 	return creds, resp, nil
 }
 
+// CreateDockerCredentialsKubernetes will create new Docker credentials for a
+// registry and return them as a Kubernetes Secret manifest for image pulls.
+// The manifest has no namespace, so it is created in the namespace it is
+// applied to.
+func (h *ContainerRegistryServiceHandler) CreateDockerCredentialsKubernetes(ctx context.Context, vcrID string, createOptions *KubernetesCredentialsOpt) (*ContainerRegistryKubernetesCredentials, *http.Response, error) { //nolint:lll
+	url := fmt.Sprintf("%s/%s/docker-credentials/kubernetes", vcrPath, vcrID)
+	req, errReq := h.client.NewRequest(ctx, http.MethodOptions, url, nil)
+	if errReq != nil {
+		return nil, nil, errReq
+	}
+
+	if createOptions != nil {
+		queryParam := req.URL.Query()
+		if createOptions.ExpirySeconds != nil {
+			queryParam.Add("expiry_seconds", fmt.Sprintf("%d", *createOptions.ExpirySeconds))
+		}
+
+		if createOptions.WriteAccess != nil {
+			queryParam.Add("read_write", fmt.Sprintf("%t", *createOptions.WriteAccess))
+		}
+
+		if createOptions.Base64Encode != nil {
+			queryParam.Add("base64_encode", fmt.Sprintf("%t", *createOptions.Base64Encode))
+		}
+
+		req.URL.RawQuery = queryParam.Encode()
+	}
+
+	// The manifest is YAML, so it is read from the body rather than decoded
+	resp, errResp := h.client.DoWithContext(ctx, req, nil)
+	if errResp != nil {
+		return nil, resp, errResp
+	}
+
+	body, errRead := io.ReadAll(resp.Body)
+	if errRead != nil {
+		return nil, resp, errRead
+	}
+
+	creds := ContainerRegistryKubernetesCredentials(body)
+	return &creds, resp, nil
+}
+
 // ListRegions will return a list of regions relevant to the container registry
 // API operations
 func (h *ContainerRegistryServiceHandler) ListRegions(ctx context.Context) ([]ContainerRegistryRegion, *Meta, *http.Response, error) {
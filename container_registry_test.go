@@ -2,9 +2,11 @@ package govultr
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 )
@@ -1093,3 +1095,278 @@ func TestVCRServiceHandler_ListPlans(t *testing.T) {
 		t.Error("ContainerRegistry.ListPlans returned nil")
 	}
 }
+
+func TestContainerRegistryScanSummary_AtLeast(t *testing.T) {
+	var summary ContainerRegistryScanSummary
+	if err := json.Unmarshal([]byte(`{
+		"status": "complete",
+		"scanned_at": "2024-05-01 12:00:00",
+		"severity_counts": {"low": 7, "medium": 3, "high": 2, "critical": 1},
+		"vulnerabilities": [{"id": "CVE-2024-0001", "severity": "critical", "package": "openssl", "version": "3.0.1", "fix_version": "3.0.13"}]
+	}`), &summary); err != nil {
+		t.Fatalf("json.Unmarshal returned %+v", err)
+	}
+
+	for severity, expected := range map[string]int{
+		ScanSeverityUnknown:  13,
+		ScanSeverityMedium:   6,
+		ScanSeverityHigh:     3,
+		ScanSeverityCritical: 1,
+	} {
+		if count := summary.AtLeast(severity); count != expected {
+			t.Errorf("ContainerRegistryScanSummary.AtLeast(%s) returned %d, expected %d", severity, count, expected)
+		}
+	}
+}
+
+func TestVCRServiceHandler_PruneRepositories(t *testing.T) {
+	setup()
+	defer teardown()
+
+	vcrID := "e1d6be16-2b0c-4d76-a3eb-f28bf6ea5fe0"
+	now := time.Now().UTC()
+	updated := func(days int) string {
+		return now.AddDate(0, 0, -days).Format(time.RFC3339)
+	}
+
+	mux.HandleFunc(fmt.Sprintf("%s/%s/repositories", vcrPath, vcrID), func(writer http.ResponseWriter, request *http.Request) {
+		response := fmt.Sprintf(`{"repositories": [
+			{"image": "app-old", "updated_at": %q},
+			{"image": "app-new", "updated_at": %q},
+			{"image": "app-older", "updated_at": %q},
+			{"image": "app-oldest", "updated_at": %q},
+			{"image": "base", "updated_at": %q}
+		], "meta": {"total": 5, "links": {"next": "", "prev": ""}}}`, updated(40), updated(1), updated(50), updated(60), updated(90))
+		fmt.Fprint(writer, response)
+	})
+
+	var deleted []string
+	mux.HandleFunc(fmt.Sprintf("%s/%s/repository/", vcrPath, vcrID), func(writer http.ResponseWriter, request *http.Request) {
+		if request.Method == http.MethodDelete {
+			deleted = append(deleted, strings.TrimPrefix(request.URL.Path, fmt.Sprintf("%s/%s/repository/", vcrPath, vcrID)))
+		}
+	})
+
+	policy := &ContainerRegistryRetentionPolicy{NameGlob: "app-*", MaxAgeDays: 30, KeepLatestN: 2, DryRun: true}
+	pruned, err := client.ContainerRegistry.PruneRepositories(ctx, vcrID, policy)
+	if err != nil {
+		t.Errorf("ContainerRegistry.PruneRepositories returned %+v", err)
+	}
+
+	var images []string
+	for i := range pruned {
+		images = append(images, pruned[i].Image)
+	}
+
+	expected := []string{"app-older", "app-oldest"}
+	if !reflect.DeepEqual(images, expected) {
+		t.Errorf("ContainerRegistry.PruneRepositories returned %+v, expected %+v", images, expected)
+	}
+
+	if len(deleted) != 0 {
+		t.Errorf("ContainerRegistry.PruneRepositories deleted %+v on a dry run", deleted)
+	}
+
+	policy.DryRun = false
+	if _, err = client.ContainerRegistry.PruneRepositories(ctx, vcrID, policy); err != nil {
+		t.Errorf("ContainerRegistry.PruneRepositories returned %+v", err)
+	}
+
+	if !reflect.DeepEqual(deleted, expected) {
+		t.Errorf("ContainerRegistry.PruneRepositories deleted %+v, expected %+v", deleted, expected)
+	}
+
+	for _, invalid := range []*ContainerRegistryRetentionPolicy{nil, {}, {NameGlob: "*"}} {
+		if _, err = client.ContainerRegistry.PruneRepositories(ctx, vcrID, invalid); err == nil {
+			t.Errorf("ContainerRegistry.PruneRepositories(%+v) expected an error", invalid)
+		}
+	}
+
+	if !reflect.DeepEqual(deleted, expected) {
+		t.Errorf("ContainerRegistry.PruneRepositories deleted %+v with a policy selecting nothing", deleted)
+	}
+}
+
+func TestVCRServiceHandler_CreateAndWait(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v2/registry", func(writer http.ResponseWriter, request *http.Request) {
+		fmt.Fprint(writer, `{"id": "vcr", "name": "team"}`)
+	})
+
+	gets := 0
+	mux.HandleFunc("/v2/registry/vcr", func(writer http.ResponseWriter, request *http.Request) {
+		gets++
+		if gets == 1 {
+			fmt.Fprint(writer, `{"id": "vcr", "name": "team"}`)
+			return
+		}
+		fmt.Fprint(writer, `{"id": "vcr", "name": "team", "urn": "sjc.vultrcr.com/team"}`)
+	})
+
+	var queries []string
+	mux.HandleFunc("/v2/registry/vcr/docker-credentials", func(writer http.ResponseWriter, request *http.Request) {
+		queries = append(queries, request.URL.RawQuery)
+		if len(queries) == 1 {
+			http.Error(writer, `{"error": "registry not found", "status": 404}`, http.StatusNotFound)
+			return
+		}
+		fmt.Fprint(writer, `{"auths": {}}`)
+	})
+
+	var statuses []string
+	options := &WaitOptions{
+		Interval: time.Millisecond,
+		Progress: func(status string, elapsed time.Duration) {
+			statuses = append(statuses, status)
+		},
+	}
+
+	vcr, _, err := client.ContainerRegistry.CreateAndWait(ctx, &ContainerRegistryReq{Name: "team", Region: "sjc", Plan: "start_up"}, options)
+	if err != nil {
+		t.Fatalf("ContainerRegistry.CreateAndWait returned %+v", err)
+	}
+
+	if vcr.URN != "sjc.vultrcr.com/team" {
+		t.Errorf("ContainerRegistry.CreateAndWait returned %+v", vcr)
+	}
+
+	expected := []string{vcrStatusProvisioning, vcrStatusCredentials, vcrStatusReady}
+	if !reflect.DeepEqual(statuses, expected) {
+		t.Errorf("ContainerRegistry.CreateAndWait reported %+v, expected %+v", statuses, expected)
+	}
+
+	if queries[0] != "expiry_seconds=60&read_write=false" {
+		t.Errorf("ContainerRegistry.CreateAndWait sent credentials query %s", queries[0])
+	}
+}
+
+func TestVCRServiceHandler_Robots(t *testing.T) {
+	setup()
+	defer teardown()
+
+	robot := `{"name": "robot$team+ci", "description": "ci", "disable": false, "duration": -1, "creation_time": "2024-01-01T00:00:00Z",
+		"permissions": [{"kind": "project", "namespace": "team", "access": [{"action": "pull", "resource": "repository"}]}]}`
+
+	mux.HandleFunc("/v2/registry/vcr/robots", func(writer http.ResponseWriter, request *http.Request) {
+		fmt.Fprintf(writer, `{"robots": [%s], "meta": {"total": 1, "links": {"next": "", "prev": ""}}}`, robot)
+	})
+
+	var updates []ContainerRegistryRobotReq
+	mux.HandleFunc("/v2/registry/vcr/robot/robot$team+ci", func(writer http.ResponseWriter, request *http.Request) {
+		switch request.Method {
+		case http.MethodPut:
+			var update ContainerRegistryRobotReq
+			json.NewDecoder(request.Body).Decode(&update)
+			updates = append(updates, update)
+			fmt.Fprint(writer, robot)
+		case http.MethodDelete:
+			writer.WriteHeader(http.StatusNoContent)
+		default:
+			fmt.Fprint(writer, robot)
+		}
+	})
+
+	expected := ContainerRegistryRobot{
+		Name:        "robot$team+ci",
+		Description: "ci",
+		Duration:    -1,
+		DateCreated: "2024-01-01T00:00:00Z",
+		Permissions: []ContainerRegistryRobotPermission{NewRobotPermission("team", false)},
+	}
+
+	robots, meta, _, err := client.ContainerRegistry.ListRobots(ctx, "vcr", nil)
+	if err != nil {
+		t.Errorf("ContainerRegistry.ListRobots returned %+v", err)
+	}
+
+	if !reflect.DeepEqual(robots, []ContainerRegistryRobot{expected}) || meta.Total != 1 {
+		t.Errorf("ContainerRegistry.ListRobots returned %+v, expected %+v", robots, expected)
+	}
+
+	got, _, err := client.ContainerRegistry.GetRobot(ctx, "vcr", "robot$team+ci")
+	if err != nil {
+		t.Errorf("ContainerRegistry.GetRobot returned %+v", err)
+	}
+
+	if !got.CanPull("team") || got.CanPush("team") || got.CanPull("other") {
+		t.Errorf("ContainerRegistry.GetRobot returned unexpected permissions %+v", got.Permissions)
+	}
+
+	disable := true
+	update := ContainerRegistryRobotReq{Disable: &disable, Permissions: []ContainerRegistryRobotPermission{NewRobotPermission("team", true)}}
+	if _, _, err = client.ContainerRegistry.UpdateRobot(ctx, "vcr", "robot$team+ci", &update); err != nil {
+		t.Errorf("ContainerRegistry.UpdateRobot returned %+v", err)
+	}
+
+	if len(updates) != 1 || !reflect.DeepEqual(updates[0], update) {
+		t.Errorf("ContainerRegistry.UpdateRobot sent %+v, expected %+v", updates, update)
+	}
+
+	if err = client.ContainerRegistry.DeleteRobot(ctx, "vcr", "robot$team+ci"); err != nil {
+		t.Errorf("ContainerRegistry.DeleteRobot returned %+v", err)
+	}
+}
+
+func TestVCRServiceHandler_CreateDockerCredentialsKubernetes(t *testing.T) {
+	setup()
+	defer teardown()
+
+	manifest := "apiVersion: v1\nkind: Secret\nmetadata:\n  name: vultr-cr-credentials\ntype: kubernetes.io/dockerconfigjson\n"
+	mux.HandleFunc("/v2/registry/vcr/docker-credentials/kubernetes", func(writer http.ResponseWriter, request *http.Request) {
+		if request.Method != http.MethodOptions {
+			t.Errorf("ContainerRegistry.CreateDockerCredentialsKubernetes sent %s, expected OPTIONS", request.Method)
+		}
+		if query := request.URL.RawQuery; query != "base64_encode=true&expiry_seconds=3600&read_write=false" {
+			t.Errorf("ContainerRegistry.CreateDockerCredentialsKubernetes sent query %s", query)
+		}
+		writer.Header().Set("Content-Type", "application/yaml")
+		fmt.Fprint(writer, manifest)
+	})
+
+	expiry, writeAccess, encode := 3600, false, true
+	creds, _, err := client.ContainerRegistry.CreateDockerCredentialsKubernetes(ctx, "vcr", &KubernetesCredentialsOpt{
+		ExpirySeconds: &expiry,
+		WriteAccess:   &writeAccess,
+		Base64Encode:  &encode,
+	})
+	if err != nil {
+		t.Fatalf("ContainerRegistry.CreateDockerCredentialsKubernetes returned %+v", err)
+	}
+
+	if creds.String() != manifest {
+		t.Errorf("ContainerRegistry.CreateDockerCredentialsKubernetes returned %q, expected %q", creds.String(), manifest)
+	}
+}
+
+func TestContainerRegistryDockerCredentials_Decode(t *testing.T) {
+	creds := ContainerRegistryDockerCredentials(`{"auths": {"ewr.vultrcr.com": {"auth": "cm9ib3Q6c2VjcmV0"}}}`)
+
+	auth, err := creds.Decode()
+	if err != nil {
+		t.Fatalf("ContainerRegistryDockerCredentials.Decode returned %+v", err)
+	}
+
+	expected := &ContainerRegistryDockerAuth{Registry: "ewr.vultrcr.com", Username: "robot", Password: "secret", Auth: "cm9ib3Q6c2VjcmV0"}
+	if !reflect.DeepEqual(auth, expected) {
+		t.Errorf("ContainerRegistryDockerCredentials.Decode returned %+v, expected %+v", auth, expected)
+	}
+
+	config, err := auth.DockerConfigJSON()
+	if err != nil {
+		t.Fatalf("ContainerRegistryDockerAuth.DockerConfigJSON returned %+v", err)
+	}
+
+	expectedConfig := `{"auths":{"ewr.vultrcr.com":{"username":"robot","password":"secret","auth":"cm9ib3Q6c2VjcmV0"}}}`
+	if string(config) != expectedConfig {
+		t.Errorf("ContainerRegistryDockerAuth.DockerConfigJSON returned %s, expected %s", config, expectedConfig)
+	}
+
+	for _, invalid := range []string{`{"auths": {}}`, `{"auths": {"ewr.vultrcr.com": {"auth": "bm9jb2xvbg=="}}}`, `not json`} {
+		creds = ContainerRegistryDockerCredentials(invalid)
+		if _, err = creds.Decode(); err == nil {
+			t.Errorf("ContainerRegistryDockerCredentials.Decode(%s) expected an error", invalid)
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package govultr
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/hashicorp/go-retryablehttp"
+)
+
+// CredentialsProvider supplies the API key requests are authenticated with
+type CredentialsProvider interface {
+	// APIKey returns the API key to use for a request
+	APIKey(ctx context.Context) (string, error)
+
+	// Refresh is called when a request is rejected as unauthorized and returns
+	// a new API key, e.g. after the old one was rotated
+	Refresh(ctx context.Context) (string, error)
+}
+
+// CredentialsRefreshCallback defines the type of the function called after
+// the credentials are refreshed, with the error from Refresh if it failed
+type CredentialsRefreshCallback func(err error)
+
+// SetCredentialsProvider authenticates every request with the API key from the
+// provider, which replaces authenticating through the HTTP client passed to
+// NewClient. When a request is rejected with a 401 the credentials are
+// refreshed and the request is retried once with the new key.
+func (c *Client) SetCredentialsProvider(provider CredentialsProvider) {
+	c.credentials = provider
+}
+
+// OnCredentialsRefreshed sets the function called whenever the credentials
+// are refreshed after an unauthorized request
+func (c *Client) OnCredentialsRefreshed(rc CredentialsRefreshCallback) {
+	c.onCredentialsRefreshed = rc
+}
+
+// refreshCredentials refreshes the credentials and returns the new API key
+func (c *Client) refreshCredentials(ctx context.Context) (string, error) {
+	apiKey, err := c.credentials.Refresh(ctx)
+	if c.onCredentialsRefreshed != nil {
+		c.onCredentialsRefreshed(err)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	if c.rateLimiter != nil {
+		if err = c.rateLimiter.Wait(ctx); err != nil {
+			return "", err
+		}
+	}
+
+	return apiKey, nil
+}
+
+// retryWithRefreshedCredentials refreshes the credentials and sends rreq again
+// with the new API key
+func (c *Client) retryWithRefreshedCredentials(ctx context.Context, r *http.Request, rreq *retryablehttp.Request) (*http.Response, error) { //nolint:lll
+	apiKey, err := c.refreshCredentials(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rreq.Header.Set("Authorization", "Bearer "+apiKey)
+	res, err := c.sendRateLimited(ctx, rreq)
+
+	if c.onRequestCompleted != nil {
+		c.onRequestCompleted(r, res)
+	}
+
+	return res, err
+}
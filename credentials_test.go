@@ -0,0 +1,77 @@
+package govultr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+type rotatingCredentials struct {
+	keys      []string
+	refreshes int
+	err       error
+}
+
+func (r *rotatingCredentials) APIKey(ctx context.Context) (string, error) {
+	return r.keys[r.refreshes], nil
+}
+
+func (r *rotatingCredentials) Refresh(ctx context.Context) (string, error) {
+	if r.err != nil {
+		return "", r.err
+	}
+	r.refreshes++
+	return r.keys[r.refreshes], nil
+}
+
+func TestClient_SetCredentialsProvider(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var seen []string
+	mux.HandleFunc("/v2/account", func(writer http.ResponseWriter, request *http.Request) {
+		auth := request.Header.Get("Authorization")
+		seen = append(seen, auth)
+		if auth != "Bearer new-key" {
+			writer.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprint(writer, `{"error": "Invalid API token."}`)
+			return
+		}
+		fmt.Fprint(writer, `{"account": {"name": "Example Account"}}`)
+	})
+
+	credentials := &rotatingCredentials{keys: []string{"old-key", "new-key"}}
+	client.SetCredentialsProvider(credentials)
+
+	var refreshed []error
+	client.OnCredentialsRefreshed(func(err error) {
+		refreshed = append(refreshed, err)
+	})
+
+	account, _, err := client.Account.Get(ctx)
+	if err != nil {
+		t.Errorf("Account.Get returned %+v", err)
+	}
+
+	if account.Name != "Example Account" {
+		t.Errorf("Account.Get returned %+v, expected Example Account", account)
+	}
+
+	expected := []string{"Bearer old-key", "Bearer new-key"}
+	if !reflect.DeepEqual(seen, expected) {
+		t.Errorf("Authorization headers were %+v, expected %+v", seen, expected)
+	}
+
+	if !reflect.DeepEqual(refreshed, []error{nil}) {
+		t.Errorf("OnCredentialsRefreshed called with %+v, expected one successful refresh", refreshed)
+	}
+
+	credentials.refreshes = 0
+	credentials.err = errors.New("vault unavailable")
+	if _, _, err = client.Account.Get(ctx); !errors.Is(err, credentials.err) {
+		t.Errorf("Account.Get returned %+v, expected the refresh error", err)
+	}
+}
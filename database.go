@@ -2,13 +2,29 @@ package govultr
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net"
 	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/google/go-querystring/query"
 )
 
-const databasePath = "/v2/databases"
+const (
+	databasePath          = "/v2/databases"
+	databaseStatusRunning = "Running"
+
+	databaseEnginePG       = "pg"
+	databaseEngineMySQL    = "mysql"
+	databaseEngineRedis    = "redis"
+	databaseEngineValkey   = "valkey"
+	databaseEngineKafka    = "kafka"
+	databaseEngineFerretPG = "ferretpg"
+)
 
 // DatabaseService is the interface to interact with the Database endpoints on the Vultr API
 // Link: https://www.vultr.com/api/#tag/managed-databases
@@ -18,8 +34,13 @@ type DatabaseService interface {
 	List(ctx context.Context, options *DBListOptions) ([]Database, *Meta, *http.Response, error)
 	Create(ctx context.Context, databaseReq *DatabaseCreateReq) (*Database, *http.Response, error)
 	Get(ctx context.Context, databaseID string) (*Database, *http.Response, error)
+	WaitForStatus(ctx context.Context, databaseID, status string, options *WaitOptions) (*Database, *http.Response, error)
 	Update(ctx context.Context, databaseID string, databaseReq *DatabaseUpdateReq) (*Database, *http.Response, error)
 	Delete(ctx context.Context, databaseID string) error
+	Resize(ctx context.Context, databaseID string, plan string, options *WaitOptions) (*Database, *http.Response, error)
+	AttachVPC(ctx context.Context, databaseID, vpcID string) (*Database, *http.Response, error)
+	DetachVPC(ctx context.Context, databaseID string) (*Database, *http.Response, error)
+	CheckVPCAccess(ctx context.Context, databaseID string, instanceIDs []string) ([]DatabaseVPCWarning, error)
 
 	GetUsage(ctx context.Context, databaseID string) (*DatabaseUsage, *http.Response, error)
 
@@ -34,6 +55,8 @@ type DatabaseService interface {
 	CreateDB(ctx context.Context, databaseID string, databaseDBReq *DatabaseDBCreateReq) (*DatabaseDB, *http.Response, error)
 	GetDB(ctx context.Context, databaseID string, dbname string) (*DatabaseDB, *http.Response, error)
 	DeleteDB(ctx context.Context, databaseID string, dbname string) error
+	DBExists(ctx context.Context, databaseID string, dbname string) (bool, error)
+	EnsureDB(ctx context.Context, databaseID string, dbname string) (*DatabaseDB, bool, error)
 
 	ListMaintenanceUpdates(ctx context.Context, databaseID string) ([]string, *http.Response, error)
 	StartMaintenance(ctx context.Context, databaseID string) (string, *http.Response, error)
@@ -48,8 +71,10 @@ type DatabaseService interface {
 	PromoteReadReplica(ctx context.Context, databaseID string) error
 
 	GetBackupInformation(ctx context.Context, databaseID string) (*DatabaseBackups, *http.Response, error)
+	WaitForBackup(ctx context.Context, databaseID string, after time.Time, options *WaitOptions) (*DatabaseBackup, *http.Response, error)
 	RestoreFromBackup(ctx context.Context, databaseID string, databaseRestoreReq *DatabaseBackupRestoreReq) (*Database, *http.Response, error)
 	Fork(ctx context.Context, databaseID string, databaseForkReq *DatabaseForkReq) (*Database, *http.Response, error)
+	VerifyBackup(ctx context.Context, databaseID string, databaseForkReq *DatabaseForkReq, verify DatabaseVerifyFunc, options *WaitOptions) error //nolint:lll
 
 	ListConnectionPools(ctx context.Context, databaseID string) (*DatabaseConnections, []DatabaseConnectionPool, *Meta, *http.Response, error)
 	CreateConnectionPool(ctx context.Context, databaseID string, databaseConnectionPoolReq *DatabaseConnectionPoolCreateReq) (*DatabaseConnectionPool, *http.Response, error) //nolint:lll
@@ -62,6 +87,7 @@ type DatabaseService interface {
 
 	ListAvailableVersions(ctx context.Context, databaseID string) ([]string, *http.Response, error)
 	StartVersionUpgrade(ctx context.Context, databaseID string, databaseVersionUpgradeReq *DatabaseVersionUpgradeReq) (string, *http.Response, error) //nolint:lll
+	UpgradeVersionAndWait(ctx context.Context, databaseID, version string, options *WaitOptions) (*Database, *http.Response, error)
 }
 
 // DatabaseServiceHandler handles interaction with the server methods for the Vultr API
@@ -153,6 +179,91 @@ type Database struct {
 	ReadReplicas           []Database           `json:"read_replicas,omitempty"`
 }
 
+// DSN returns a connection URI for the database with TLS required. Kafka
+// clusters have no URI form, use BootstrapBrokers for those instead.
+func (d *Database) DSN() (string, error) {
+	switch d.DatabaseEngine {
+	case databaseEnginePG:
+		return databaseURI("postgres", url.UserPassword(d.User, d.Password), d.Host, d.Port, d.DBName, "sslmode=require"), nil
+	case databaseEngineMySQL:
+		return databaseURI("mysql", url.UserPassword(d.User, d.Password), d.Host, d.Port, d.DBName, "ssl-mode=REQUIRED"), nil
+	case databaseEngineRedis, databaseEngineValkey:
+		return databaseURI("rediss", url.UserPassword(d.User, d.Password), d.Host, d.Port, "", ""), nil
+	case databaseEngineFerretPG:
+		if d.FerretDBCredentials == nil {
+			return "", fmt.Errorf("database %s has no FerretDB credentials", d.ID)
+		}
+
+		c := d.FerretDBCredentials
+		return databaseURI("mongodb", url.UserPassword(c.User, c.Password), c.Host, fmt.Sprint(c.Port), "", "tls=true"), nil
+	}
+
+	return "", fmt.Errorf("no connection URI for database engine %q", d.DatabaseEngine)
+}
+
+// PooledDSN returns a connection URI for a PostgreSQL connection pool. The
+// password is only included when the pool uses the default database user.
+func (d *Database) PooledDSN(pool *DatabaseConnectionPool) (string, error) {
+	if d.DatabaseEngine != databaseEnginePG {
+		return "", fmt.Errorf("connection pools are not supported by database engine %q", d.DatabaseEngine)
+	}
+
+	user := url.User(pool.Username)
+	if pool.Username == d.User {
+		user = url.UserPassword(d.User, d.Password)
+	}
+
+	return databaseURI("postgres", user, d.Host, d.Port, pool.Name, "sslmode=require"), nil
+}
+
+// ReplicaDSNs returns a connection URI for each read replica of the database
+func (d *Database) ReplicaDSNs() ([]string, error) {
+	dsns := make([]string, 0, len(d.ReadReplicas))
+	for i := range d.ReadReplicas {
+		dsn, err := d.ReadReplicas[i].DSN()
+		if err != nil {
+			return nil, err
+		}
+
+		dsns = append(dsns, dsn)
+	}
+
+	return dsns, nil
+}
+
+// BootstrapBrokers returns the host:port bootstrap address of a Kafka cluster.
+// Clients must connect using TLS with SASL authentication using User and Password.
+func (d *Database) BootstrapBrokers() ([]string, error) {
+	if d.DatabaseEngine != databaseEngineKafka {
+		return nil, fmt.Errorf("bootstrap brokers are not supported by database engine %q", d.DatabaseEngine)
+	}
+
+	return []string{net.JoinHostPort(d.Host, d.Port)}, nil
+}
+
+// databaseURI assembles a connection URI from its parts
+func databaseURI(scheme string, user *url.Userinfo, host, port, dbName, query string) string {
+	u := url.URL{
+		Scheme:   scheme,
+		User:     user,
+		Host:     net.JoinHostPort(host, port),
+		RawQuery: query,
+	}
+
+	if dbName != "" || query != "" {
+		u.Path = "/" + dbName
+	}
+
+	return u.String()
+}
+
+// DatabaseVPCWarning describes why an instance may be unable to reach a
+// Managed Database over its VPC
+type DatabaseVPCWarning struct {
+	InstanceID string
+	Reason     string
+}
+
 // FerretDBCredentials represents connection details and IP address information for FerretDB engine type subscriptions
 type FerretDBCredentials struct {
 	Host      string `json:"host"`
@@ -400,6 +511,11 @@ type DatabaseBackup struct {
 	Time string `json:"time"`
 }
 
+// Timestamp returns the time the backup was taken
+func (d *DatabaseBackup) Timestamp() (time.Time, error) {
+	return time.Parse(time.DateTime, d.Date+" "+d.Time)
+}
+
 // DatabaseBackupRestoreReq struct used to restore the backup of a Managed Database to a new subscription.
 type DatabaseBackupRestoreReq struct {
 	Label string `json:"label,omitempty"`
@@ -418,6 +534,10 @@ type DatabaseForkReq struct {
 	Time   string `json:"time,omitempty"`
 }
 
+// DatabaseVerifyFunc is called by VerifyBackup with the running fork, e.g. to
+// connect with its DSN and run verification queries
+type DatabaseVerifyFunc func(ctx context.Context, fork *Database) error
+
 // DatabaseConnectionPool represents a PostgreSQL connection pool within a Managed Database cluster
 type DatabaseConnectionPool struct {
 	Name     string `json:"name"`
@@ -620,6 +740,16 @@ func (d *DatabaseServiceHandler) Get(ctx context.Context, databaseID string) (*D
 	return database.Database, resp, nil
 }
 
+// WaitForStatus polls a managed database until its status matches status,
+// such as "Running"
+func (d *DatabaseServiceHandler) WaitForStatus(ctx context.Context, databaseID, status string, options *WaitOptions) (*Database, *http.Response, error) { //nolint:lll
+	return waitForStatus(ctx, options, status, func(ctx context.Context) (*Database, *http.Response, error) {
+		return d.Get(ctx, databaseID)
+	}, func(r *Database) []string {
+		return []string{r.Status}
+	})
+}
+
 // Update will update the Managed Database with the given parameters
 func (d *DatabaseServiceHandler) Update(ctx context.Context, databaseID string, databaseReq *DatabaseUpdateReq) (*Database, *http.Response, error) { //nolint:lll
 	uri := fmt.Sprintf("%s/%s", databasePath, databaseID)
@@ -651,6 +781,139 @@ func (d *DatabaseServiceHandler) Delete(ctx context.Context, databaseID string)
 	return err
 }
 
+// Resize changes the plan of a Managed Database. The target plan is checked to
+// have enough disk for the current usage before the change is applied, after
+// which this waits for the resize to start and the cluster to return to a
+// Running state on the new plan. The resize counts as started once a status
+// other than Running is seen, or the new plan is reported when the update
+// response still reported the old one.
+func (d *DatabaseServiceHandler) Resize(ctx context.Context, databaseID, plan string, options *WaitOptions) (*Database, *http.Response, error) { //nolint:lll
+	plans, _, _, err := d.ListPlans(ctx, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var target *DatabasePlan
+	for i := range plans {
+		if plans[i].ID == plan {
+			target = &plans[i]
+			break
+		}
+	}
+
+	if target == nil {
+		return nil, nil, fmt.Errorf("database plan %q not found", plan)
+	}
+
+	usage, _, err := d.GetUsage(ctx, databaseID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if usage.Disk.CurrentGB > float32(target.Disk) {
+		return nil, nil, fmt.Errorf("database plan %q has %d GB of disk but %.2f GB is in use", plan, target.Disk, usage.Disk.CurrentGB)
+	}
+
+	updated, _, err := d.Update(ctx, databaseID, &DatabaseUpdateReq{Plan: plan})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// A plan reported straight away by the update is no sign the resize ran
+	planIsEvidence := updated == nil || updated.Plan != plan
+	started := false
+
+	var database *Database
+	var resp *http.Response
+	err = waitFor(ctx, options, func(ctx context.Context) (string, bool, error) {
+		var errGet error
+		database, resp, errGet = d.Get(ctx, databaseID)
+		if errGet != nil {
+			return "", false, errGet
+		}
+
+		if !started {
+			started = database.Status != databaseStatusRunning || (planIsEvidence && database.Plan == plan)
+		}
+		if !started {
+			return database.Status + " (waiting for the resize to start)", false, nil
+		}
+
+		return database.Status, database.Plan == plan && database.Status == databaseStatusRunning, nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return database, resp, nil
+}
+
+// AttachVPC places a Managed Database on a VPC. Once attached, Host resolves to
+// the private address and PublicHost to the public one. The API does not allow
+// the public endpoint to be disabled, so restrict it with TrustedIPs instead.
+func (d *DatabaseServiceHandler) AttachVPC(ctx context.Context, databaseID, vpcID string) (*Database, *http.Response, error) {
+	return d.Update(ctx, databaseID, &DatabaseUpdateReq{VPCID: &vpcID})
+}
+
+// DetachVPC removes a Managed Database from its VPC
+func (d *DatabaseServiceHandler) DetachVPC(ctx context.Context, databaseID string) (*Database, *http.Response, error) {
+	vpcID := ""
+	return d.Update(ctx, databaseID, &DatabaseUpdateReq{VPCID: &vpcID})
+}
+
+// CheckVPCAccess reports the instances that cannot reach a Managed Database
+// over its VPC, either because they are in another region or because they are
+// not attached to the database's VPC. A nil slice means all of them can.
+func (d *DatabaseServiceHandler) CheckVPCAccess(ctx context.Context, databaseID string, instanceIDs []string) ([]DatabaseVPCWarning, error) { //nolint:lll
+	database, _, err := d.Get(ctx, databaseID)
+	if err != nil {
+		return nil, err
+	}
+
+	var warnings []DatabaseVPCWarning
+	for _, instanceID := range instanceIDs {
+		if database.VPCID == "" {
+			warnings = append(warnings, DatabaseVPCWarning{InstanceID: instanceID, Reason: "database is not attached to a VPC"})
+			continue
+		}
+
+		instance, _, err := d.client.Instance.Get(ctx, instanceID)
+		if err != nil {
+			return nil, err
+		}
+
+		if instance.Region != database.Region {
+			warnings = append(warnings, DatabaseVPCWarning{
+				InstanceID: instanceID,
+				Reason:     fmt.Sprintf("instance is in region %s but the database is in %s", instance.Region, database.Region),
+			})
+			continue
+		}
+
+		vpcs, _, _, err := d.client.Instance.ListVPCInfo(ctx, instanceID, &ListOptions{PerPage: 100})
+		if err != nil {
+			return nil, err
+		}
+
+		attached := false
+		for i := range vpcs {
+			if vpcs[i].ID == database.VPCID {
+				attached = true
+				break
+			}
+		}
+
+		if !attached {
+			warnings = append(warnings, DatabaseVPCWarning{
+				InstanceID: instanceID,
+				Reason:     fmt.Sprintf("instance is not attached to VPC %s", database.VPCID),
+			})
+		}
+	}
+
+	return warnings, nil
+}
+
 // GetUsage retrieves disk, memory, and CPU usage information for your Managed Database.
 func (d *DatabaseServiceHandler) GetUsage(ctx context.Context, databaseID string) (*DatabaseUsage, *http.Response, error) {
 	uri := fmt.Sprintf("%s/%s/usage", databasePath, databaseID)
@@ -839,6 +1102,44 @@ func (d *DatabaseServiceHandler) DeleteDB(ctx context.Context, databaseID, dbnam
 	return err
 }
 
+// DBExists reports whether a logical database with the given name exists
+// within the Managed Database
+func (d *DatabaseServiceHandler) DBExists(ctx context.Context, databaseID, dbname string) (bool, error) {
+	dbs, _, _, err := d.ListDBs(ctx, databaseID)
+	if err != nil {
+		return false, err
+	}
+
+	for i := range dbs {
+		if dbs[i].Name == dbname {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// EnsureDB creates a logical database within the Managed Database unless one
+// with the given name already exists. The returned bool reports whether the
+// database was created.
+func (d *DatabaseServiceHandler) EnsureDB(ctx context.Context, databaseID, dbname string) (*DatabaseDB, bool, error) {
+	exists, err := d.DBExists(ctx, databaseID, dbname)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if exists {
+		return &DatabaseDB{Name: dbname}, false, nil
+	}
+
+	db, _, err := d.CreateDB(ctx, databaseID, &DatabaseDBCreateReq{Name: dbname})
+	if err != nil {
+		return nil, false, err
+	}
+
+	return db, true, nil
+}
+
 // ListMaintenanceUpdates retrieves all available maintenance updates for your Managed Database.
 func (d *DatabaseServiceHandler) ListMaintenanceUpdates(ctx context.Context, databaseID string) ([]string, *http.Response, error) {
 	uri := fmt.Sprintf("%s/%s/maintenance", databasePath, databaseID)
@@ -991,6 +1292,111 @@ func (d *DatabaseServiceHandler) GetBackupInformation(ctx context.Context, datab
 	return databaseBackups, resp, nil
 }
 
+// WaitForBackup polls until the latest backup of a Managed Database was taken
+// after the given time and returns it. Backups are taken automatically and the
+// API cannot trigger one on demand or change their schedule, so this is used to
+// wait for a fresh backup, e.g. before a migration.
+func (d *DatabaseServiceHandler) WaitForBackup(ctx context.Context, databaseID string, after time.Time, options *WaitOptions) (*DatabaseBackup, *http.Response, error) { //nolint:lll
+	var backups *DatabaseBackups
+	var resp *http.Response
+	err := waitFor(ctx, options, func(ctx context.Context) (string, bool, error) {
+		var errGet error
+		backups, resp, errGet = d.GetBackupInformation(ctx, databaseID)
+		if errGet != nil {
+			return "", false, errGet
+		}
+
+		if backups.LatestBackup.Date == "" {
+			return "no backup", false, nil
+		}
+
+		taken, errParse := backups.LatestBackup.Timestamp()
+		if errParse != nil {
+			return "", false, errParse
+		}
+
+		status := fmt.Sprintf("latest backup %s", taken.Format(time.RFC3339))
+		return status, taken.After(after), nil
+	})
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return &backups.LatestBackup, resp, nil
+}
+
+// VerifyBackup forks a temporary Managed Database from the latest backup,
+// waits for it to be running, calls verify with it and then deletes the fork,
+// even when verification fails. Unset fields of databaseForkReq default to the
+// plan and region of the source database and the latest backup. The API cannot
+// trigger a backup on demand, so combine with WaitForBackup for a fresh one.
+func (d *DatabaseServiceHandler) VerifyBackup(ctx context.Context, databaseID string, databaseForkReq *DatabaseForkReq, verify DatabaseVerifyFunc, options *WaitOptions) (err error) { //nolint:lll
+	if verify == nil {
+		return errors.New("verify backup requires a verify function")
+	}
+
+	forkReq := DatabaseForkReq{}
+	if databaseForkReq != nil {
+		forkReq = *databaseForkReq
+	}
+
+	if forkReq.Plan == "" || forkReq.Region == "" || forkReq.Label == "" {
+		source, _, errGet := d.Get(ctx, databaseID)
+		if errGet != nil {
+			return errGet
+		}
+
+		if forkReq.Plan == "" {
+			forkReq.Plan = source.Plan
+		}
+		if forkReq.Region == "" {
+			forkReq.Region = source.Region
+		}
+		if forkReq.Label == "" {
+			forkReq.Label = source.Label + "-verify"
+		}
+	}
+
+	if forkReq.Date == "" {
+		backups, _, errGet := d.GetBackupInformation(ctx, databaseID)
+		if errGet != nil {
+			return errGet
+		}
+
+		if backups.LatestBackup.Date == "" {
+			return fmt.Errorf("database %s has no backups", databaseID)
+		}
+		forkReq.Date, forkReq.Time = backups.LatestBackup.Date, backups.LatestBackup.Time
+	}
+
+	fork, _, err := d.Fork(ctx, databaseID, &forkReq)
+	if err != nil {
+		return err
+	}
+
+	forkID := fork.ID
+	defer func() {
+		if errDelete := d.Delete(context.WithoutCancel(ctx), forkID); errDelete != nil {
+			err = errors.Join(err, fmt.Errorf("deleting fork %s: %w", forkID, errDelete))
+		}
+	}()
+
+	err = waitFor(ctx, options, func(ctx context.Context) (string, bool, error) {
+		var errGet error
+		fork, _, errGet = d.Get(ctx, forkID)
+		if errGet != nil {
+			return "", false, errGet
+		}
+
+		return fork.Status, fork.Status == databaseStatusRunning, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return verify(ctx, fork)
+}
+
 // RestoreFromBackup will create a new subscription of the same plan from a backup of the Managed Database using the given parameters
 func (d *DatabaseServiceHandler) RestoreFromBackup(ctx context.Context, databaseID string, databaseRestoreReq *DatabaseBackupRestoreReq) (*Database, *http.Response, error) { //nolint:lll
 	uri := fmt.Sprintf("%s/%s/restore", databasePath, databaseID)
@@ -1184,3 +1590,106 @@ func (d *DatabaseServiceHandler) StartVersionUpgrade(ctx context.Context, databa
 
 	return databaseVersionUpgrade.Message, resp, nil
 }
+
+// ValidateUpgrade checks that upgrading a Managed Database from one engine
+// version to another moves to a newer version. Versions are compared
+// numerically on each dot-separated part, e.g. "8" < "8.4" < "15". Which
+// versions a database can actually move to comes from ListAvailableVersions.
+func ValidateUpgrade(from, to string) error {
+	fromParts, err := parseEngineVersion(from)
+	if err != nil {
+		return err
+	}
+
+	toParts, err := parseEngineVersion(to)
+	if err != nil {
+		return err
+	}
+
+	for i := 0; i < len(fromParts) || i < len(toParts); i++ {
+		var f, t int
+		if i < len(fromParts) {
+			f = fromParts[i]
+		}
+		if i < len(toParts) {
+			t = toParts[i]
+		}
+
+		if t > f {
+			return nil
+		}
+		if t < f {
+			break
+		}
+	}
+
+	return fmt.Errorf("cannot upgrade database engine version %s to %s: not a newer version", from, to)
+}
+
+func parseEngineVersion(version string) ([]int, error) {
+	parts := strings.Split(strings.TrimPrefix(version, "v"), ".")
+	numbers := make([]int, len(parts))
+	for i, part := range parts {
+		number, err := strconv.Atoi(part)
+		if err != nil || number < 0 {
+			return nil, fmt.Errorf("invalid database engine version %q", version)
+		}
+		numbers[i] = number
+	}
+
+	return numbers, nil
+}
+
+// UpgradeVersionAndWait upgrades a Managed Database to the given engine
+// version and polls until it is running that version. The upgrade is refused
+// before it starts when the version is not newer than the current one or is
+// not among the database's available versions.
+func (d *DatabaseServiceHandler) UpgradeVersionAndWait(ctx context.Context, databaseID, version string, options *WaitOptions) (*Database, *http.Response, error) { //nolint:lll
+	database, _, err := d.Get(ctx, databaseID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err = ValidateUpgrade(database.DatabaseEngineVersion, version); err != nil {
+		return nil, nil, err
+	}
+
+	versions, _, err := d.ListAvailableVersions(ctx, databaseID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	available := false
+	for _, v := range versions {
+		if v == version {
+			available = true
+			break
+		}
+	}
+
+	if !available {
+		return nil, nil, fmt.Errorf("database engine version %s is not available for database %s, available versions: %s",
+			version, databaseID, strings.Join(versions, ", "))
+	}
+
+	if _, _, err = d.StartVersionUpgrade(ctx, databaseID, &DatabaseVersionUpgradeReq{Version: version}); err != nil {
+		return nil, nil, err
+	}
+
+	var resp *http.Response
+	err = waitFor(ctx, options, func(ctx context.Context) (string, bool, error) {
+		var errGet error
+		database, resp, errGet = d.Get(ctx, databaseID)
+		if errGet != nil {
+			return "", false, errGet
+		}
+
+		status := fmt.Sprintf("%s (version %s)", database.Status, database.DatabaseEngineVersion)
+		return status, database.DatabaseEngineVersion == version && database.Status == databaseStatusRunning, nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return database, resp, nil
+}
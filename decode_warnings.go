@@ -0,0 +1,248 @@
+package govultr
+
+import (
+	"context"
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+var (
+	jsonUnmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+type decodeWarningsContextKey struct{}
+
+// DecodeWarnings describes where an API response did not match the types it
+// was decoded into. Fields are given as dotted paths such as
+// "instance.features[0]".
+type DecodeWarnings struct {
+	// Fields in the response that are not part of the decoded type
+	UnknownFields []string
+	// Fields whose value is of a different JSON type than the decoded type expects
+	TypeMismatches []string
+}
+
+// SetDecodeWarnings enables tolerant decoding of responses. Type mismatches no
+// longer fail a request and, along with unknown fields, are collected into
+// DecodeWarnings retrievable with ResponseDecodeWarnings.
+func (c *Client) SetDecodeWarnings(enabled bool) {
+	c.decodeWarnings = enabled
+}
+
+// ResponseDecodeWarnings returns the warnings collected while decoding resp,
+// or nil if decode warnings are disabled or the response matched its type
+func ResponseDecodeWarnings(resp *http.Response) *DecodeWarnings {
+	if resp == nil || resp.Request == nil {
+		return nil
+	}
+
+	warnings, _ := resp.Request.Context().Value(decodeWarningsContextKey{}).(*DecodeWarnings)
+	return warnings
+}
+
+// decodeTolerant decodes body into data, returning warnings for anything that
+// does not match the type of data instead of failing on type mismatches
+func decodeTolerant(body []byte, data interface{}) (*DecodeWarnings, error) {
+	var typeErr *json.UnmarshalTypeError
+	if err := json.Unmarshal(body, data); err != nil && !errors.As(err, &typeErr) {
+		return nil, err
+	}
+
+	var value interface{}
+	if err := json.Unmarshal(body, &value); err != nil {
+		return nil, err
+	}
+
+	warnings := new(DecodeWarnings)
+	collectDecodeWarnings(warnings, "", value, reflect.TypeOf(data))
+	if len(warnings.UnknownFields) == 0 && len(warnings.TypeMismatches) == 0 {
+		return nil, nil
+	}
+
+	return warnings, nil
+}
+
+// attachDecodeWarnings stores warnings in the context of the request of res so
+// they can be retrieved with ResponseDecodeWarnings
+func attachDecodeWarnings(res *http.Response, warnings *DecodeWarnings) {
+	if res.Request == nil {
+		return
+	}
+
+	ctx := context.WithValue(res.Request.Context(), decodeWarningsContextKey{}, warnings)
+	res.Request = res.Request.WithContext(ctx)
+}
+
+// collectDecodeWarnings walks a generically decoded JSON value alongside the
+// type it was decoded into and records unknown fields and type mismatches
+func collectDecodeWarnings(warnings *DecodeWarnings, path string, value interface{}, t reflect.Type) {
+	if value == nil || t == nil {
+		return
+	}
+
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	if reflect.PointerTo(t).Implements(jsonUnmarshalerType) || reflect.PointerTo(t).Implements(textUnmarshalerType) {
+		return
+	}
+
+	mismatch := func() {
+		warnings.TypeMismatches = append(warnings.TypeMismatches, fmt.Sprintf("%s: expected %s, got %s", displayPath(path), t, jsonKind(value)))
+	}
+
+	switch t.Kind() {
+	case reflect.Interface:
+	case reflect.Struct:
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			mismatch()
+			return
+		}
+
+		fields := jsonFields(t)
+		for _, key := range sortedKeys(object) {
+			field, ok := fields[strings.ToLower(key)]
+			if !ok {
+				warnings.UnknownFields = append(warnings.UnknownFields, joinPath(path, key))
+				continue
+			}
+			collectDecodeWarnings(warnings, joinPath(path, key), object[key], field)
+		}
+	case reflect.Map:
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			mismatch()
+			return
+		}
+
+		for _, key := range sortedKeys(object) {
+			collectDecodeWarnings(warnings, joinPath(path, key), object[key], t.Elem())
+		}
+	case reflect.Slice, reflect.Array:
+		if _, ok := value.(string); ok && t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8 {
+			return
+		}
+
+		items, ok := value.([]interface{})
+		if !ok {
+			mismatch()
+			return
+		}
+
+		for i := range items {
+			collectDecodeWarnings(warnings, fmt.Sprintf("%s[%d]", path, i), items[i], t.Elem())
+		}
+	case reflect.String:
+		if _, ok := value.(string); !ok {
+			mismatch()
+		}
+	case reflect.Bool:
+		if _, ok := value.(bool); !ok {
+			mismatch()
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		if _, ok := value.(float64); !ok {
+			mismatch()
+		}
+	}
+}
+
+// jsonFields returns the types of the fields of a struct keyed by their
+// lowercased JSON name. Fields decoded from strings with the string option map
+// to a nil type so their values are not checked.
+func jsonFields(t reflect.Type) map[string]reflect.Type {
+	fields := make(map[string]reflect.Type)
+	var embedded []reflect.Type
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+
+		name, options, _ := strings.Cut(tag, ",")
+		if field.Anonymous && name == "" {
+			ft := field.Type
+			if ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				embedded = append(embedded, ft)
+				continue
+			}
+		}
+
+		if !field.IsExported() {
+			continue
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+
+		fields[strings.ToLower(name)] = field.Type
+		if strings.Contains(options, "string") {
+			fields[strings.ToLower(name)] = nil
+		}
+	}
+
+	for _, e := range embedded {
+		for name, ft := range jsonFields(e) {
+			if _, ok := fields[name]; !ok {
+				fields[name] = ft
+			}
+		}
+	}
+
+	return fields
+}
+
+func sortedKeys(object map[string]interface{}) []string {
+	keys := make([]string, 0, len(object))
+	for key := range object {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+func displayPath(path string) string {
+	if path == "" {
+		return "(root)"
+	}
+	return path
+}
+
+func jsonKind(value interface{}) string {
+	switch value.(type) {
+	case map[string]interface{}:
+		return "object"
+	case []interface{}:
+		return "array"
+	case string:
+		return "string"
+	case float64:
+		return "number"
+	case bool:
+		return "bool"
+	}
+	return "null"
+}
@@ -0,0 +1,91 @@
+package govultr
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestClient_SetDecodeWarnings(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v2/ssh-keys/1", func(writer http.ResponseWriter, request *http.Request) {
+		fmt.Fprint(writer, `{"ssh_key": {"id": "1", "name": 42, "ssh_key": "ssh-rsa AAAA", "fingerprint": "aa:bb"}, "extra": [1]}`)
+	})
+
+	if _, _, err := client.SSHKey.Get(ctx, "1"); err == nil {
+		t.Error("SSHKey.Get returned no error for a type mismatch with decode warnings disabled")
+	}
+
+	client.SetDecodeWarnings(true)
+	key, resp, err := client.SSHKey.Get(ctx, "1")
+	if err != nil {
+		t.Errorf("SSHKey.Get returned %+v", err)
+	}
+
+	if key.ID != "1" || key.SSHKey != "ssh-rsa AAAA" {
+		t.Errorf("SSHKey.Get returned %+v, expected the matching fields to be decoded", key)
+	}
+
+	expected := &DecodeWarnings{
+		UnknownFields:  []string{"extra", "ssh_key.fingerprint"},
+		TypeMismatches: []string{"ssh_key.name: expected string, got number"},
+	}
+
+	if warnings := ResponseDecodeWarnings(resp); !reflect.DeepEqual(warnings, expected) {
+		t.Errorf("ResponseDecodeWarnings returned %+v, expected %+v", warnings, expected)
+	}
+}
+
+func TestDecodeTolerant(t *testing.T) {
+	type embedded struct {
+		Region string `json:"region"`
+	}
+
+	type resource struct {
+		embedded
+		ID       string            `json:"id"`
+		Size     int               `json:"size"`
+		Count    int               `json:"count,string"`
+		Tags     []string          `json:"tags"`
+		Labels   map[string]string `json:"labels"`
+		Raw      []byte            `json:"raw"`
+		Ignored  string            `json:"-"`
+		Disabled *bool             `json:"disabled"`
+	}
+
+	body := `{"id": "a", "region": "ewr", "size": "big", "count": "3", "tags": ["x", 1], "labels": {"k": true}, "raw": "AQI=", "Ignored": "y", "disabled": null}`
+	warnings, err := decodeTolerant([]byte(body), new(resource))
+	if err != nil {
+		t.Errorf("decodeTolerant returned %+v", err)
+	}
+
+	expected := &DecodeWarnings{
+		UnknownFields: []string{"Ignored"},
+		TypeMismatches: []string{
+			"labels.k: expected string, got bool",
+			"size: expected int, got string",
+			"tags[1]: expected string, got number",
+		},
+	}
+
+	if !reflect.DeepEqual(warnings, expected) {
+		t.Errorf("decodeTolerant returned %+v, expected %+v", warnings, expected)
+	}
+
+	if _, err = decodeTolerant([]byte(`{"id":`), new(resource)); err == nil {
+		t.Error("decodeTolerant returned no error for invalid JSON")
+	}
+}
+
+func FuzzDecodeTolerant(f *testing.F) {
+	f.Add([]byte(`{"instance": {"id": "1", "ram": 1024, "features": ["ipv6"], "tags": null}}`))
+	f.Add([]byte(`{"instance": {"id": 1, "ram": "1024", "features": "ipv6", "extra": {"a": [1, 2]}}}`))
+	f.Add([]byte(`[]`))
+
+	f.Fuzz(func(t *testing.T, body []byte) {
+		_, _ = decodeTolerant(body, new(instanceBase))
+	})
+}
@@ -0,0 +1,162 @@
+package govultr
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+type forceDeleteContextKey struct{}
+
+// DeleteProtectedError is returned when a delete is refused because the
+// resource carries a protected tag or label
+type DeleteProtectedError struct {
+	Resource string
+	ID       string
+	Match    string
+}
+
+func (e *DeleteProtectedError) Error() string {
+	return fmt.Sprintf("%s %s is delete protected by %q", e.Resource, e.ID, e.Match)
+}
+
+// DeletePolicy defines the type of the function consulted before every delete
+// request. The resource type and ID are the last two segments of the request
+// path, e.g. "instances" and the instance ID, or of its parent path for
+// deletes named by an action such as a VKE cluster deleted with its linked
+// resources. Labels holds the label and tags of the resource, or nil if there
+// is none at that path. The delete is refused without consulting the policy if
+// the labels cannot be fetched for any other reason. Returning an error
+// refuses the delete and the error is returned to the caller.
+type DeletePolicy func(resourceType, id string, labels []string) error
+
+// ProtectLabels returns a DeletePolicy that refuses to delete a resource whose
+// label or tags match any of the given values with a *DeleteProtectedError
+func ProtectLabels(protected ...string) DeletePolicy {
+	values := make(map[string]bool, len(protected))
+	for _, value := range protected {
+		values[value] = true
+	}
+
+	return func(resourceType, id string, labels []string) error {
+		for _, label := range labels {
+			if values[label] {
+				return &DeleteProtectedError{Resource: resourceType, ID: id, Match: label}
+			}
+		}
+		return nil
+	}
+}
+
+// SetDeletePolicy sets a policy consulted before any delete request is sent by
+// any service, so guardrails such as never deleting resources labeled "prod"
+// can be enforced in one place. Use ContextWithForceDelete to bypass it.
+func (c *Client) SetDeletePolicy(policy DeletePolicy) {
+	c.deletePolicy = policy
+}
+
+// SetDeleteProtection sets the ProtectLabels policy for the given values as
+// the delete policy, replacing any policy set by SetDeletePolicy. Use
+// ContextWithForceDelete to delete a protected resource on purpose.
+func (c *Client) SetDeleteProtection(protected ...string) {
+	c.SetDeletePolicy(ProtectLabels(protected...))
+}
+
+// ContextWithForceDelete returns a copy of ctx that bypasses the delete policy
+// for deletes made with it
+func ContextWithForceDelete(ctx context.Context) context.Context {
+	return context.WithValue(ctx, forceDeleteContextKey{}, true)
+}
+
+// guardsDelete reports whether deletes made with ctx need to be checked
+func (c *Client) guardsDelete(ctx context.Context) bool {
+	return c.deletePolicy != nil && !forcedDelete(ctx)
+}
+
+func forcedDelete(ctx context.Context) bool {
+	force, _ := ctx.Value(forceDeleteContextKey{}).(bool)
+	return force
+}
+
+// deleteActions are the final path segments of delete requests that act on the
+// resource at the parent path instead of naming a resource of their own
+var deleteActions = map[string]bool{
+	"delete-with-linked-resources": true,
+}
+
+// checkDeletePolicy consults the delete policy about a delete request, first
+// fetching the resource being deleted to find its label and tags
+func (c *Client) checkDeletePolicy(ctx context.Context, r *http.Request) error {
+	resourceURL := *r.URL
+	segments := strings.Split(strings.Trim(resourceURL.Path, "/"), "/")
+	if len(segments) > 1 && deleteActions[segments[len(segments)-1]] {
+		segments = segments[:len(segments)-1]
+		resourceURL.Path = "/" + strings.Join(segments, "/")
+		resourceURL.RawPath = ""
+	}
+
+	id := segments[len(segments)-1]
+	resourceType := ""
+	if len(segments) > 1 {
+		resourceType = segments[len(segments)-2]
+	}
+
+	labels, err := c.resourceLabels(ctx, &resourceURL, r.Header)
+	if err != nil {
+		return fmt.Errorf("refusing delete of %s %s: looking up its labels: %w", resourceType, id, err)
+	}
+
+	return c.deletePolicy(resourceType, id, labels)
+}
+
+// resourceLabels returns the label and tags of the resource at resourceURL, or
+// nil if there is no resource to fetch there
+func (c *Client) resourceLabels(ctx context.Context, resourceURL *url.URL, header http.Header) ([]string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, resourceURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header = header.Clone()
+
+	var body map[string]json.RawMessage
+	if _, err = c.do(ctx, req, &body); err != nil {
+		if IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var labels []string
+	for key, raw := range body {
+		if key == "meta" {
+			continue
+		}
+
+		var resource struct {
+			Label string   `json:"label"`
+			Tag   string   `json:"tag"`
+			Tags  []string `json:"tags"`
+		}
+		if json.Unmarshal(raw, &resource) != nil {
+			continue
+		}
+
+		labels = appendLabels(labels, resource.Label, resource.Tag)
+		labels = appendLabels(labels, resource.Tags...)
+	}
+
+	return labels, nil
+}
+
+// appendLabels appends the non-empty values to labels
+func appendLabels(labels []string, values ...string) []string {
+	for _, value := range values {
+		if value != "" {
+			labels = append(labels, value)
+		}
+	}
+	return labels
+}
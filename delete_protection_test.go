@@ -0,0 +1,216 @@
+package govultr
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestClient_SetDeleteProtection(t *testing.T) {
+	setup()
+	defer teardown()
+
+	deleted := false
+	mux.HandleFunc("/v2/instances/14b3e7d6-ffb5-4994-8502-57fcd9db3b33", func(writer http.ResponseWriter, request *http.Request) {
+		if request.Method == http.MethodDelete {
+			deleted = true
+			return
+		}
+		fmt.Fprint(writer, `{"instance": {"id": "14b3e7d6-ffb5-4994-8502-57fcd9db3b33", "label": "db-1", "tags": ["production"]}}`)
+	})
+
+	client.SetDeleteProtection("production")
+
+	err := client.Instance.Delete(ctx, "14b3e7d6-ffb5-4994-8502-57fcd9db3b33")
+
+	var protected *DeleteProtectedError
+	if !errors.As(err, &protected) || protected.Match != "production" {
+		t.Errorf("Instance.Delete returned %+v, expected a DeleteProtectedError matching production", err)
+	}
+
+	if deleted {
+		t.Error("Instance.Delete deleted a protected instance")
+	}
+
+	if err = client.Instance.Delete(ContextWithForceDelete(ctx), "14b3e7d6-ffb5-4994-8502-57fcd9db3b33"); err != nil {
+		t.Errorf("Instance.Delete returned %+v", err)
+	}
+
+	if !deleted {
+		t.Error("Instance.Delete did not delete with a forced context")
+	}
+}
+
+func TestClient_SetDeleteProtectionReplacesPolicy(t *testing.T) {
+	setup()
+	defer teardown()
+
+	deleted := false
+	mux.HandleFunc("/v2/blocks/c56c7b6e-15c2-445e-9a5d-1063ab5828ec", func(writer http.ResponseWriter, request *http.Request) {
+		if request.Method == http.MethodDelete {
+			deleted = true
+			return
+		}
+		fmt.Fprint(writer, `{"block": {"id": "c56c7b6e-15c2-445e-9a5d-1063ab5828ec", "label": "production"}}`)
+	})
+
+	client.SetDeletePolicy(func(resourceType, id string, labels []string) error {
+		return errors.New("refused by the replaced policy")
+	})
+	client.SetDeleteProtection("production")
+
+	err := client.BlockStorage.Delete(ctx, "c56c7b6e-15c2-445e-9a5d-1063ab5828ec")
+
+	var protected *DeleteProtectedError
+	if !errors.As(err, &protected) || protected.Resource != "blocks" || protected.Match != "production" {
+		t.Errorf("BlockStorage.Delete returned %+v, expected a DeleteProtectedError for blocks matching production", err)
+	}
+
+	if deleted {
+		t.Error("BlockStorage.Delete deleted a protected block")
+	}
+}
+
+func TestClient_SetDeletePolicy(t *testing.T) {
+	setup()
+	defer teardown()
+
+	deleted := map[string]bool{}
+	mux.HandleFunc("/v2/blocks/", func(writer http.ResponseWriter, request *http.Request) {
+		id := strings.TrimPrefix(request.URL.Path, "/v2/blocks/")
+		if request.Method == http.MethodDelete {
+			deleted[id] = true
+			return
+		}
+
+		label := "scratch"
+		if id == "b1" {
+			label = "prod-data"
+		}
+		fmt.Fprintf(writer, `{"block": {"id": %q, "label": %q}}`, id, label)
+	})
+
+	var calls []string
+	client.SetDeletePolicy(func(resourceType, id string, labels []string) error {
+		calls = append(calls, fmt.Sprintf("%s/%s %v", resourceType, id, labels))
+		for _, label := range labels {
+			if strings.HasPrefix(label, "prod") {
+				return &DeleteProtectedError{Resource: resourceType, ID: id, Match: label}
+			}
+		}
+		return nil
+	})
+
+	err := client.BlockStorage.Delete(ctx, "b1")
+	var protected *DeleteProtectedError
+	if !errors.As(err, &protected) || protected.Match != "prod-data" {
+		t.Errorf("BlockStorage.Delete returned %+v, expected a DeleteProtectedError matching prod-data", err)
+	}
+
+	if err = client.BlockStorage.Delete(ctx, "b2"); err != nil {
+		t.Errorf("BlockStorage.Delete returned %+v", err)
+	}
+
+	if err = client.BlockStorage.Delete(ContextWithForceDelete(ctx), "b1"); err != nil {
+		t.Errorf("BlockStorage.Delete returned %+v", err)
+	}
+
+	expectedCalls := []string{"blocks/b1 [prod-data]", "blocks/b2 [scratch]"}
+	if !reflect.DeepEqual(calls, expectedCalls) {
+		t.Errorf("DeletePolicy called with %+v, expected %+v", calls, expectedCalls)
+	}
+
+	if !reflect.DeepEqual(deleted, map[string]bool{"b1": true, "b2": true}) {
+		t.Errorf("BlockStorage.Delete deleted %+v, expected b1 only when forced and b2", deleted)
+	}
+}
+
+func TestClient_SetDeletePolicyLookupFailure(t *testing.T) {
+	setup()
+	defer teardown()
+
+	deleted := map[string]bool{}
+	mux.HandleFunc("/v2/blocks/", func(writer http.ResponseWriter, request *http.Request) {
+		id := strings.TrimPrefix(request.URL.Path, "/v2/blocks/")
+		if request.Method == http.MethodDelete {
+			deleted[id] = true
+			return
+		}
+
+		if id == "gone" {
+			http.Error(writer, `{"error": "block not found", "status": 404}`, http.StatusNotFound)
+			return
+		}
+		http.Error(writer, `{"error": "unavailable", "status": 503}`, http.StatusServiceUnavailable)
+	})
+
+	var calls []string
+	client.SetRetryLimit(0)
+	client.SetDeletePolicy(func(resourceType, id string, labels []string) error {
+		calls = append(calls, fmt.Sprintf("%s/%s %v", resourceType, id, labels))
+		return nil
+	})
+
+	if err := client.BlockStorage.Delete(ctx, "b1"); err == nil || ErrorCategoryOf(err) != ErrorCategoryTransient {
+		t.Errorf("BlockStorage.Delete returned %+v, expected the lookup error", err)
+	}
+
+	if err := client.BlockStorage.Delete(ctx, "gone"); err != nil {
+		t.Errorf("BlockStorage.Delete returned %+v", err)
+	}
+
+	expectedCalls := []string{"blocks/gone []"}
+	if !reflect.DeepEqual(calls, expectedCalls) {
+		t.Errorf("DeletePolicy called with %+v, expected %+v", calls, expectedCalls)
+	}
+
+	if !reflect.DeepEqual(deleted, map[string]bool{"gone": true}) {
+		t.Errorf("BlockStorage.Delete deleted %+v, expected only the block with no labels to fetch", deleted)
+	}
+}
+
+func TestClient_SetDeletePolicyDeleteAction(t *testing.T) {
+	setup()
+	defer teardown()
+
+	deleted := false
+	mux.HandleFunc("/v2/kubernetes/clusters/c1", func(writer http.ResponseWriter, request *http.Request) {
+		fmt.Fprint(writer, `{"vke_cluster": {"id": "c1", "label": "prod-cluster"}}`)
+	})
+	mux.HandleFunc("/v2/kubernetes/clusters/c1/delete-with-linked-resources", func(writer http.ResponseWriter, request *http.Request) {
+		if request.Method != http.MethodDelete {
+			http.Error(writer, `{"error": "method not allowed", "status": 405}`, http.StatusMethodNotAllowed)
+			return
+		}
+		deleted = true
+	})
+
+	var calls []string
+	client.SetDeletePolicy(func(resourceType, id string, labels []string) error {
+		calls = append(calls, fmt.Sprintf("%s/%s %v", resourceType, id, labels))
+		for _, label := range labels {
+			if strings.HasPrefix(label, "prod") {
+				return &DeleteProtectedError{Resource: resourceType, ID: id, Match: label}
+			}
+		}
+		return nil
+	})
+
+	err := client.Kubernetes.DeleteClusterWithResources(ctx, "c1")
+	var protected *DeleteProtectedError
+	if !errors.As(err, &protected) || protected.Match != "prod-cluster" {
+		t.Errorf("Kubernetes.DeleteClusterWithResources returned %+v, expected a DeleteProtectedError matching prod-cluster", err)
+	}
+
+	expectedCalls := []string{"clusters/c1 [prod-cluster]"}
+	if !reflect.DeepEqual(calls, expectedCalls) {
+		t.Errorf("DeletePolicy called with %+v, expected %+v", calls, expectedCalls)
+	}
+
+	if deleted {
+		t.Error("Kubernetes.DeleteClusterWithResources sent the delete despite the policy")
+	}
+}
@@ -0,0 +1,47 @@
+package govultr
+
+import (
+	"context"
+	"net"
+)
+
+// DNSResolver is the interface used for the public DNS lookups made by
+// CheckDelegation and Summarize. *net.Resolver satisfies it.
+type DNSResolver interface {
+	LookupHost(ctx context.Context, host string) ([]string, error)
+	LookupNS(ctx context.Context, name string) ([]*net.NS, error)
+}
+
+// RecordLookup returns the values of a record type for a name as answered by
+// the DNS server at address, given as host:port
+type RecordLookup func(ctx context.Context, address, name, recordType string) ([]string, error)
+
+// SetDNSResolver sets the resolver used for public DNS lookups, e.g. to query
+// a specific upstream or to stub lookups in tests. A nil resolver restores
+// net.DefaultResolver.
+func (c *Client) SetDNSResolver(resolver DNSResolver) {
+	c.dnsResolver = resolver
+}
+
+// resolver returns the DNSResolver lookups should be made with
+func (c *Client) resolver() DNSResolver {
+	if c.dnsResolver == nil {
+		return net.DefaultResolver
+	}
+	return c.dnsResolver
+}
+
+// SetRecordLookup sets the function VerifyPropagation queries each resolver
+// with. A nil lookup restores the default, which sends the queries directly to
+// each resolver.
+func (c *Client) SetRecordLookup(lookup RecordLookup) {
+	c.recordLookup = lookup
+}
+
+// lookupRecordAt returns the RecordLookup propagation checks should be made with
+func (c *Client) lookupRecordAt() RecordLookup {
+	if c.recordLookup == nil {
+		return lookupRecord
+	}
+	return c.recordLookup
+}
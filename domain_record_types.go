@@ -0,0 +1,158 @@
+package govultr
+
+import (
+	"crypto/sha1" //nolint:gosec
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// CAA property tags
+const (
+	CAATagIssue     = "issue"
+	CAATagIssueWild = "issuewild"
+	CAATagIodef     = "iodef"
+
+	// CAAFlagCritical tells a CA that does not understand the tag not to issue
+	CAAFlagCritical = 128
+)
+
+// SSHFP key algorithms
+const (
+	SSHFPAlgorithmRSA     = 1
+	SSHFPAlgorithmDSA     = 2
+	SSHFPAlgorithmECDSA   = 3
+	SSHFPAlgorithmEd25519 = 4
+	SSHFPAlgorithmEd448   = 6
+)
+
+// SSHFP fingerprint types
+const (
+	SSHFPFingerprintSHA1   = 1
+	SSHFPFingerprintSHA256 = 2
+)
+
+// sshfpAlgorithms maps the key types of authorized_keys entries to SSHFP algorithms
+var sshfpAlgorithms = map[string]int{
+	"ssh-rsa":             SSHFPAlgorithmRSA,
+	"ssh-dss":             SSHFPAlgorithmDSA,
+	"ecdsa-sha2-nistp256": SSHFPAlgorithmECDSA,
+	"ecdsa-sha2-nistp384": SSHFPAlgorithmECDSA,
+	"ecdsa-sha2-nistp521": SSHFPAlgorithmECDSA,
+	"ssh-ed25519":         SSHFPAlgorithmEd25519,
+	"ssh-ed448":           SSHFPAlgorithmEd448,
+}
+
+// NewCAARecord returns a CAA record request after checking the flags, tag and
+// value. The value of issue and issuewild is a CA domain, optionally followed
+// by parameters, or ";" to forbid issuance. The value of iodef is a mailto:,
+// http: or https: URL.
+func NewCAARecord(name string, flags int, tag, value string, ttl int) (*DomainRecordReq, error) {
+	if flags != 0 && flags != CAAFlagCritical {
+		return nil, fmt.Errorf("invalid CAA flags %d", flags)
+	}
+
+	if tag == "" || len(tag) > 15 || strings.IndexFunc(tag, func(r rune) bool { return !isAlphanumeric(r) }) >= 0 {
+		return nil, fmt.Errorf("invalid CAA tag %q", tag)
+	}
+
+	if strings.ContainsAny(value, "\"\n") {
+		return nil, fmt.Errorf("invalid CAA value %q", value)
+	}
+
+	switch tag {
+	case CAATagIssue, CAATagIssueWild:
+		domain := strings.TrimSpace(strings.SplitN(value, ";", 2)[0])
+		if domain == "" && !strings.HasPrefix(strings.TrimSpace(value), ";") {
+			return nil, errors.New("CAA issue value must be a CA domain or \";\"")
+		}
+		if strings.ContainsAny(domain, " /:") {
+			return nil, fmt.Errorf("invalid CAA issuer domain %q", domain)
+		}
+	case CAATagIodef:
+		u, err := url.Parse(value)
+		if err != nil || (u.Scheme != "mailto" && u.Scheme != "http" && u.Scheme != "https") {
+			return nil, fmt.Errorf("CAA iodef value %q must be a mailto:, http: or https: URL", value)
+		}
+	}
+
+	return &DomainRecordReq{
+		Name: name,
+		Type: "CAA",
+		Data: fmt.Sprintf("%d %s \"%s\"", flags, tag, value),
+		TTL:  ttl,
+	}, nil
+}
+
+// NewSSHFPRecord returns an SSHFP record request after checking the algorithm,
+// fingerprint type and that the fingerprint is hex of the matching length
+func NewSSHFPRecord(name string, algorithm, fingerprintType int, fingerprint string, ttl int) (*DomainRecordReq, error) {
+	switch algorithm {
+	case SSHFPAlgorithmRSA, SSHFPAlgorithmDSA, SSHFPAlgorithmECDSA, SSHFPAlgorithmEd25519, SSHFPAlgorithmEd448:
+	default:
+		return nil, fmt.Errorf("invalid SSHFP algorithm %d", algorithm)
+	}
+
+	size := 0
+	switch fingerprintType {
+	case SSHFPFingerprintSHA1:
+		size = sha1.Size
+	case SSHFPFingerprintSHA256:
+		size = sha256.Size
+	default:
+		return nil, fmt.Errorf("invalid SSHFP fingerprint type %d", fingerprintType)
+	}
+
+	decoded, err := hex.DecodeString(fingerprint)
+	if err != nil || len(decoded) != size {
+		return nil, fmt.Errorf("SSHFP fingerprint must be %d hex encoded bytes", size)
+	}
+
+	return &DomainRecordReq{
+		Name: name,
+		Type: "SSHFP",
+		Data: fmt.Sprintf("%d %d %s", algorithm, fingerprintType, hex.EncodeToString(decoded)),
+		TTL:  ttl,
+	}, nil
+}
+
+// NewSSHFPRecordFromKey returns an SSHFP record request for a host public key
+// in authorized_keys format, such as the contents of ssh_host_ed25519_key.pub
+func NewSSHFPRecordFromKey(name, publicKey string, fingerprintType int, ttl int) (*DomainRecordReq, error) {
+	fields := strings.Fields(publicKey)
+	if len(fields) < 2 {
+		return nil, errors.New("public key must be in authorized_keys format")
+	}
+
+	algorithm, ok := sshfpAlgorithms[fields[0]]
+	if !ok {
+		return nil, fmt.Errorf("unsupported SSH key type %q", fields[0])
+	}
+
+	blob, err := base64.StdEncoding.DecodeString(fields[1])
+	if err != nil {
+		return nil, fmt.Errorf("decoding public key: %w", err)
+	}
+
+	var fingerprint []byte
+	switch fingerprintType {
+	case SSHFPFingerprintSHA1:
+		sum := sha1.Sum(blob) //nolint:gosec
+		fingerprint = sum[:]
+	case SSHFPFingerprintSHA256:
+		sum := sha256.Sum256(blob)
+		fingerprint = sum[:]
+	default:
+		return nil, fmt.Errorf("invalid SSHFP fingerprint type %d", fingerprintType)
+	}
+
+	return NewSSHFPRecord(name, algorithm, fingerprintType, hex.EncodeToString(fingerprint), ttl)
+}
+
+func isAlphanumeric(r rune) bool {
+	return (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9')
+}
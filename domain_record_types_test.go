@@ -0,0 +1,98 @@
+package govultr
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"reflect"
+	"testing"
+)
+
+func TestNewCAARecord(t *testing.T) {
+	record, err := NewCAARecord("", 0, CAATagIssue, "letsencrypt.org", 300)
+	if err != nil {
+		t.Fatalf("NewCAARecord returned %+v", err)
+	}
+
+	expected := &DomainRecordReq{Name: "", Type: "CAA", Data: `0 issue "letsencrypt.org"`, TTL: 300}
+	if !reflect.DeepEqual(record, expected) {
+		t.Errorf("NewCAARecord returned %+v, expected %+v", record, expected)
+	}
+
+	valid := []struct {
+		flags      int
+		tag, value string
+	}{
+		{0, CAATagIssueWild, ";"},
+		{CAAFlagCritical, CAATagIssue, "pki.goog; cansignhttpexchanges=yes"},
+		{0, CAATagIodef, "mailto:security@example.com"},
+	}
+	for _, c := range valid {
+		if _, err = NewCAARecord("", c.flags, c.tag, c.value, 0); err != nil {
+			t.Errorf("NewCAARecord(%d, %s, %s) returned %+v", c.flags, c.tag, c.value, err)
+		}
+	}
+
+	invalid := []struct {
+		flags      int
+		tag, value string
+	}{
+		{1, CAATagIssue, "letsencrypt.org"},
+		{0, "is-sue", "letsencrypt.org"},
+		{0, CAATagIssue, ""},
+		{0, CAATagIssue, "https://letsencrypt.org"},
+		{0, CAATagIssue, `letsencrypt.org"`},
+		{0, CAATagIodef, "security@example.com"},
+	}
+	for _, c := range invalid {
+		if _, err = NewCAARecord("", c.flags, c.tag, c.value, 0); err == nil {
+			t.Errorf("NewCAARecord(%d, %s, %s) returned no error", c.flags, c.tag, c.value)
+		}
+	}
+}
+
+func TestNewSSHFPRecord(t *testing.T) {
+	fingerprint := "C0FFEE" + hex.EncodeToString(make([]byte, 29))
+	record, err := NewSSHFPRecord("host", SSHFPAlgorithmEd25519, SSHFPFingerprintSHA256, fingerprint, 0)
+	if err != nil {
+		t.Fatalf("NewSSHFPRecord returned %+v", err)
+	}
+
+	expected := &DomainRecordReq{Name: "host", Type: "SSHFP", Data: "4 2 c0ffee" + hex.EncodeToString(make([]byte, 29))}
+	if !reflect.DeepEqual(record, expected) {
+		t.Errorf("NewSSHFPRecord returned %+v, expected %+v", record, expected)
+	}
+
+	if _, err = NewSSHFPRecord("host", 5, SSHFPFingerprintSHA256, fingerprint, 0); err == nil {
+		t.Error("NewSSHFPRecord accepted algorithm 5")
+	}
+
+	if _, err = NewSSHFPRecord("host", SSHFPAlgorithmRSA, SSHFPFingerprintSHA1, fingerprint, 0); err == nil {
+		t.Error("NewSSHFPRecord accepted a SHA-256 fingerprint as SHA-1")
+	}
+}
+
+func TestNewSSHFPRecordFromKey(t *testing.T) {
+	var blob []byte
+	for _, field := range [][]byte{[]byte("ssh-ed25519"), make([]byte, 32)} {
+		blob = binary.BigEndian.AppendUint32(blob, uint32(len(field)))
+		blob = append(blob, field...)
+	}
+	publicKey := "ssh-ed25519 " + base64.StdEncoding.EncodeToString(blob) + " root@host"
+
+	record, err := NewSSHFPRecordFromKey("host", publicKey, SSHFPFingerprintSHA256, 300)
+	if err != nil {
+		t.Fatalf("NewSSHFPRecordFromKey returned %+v", err)
+	}
+
+	sum := sha256.Sum256(blob)
+	expected := &DomainRecordReq{Name: "host", Type: "SSHFP", Data: "4 2 " + hex.EncodeToString(sum[:]), TTL: 300}
+	if !reflect.DeepEqual(record, expected) {
+		t.Errorf("NewSSHFPRecordFromKey returned %+v, expected %+v", record, expected)
+	}
+
+	if _, err = NewSSHFPRecordFromKey("host", "ssh-foo AAAA", SSHFPFingerprintSHA256, 0); err == nil {
+		t.Error("NewSSHFPRecordFromKey accepted an unknown key type")
+	}
+}
@@ -2,8 +2,14 @@ package govultr
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net"
 	"net/http"
+	"net/netip"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/google/go-querystring/query"
 )
@@ -16,6 +22,22 @@ type DomainRecordService interface {
 	Update(ctx context.Context, domain, recordID string, domainRecordReq *DomainRecordReq) error
 	Delete(ctx context.Context, domain, recordID string) error
 	List(ctx context.Context, domain string, options *ListOptions) ([]DomainRecord, *Meta, *http.Response, error)
+
+	GetRecordSet(ctx context.Context, domain, name, recordType string) (*RecordSet, error)
+	CreateRecordSet(ctx context.Context, domain string, setReq *RecordSetReq) (*RecordSet, error)
+	ReplaceRecordSet(ctx context.Context, domain string, setReq *RecordSetReq) (*RecordSet, error)
+	DeleteRecordSet(ctx context.Context, domain, name, recordType string) error
+
+	ApplyToZones(ctx context.Context, domains []string, concurrency int, operation ZoneOperation) []ZoneResult
+	ReplaceRecordSetInZones(ctx context.Context, domains []string, setReq *RecordSetReq, concurrency int) []ZoneResult
+
+	VerifyPropagation(ctx context.Context, domain string, record *DomainRecord, resolvers []string, options *WaitOptions) ([]PropagationResult, error) //nolint:lll
+	Summarize(ctx context.Context, domain string) (*ZoneSummary, error)
+}
+
+// PublicResolvers returns the resolvers VerifyPropagation queries when none are given
+func PublicResolvers() []string {
+	return []string{"1.1.1.1:53", "8.8.8.8:53", "9.9.9.9:53"}
 }
 
 // DomainRecordsServiceHandler handles interaction with the DNS Records methods for the Vultr API
@@ -42,6 +64,53 @@ type DomainRecordReq struct {
 	Priority *int   `json:"priority,omitempty"`
 }
 
+// RecordSet represents all of the records on a domain that share a name and
+// type, such as round-robin A records or a wildcard ("*") name
+type RecordSet struct {
+	Name    string
+	Type    string
+	Records []DomainRecord
+}
+
+// RecordSetReq struct to use for record set calls. A record is kept for each
+// entry in Data, all with the same TTL and priority.
+type RecordSetReq struct {
+	Name     string
+	Type     string
+	Data     []string
+	TTL      int
+	Priority *int
+}
+
+// ZoneOperation is a record operation run on one domain by ApplyToZones
+type ZoneOperation func(ctx context.Context, domain string) (*RecordSet, error)
+
+// ZoneResult holds the outcome of a batch record operation on one domain
+type ZoneResult struct {
+	Domain    string
+	RecordSet *RecordSet
+	Error     error
+}
+
+// ZoneSummary represents the composition of the records on a domain.
+// DanglingCNAMEs are CNAME records whose target has no records in the zone or
+// does not resolve.
+type ZoneSummary struct {
+	Domain         string
+	Records        int
+	RecordsByType  map[string]int
+	RecordsByTTL   map[int]int
+	DanglingCNAMEs []DomainRecord
+}
+
+// PropagationResult represents the answer a resolver served for a record
+type PropagationResult struct {
+	Resolver   string
+	Values     []string
+	Propagated bool
+	Error      string
+}
+
 type domainRecordsBase struct {
 	Records []DomainRecord `json:"records,omitempty"`
 	Meta    *Meta          `json:"meta,omitempty"`
@@ -125,3 +194,343 @@ func (d *DomainRecordsServiceHandler) List(ctx context.Context, domain string, o
 
 	return records.Records, records.Meta, resp, nil
 }
+
+// GetRecordSet returns the records on a domain with the given name and type.
+// A set with no records is returned if there are none.
+func (d *DomainRecordsServiceHandler) GetRecordSet(ctx context.Context, domain, name, recordType string) (*RecordSet, error) {
+	records, err := collectPages(ctx, func(ctx context.Context, options *ListOptions) ([]DomainRecord, *Meta, *http.Response, error) {
+		return d.List(ctx, domain, options)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	recordSet := &RecordSet{Name: name, Type: recordType}
+	for i := range records {
+		if records[i].Name == name && strings.EqualFold(records[i].Type, recordType) {
+			recordSet.Records = append(recordSet.Records, records[i])
+		}
+	}
+
+	return recordSet, nil
+}
+
+// CreateRecordSet creates a record for each value of a record set. It returns
+// an error if any records with the same name and type already exist.
+func (d *DomainRecordsServiceHandler) CreateRecordSet(ctx context.Context, domain string, setReq *RecordSetReq) (*RecordSet, error) {
+	existing, err := d.GetRecordSet(ctx, domain, setReq.Name, setReq.Type)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(existing.Records) > 0 {
+		return nil, fmt.Errorf("record set %s %s already exists on %s", setReq.Name, setReq.Type, domain)
+	}
+
+	recordSet := &RecordSet{Name: setReq.Name, Type: setReq.Type}
+	for _, data := range setReq.Data {
+		record, _, err := d.Create(ctx, domain, setReq.record(data))
+		if err != nil {
+			return nil, err
+		}
+		recordSet.Records = append(recordSet.Records, *record)
+	}
+
+	return recordSet, nil
+}
+
+// ReplaceRecordSet makes the records with the name and type of a record set
+// match it. Missing values are created first, then values that are already
+// present have their TTL and priority updated if needed and all others are
+// deleted last, so the name keeps answering while the set changes.
+func (d *DomainRecordsServiceHandler) ReplaceRecordSet(ctx context.Context, domain string, setReq *RecordSetReq) (*RecordSet, error) {
+	existing, err := d.GetRecordSet(ctx, domain, setReq.Name, setReq.Type)
+	if err != nil {
+		return nil, err
+	}
+
+	wanted := make(map[string]bool, len(setReq.Data))
+	for _, data := range setReq.Data {
+		wanted[data] = true
+	}
+
+	var kept, stale []*DomainRecord
+	present := make(map[string]bool, len(existing.Records))
+	for i := range existing.Records {
+		record := &existing.Records[i]
+		if !wanted[record.Data] || present[record.Data] {
+			stale = append(stale, record)
+			continue
+		}
+
+		present[record.Data] = true
+		kept = append(kept, record)
+	}
+
+	for _, data := range setReq.Data {
+		if present[data] {
+			continue
+		}
+
+		if _, _, err := d.Create(ctx, domain, setReq.record(data)); err != nil {
+			return nil, err
+		}
+		present[data] = true
+	}
+
+	for _, record := range kept {
+		if setReq.changes(record) {
+			if err := d.Update(ctx, domain, record.ID, setReq.record(record.Data)); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	for _, record := range stale {
+		if err := d.Delete(ctx, domain, record.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	return d.GetRecordSet(ctx, domain, setReq.Name, setReq.Type)
+}
+
+// DeleteRecordSet deletes every record on a domain with the given name and type
+func (d *DomainRecordsServiceHandler) DeleteRecordSet(ctx context.Context, domain, name, recordType string) error {
+	recordSet, err := d.GetRecordSet(ctx, domain, name, recordType)
+	if err != nil {
+		return err
+	}
+
+	for i := range recordSet.Records {
+		if err := d.Delete(ctx, domain, recordSet.Records[i].ID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ApplyToZones runs operation on each domain, at most concurrency at a time,
+// and returns a result per domain in the order given. A failure on one domain
+// does not stop the others. Domains not yet started when ctx is done report
+// the context error. A concurrency below 1 runs one domain at a time.
+func (d *DomainRecordsServiceHandler) ApplyToZones(ctx context.Context, domains []string, concurrency int, operation ZoneOperation) []ZoneResult { //nolint:lll
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	results := make([]ZoneResult, len(domains))
+	slots := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i, domain := range domains {
+		results[i].Domain = domain
+		select {
+		case slots <- struct{}{}:
+		case <-ctx.Done():
+			results[i].Error = ctx.Err()
+			continue
+		}
+
+		wg.Add(1)
+		go func(result *ZoneResult) {
+			defer func() {
+				<-slots
+				wg.Done()
+			}()
+			result.RecordSet, result.Error = operation(ctx, result.Domain)
+		}(&results[i])
+	}
+
+	wg.Wait()
+	return results
+}
+
+// ReplaceRecordSetInZones makes the record set on each domain match setReq,
+// as ReplaceRecordSet does, at most concurrency domains at a time
+func (d *DomainRecordsServiceHandler) ReplaceRecordSetInZones(ctx context.Context, domains []string, setReq *RecordSetReq, concurrency int) []ZoneResult { //nolint:lll
+	return d.ApplyToZones(ctx, domains, concurrency, func(ctx context.Context, domain string) (*RecordSet, error) {
+		return d.ReplaceRecordSet(ctx, domain, setReq)
+	})
+}
+
+func (r *RecordSetReq) record(data string) *DomainRecordReq {
+	return &DomainRecordReq{Name: r.Name, Type: r.Type, Data: data, TTL: r.TTL, Priority: r.Priority}
+}
+
+func (r *RecordSetReq) changes(record *DomainRecord) bool {
+	if r.TTL != 0 && r.TTL != record.TTL {
+		return true
+	}
+	return r.Priority != nil && *r.Priority != record.Priority
+}
+
+// Summarize reports the composition of a domain's records for hygiene checks.
+// The API does not expose DNS query statistics.
+func (d *DomainRecordsServiceHandler) Summarize(ctx context.Context, domain string) (*ZoneSummary, error) {
+	records, err := collectPages(ctx, func(ctx context.Context, options *ListOptions) ([]DomainRecord, *Meta, *http.Response, error) {
+		return d.List(ctx, domain, options)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &ZoneSummary{
+		Domain:        domain,
+		Records:       len(records),
+		RecordsByType: make(map[string]int),
+		RecordsByTTL:  make(map[int]int),
+	}
+
+	zone := strings.ToLower(domain)
+	names := make(map[string]bool, len(records))
+	for i := range records {
+		summary.RecordsByType[records[i].Type]++
+		summary.RecordsByTTL[records[i].TTL]++
+		names[strings.ToLower(records[i].Name)] = true
+	}
+
+	for i := range records {
+		if records[i].Type != "CNAME" {
+			continue
+		}
+
+		target := strings.ToLower(strings.TrimSuffix(records[i].Data, "."))
+		if target == zone || strings.HasSuffix(target, "."+zone) {
+			if !names[strings.TrimSuffix(strings.TrimSuffix(target, zone), ".")] {
+				summary.DanglingCNAMEs = append(summary.DanglingCNAMEs, records[i])
+			}
+			continue
+		}
+
+		var dnsErr *net.DNSError
+		if _, err := d.client.resolver().LookupHost(ctx, target); errors.As(err, &dnsErr) && dnsErr.IsNotFound {
+			summary.DanglingCNAMEs = append(summary.DanglingCNAMEs, records[i])
+		}
+	}
+
+	return summary, nil
+}
+
+// VerifyPropagation queries each resolver, given as host:port, until all of
+// them serve the record's data or the wait times out, and returns the latest
+// answer from each. The A, AAAA, CNAME, MX, NS and TXT record types are
+// supported. If options is nil the wait polls every 5 seconds for up to twice
+// the record's TTL plus a minute, by which time cached answers have expired.
+// Queries are made with the lookup set by SetRecordLookup.
+func (d *DomainRecordsServiceHandler) VerifyPropagation(ctx context.Context, domain string, record *DomainRecord, resolvers []string, options *WaitOptions) ([]PropagationResult, error) { //nolint:lll
+	if len(resolvers) == 0 {
+		resolvers = PublicResolvers()
+	}
+
+	if options == nil {
+		options = &WaitOptions{
+			Interval: 5 * time.Second,
+			Timeout:  2*time.Duration(record.TTL)*time.Second + time.Minute,
+		}
+	}
+
+	name := domain
+	if record.Name != "" {
+		name = record.Name + "." + domain
+	}
+	expected := normalizeRecordValue(record.Type, record.Data)
+
+	lookup := d.client.lookupRecordAt()
+	results := make([]PropagationResult, len(resolvers))
+	err := waitFor(ctx, options, func(ctx context.Context) (string, bool, error) {
+		propagated := 0
+		for i, resolver := range resolvers {
+			result := PropagationResult{Resolver: resolver}
+			values, err := lookup(ctx, resolver, name, record.Type)
+			if err != nil {
+				result.Error = err.Error()
+			}
+
+			for _, value := range values {
+				value = normalizeRecordValue(record.Type, value)
+				result.Values = append(result.Values, value)
+				if value == expected {
+					result.Propagated = true
+				}
+			}
+
+			if result.Propagated {
+				propagated++
+			}
+			results[i] = result
+		}
+
+		return fmt.Sprintf("%d/%d resolvers", propagated, len(resolvers)), propagated == len(resolvers), nil
+	})
+
+	return results, err
+}
+
+func lookupRecord(ctx context.Context, address, name, recordType string) ([]string, error) {
+	resolver := &net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, network, _ string) (net.Conn, error) {
+			var dialer net.Dialer
+			return dialer.DialContext(ctx, network, address)
+		},
+	}
+
+	var values []string
+	switch strings.ToUpper(recordType) {
+	case "A", "AAAA":
+		network := "ip4"
+		if strings.EqualFold(recordType, "AAAA") {
+			network = "ip6"
+		}
+		ips, err := resolver.LookupIP(ctx, network, name)
+		if err != nil {
+			return nil, err
+		}
+		for _, ip := range ips {
+			values = append(values, ip.String())
+		}
+	case "CNAME":
+		cname, err := resolver.LookupCNAME(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, cname)
+	case "MX":
+		records, err := resolver.LookupMX(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		for _, mx := range records {
+			values = append(values, mx.Host)
+		}
+	case "NS":
+		records, err := resolver.LookupNS(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		for _, ns := range records {
+			values = append(values, ns.Host)
+		}
+	case "TXT":
+		return resolver.LookupTXT(ctx, name)
+	default:
+		return nil, fmt.Errorf("cannot verify propagation of %s records", recordType)
+	}
+
+	return values, nil
+}
+
+func normalizeRecordValue(recordType, value string) string {
+	switch strings.ToUpper(recordType) {
+	case "A", "AAAA":
+		if addr, err := netip.ParseAddr(value); err == nil {
+			return addr.String()
+		}
+	case "CNAME", "MX", "NS":
+		return strings.ToLower(strings.TrimSuffix(value, "."))
+	case "TXT":
+		return strings.Trim(value, `"`)
+	}
+	return value
+}
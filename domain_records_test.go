@@ -1,10 +1,16 @@
 package govultr
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"reflect"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 )
 
 func TestDomainRecordsServiceHandler_Create(t *testing.T) {
@@ -148,3 +154,277 @@ func TestDomainRecordsServiceHandler_List(t *testing.T) {
 		t.Errorf("DomainRecord.List meta returned %+v, expected %+v", meta, expectedMeta)
 	}
 }
+
+func TestDomainRecordsServiceHandler_ReplaceRecordSet(t *testing.T) {
+	setup()
+	defer teardown()
+
+	records := map[string]DomainRecord{
+		"a1": {ID: "a1", Type: "A", Name: "www", Data: "192.0.2.1", TTL: 300},
+		"a2": {ID: "a2", Type: "A", Name: "www", Data: "192.0.2.2", TTL: 300},
+		"w1": {ID: "w1", Type: "A", Name: "*", Data: "192.0.2.9", TTL: 300},
+	}
+	next := 0
+	var ops []string
+
+	mux.HandleFunc("/v2/domains/vultr.com/records", func(writer http.ResponseWriter, request *http.Request) {
+		if request.Method == http.MethodPost {
+			var req DomainRecordReq
+			_ = json.NewDecoder(request.Body).Decode(&req)
+			next++
+			record := DomainRecord{ID: fmt.Sprintf("n%d", next), Type: req.Type, Name: req.Name, Data: req.Data, TTL: req.TTL}
+			records[record.ID] = record
+			ops = append(ops, "create "+record.Data)
+			_ = json.NewEncoder(writer).Encode(domainRecordBase{Record: &record})
+			return
+		}
+
+		list := domainRecordsBase{Meta: &Meta{Links: &Links{}}}
+		for _, id := range []string{"a1", "a2", "w1", "n1", "n2"} {
+			if record, ok := records[id]; ok {
+				list.Records = append(list.Records, record)
+			}
+		}
+		_ = json.NewEncoder(writer).Encode(list)
+	})
+
+	mux.HandleFunc("/v2/domains/vultr.com/records/", func(writer http.ResponseWriter, request *http.Request) {
+		id := strings.TrimPrefix(request.URL.Path, "/v2/domains/vultr.com/records/")
+		switch request.Method {
+		case http.MethodDelete:
+			ops = append(ops, "delete "+records[id].Data)
+			delete(records, id)
+		case http.MethodPatch:
+			var req DomainRecordReq
+			_ = json.NewDecoder(request.Body).Decode(&req)
+			record := records[id]
+			record.TTL = req.TTL
+			records[id] = record
+			ops = append(ops, "update "+record.Data)
+		}
+	})
+
+	recordSet, err := client.DomainRecord.ReplaceRecordSet(ctx, "vultr.com", &RecordSetReq{
+		Name: "www",
+		Type: "A",
+		Data: []string{"192.0.2.2", "192.0.2.3"},
+		TTL:  60,
+	})
+	if err != nil {
+		t.Errorf("DomainRecord.ReplaceRecordSet returned %+v", err)
+	}
+
+	expected := &RecordSet{
+		Name: "www",
+		Type: "A",
+		Records: []DomainRecord{
+			{ID: "a2", Type: "A", Name: "www", Data: "192.0.2.2", TTL: 60},
+			{ID: "n1", Type: "A", Name: "www", Data: "192.0.2.3", TTL: 60},
+		},
+	}
+
+	if !reflect.DeepEqual(recordSet, expected) {
+		t.Errorf("DomainRecord.ReplaceRecordSet returned %+v, expected %+v", recordSet, expected)
+	}
+
+	expectedOps := []string{"create 192.0.2.3", "update 192.0.2.2", "delete 192.0.2.1"}
+	if !reflect.DeepEqual(ops, expectedOps) {
+		t.Errorf("DomainRecord.ReplaceRecordSet made changes %+v, expected %+v", ops, expectedOps)
+	}
+
+	if _, err = client.DomainRecord.CreateRecordSet(ctx, "vultr.com", &RecordSetReq{Name: "*", Type: "A", Data: []string{"192.0.2.10"}}); err == nil {
+		t.Error("DomainRecord.CreateRecordSet expected an error when the record set already exists")
+	}
+
+	if err = client.DomainRecord.DeleteRecordSet(ctx, "vultr.com", "*", "A"); err != nil {
+		t.Errorf("DomainRecord.DeleteRecordSet returned %+v", err)
+	}
+
+	if _, ok := records["w1"]; ok {
+		t.Error("DomainRecord.DeleteRecordSet did not delete the wildcard record")
+	}
+}
+
+func TestDomainRecordsServiceHandler_VerifyPropagation(t *testing.T) {
+	setup()
+	defer teardown()
+
+	lookups := 0
+	client.SetRecordLookup(func(ctx context.Context, address, name, recordType string) ([]string, error) {
+		if name != "www.vultr.com" || recordType != "CNAME" {
+			t.Errorf("RecordLookup(%s, %s, %s), expected www.vultr.com CNAME", address, name, recordType)
+		}
+
+		lookups++
+		if address == "192.0.2.53:53" && lookups < 3 {
+			return []string{"old.example.com."}, nil
+		}
+		return []string{"Edge.Example.com."}, nil
+	})
+
+	record := &DomainRecord{Type: "CNAME", Name: "www", Data: "edge.example.com", TTL: 300}
+	options := &WaitOptions{Interval: time.Millisecond}
+	results, err := client.DomainRecord.VerifyPropagation(ctx, "vultr.com", record, []string{"1.1.1.1:53", "192.0.2.53:53"}, options)
+	if err != nil {
+		t.Errorf("DomainRecord.VerifyPropagation returned %+v", err)
+	}
+
+	expected := []PropagationResult{
+		{Resolver: "1.1.1.1:53", Values: []string{"edge.example.com"}, Propagated: true},
+		{Resolver: "192.0.2.53:53", Values: []string{"edge.example.com"}, Propagated: true},
+	}
+
+	if !reflect.DeepEqual(results, expected) {
+		t.Errorf("DomainRecord.VerifyPropagation returned %+v, expected %+v", results, expected)
+	}
+
+	client.SetRecordLookup(func(ctx context.Context, address, name, recordType string) ([]string, error) {
+		return nil, errors.New("no such host")
+	})
+
+	options.Timeout = 5 * time.Millisecond
+	results, err = client.DomainRecord.VerifyPropagation(ctx, "vultr.com", record, []string{"1.1.1.1:53"}, options)
+	if err == nil {
+		t.Error("DomainRecord.VerifyPropagation expected an error when the record never propagates")
+	}
+
+	expected = []PropagationResult{{Resolver: "1.1.1.1:53", Error: "no such host"}}
+	if !reflect.DeepEqual(results, expected) {
+		t.Errorf("DomainRecord.VerifyPropagation returned %+v, expected %+v", results, expected)
+	}
+}
+
+func TestDomainRecordsServiceHandler_Summarize(t *testing.T) {
+	setup()
+	defer teardown()
+
+	client.SetDNSResolver(&stubResolver{hosts: map[string][]string{"docs.example.net": {"192.0.2.1"}}})
+
+	mux.HandleFunc("/v2/domains/vultr.com/records", func(writer http.ResponseWriter, request *http.Request) {
+		response := `{"records": [
+			{"id": "1", "type": "A", "name": "", "data": "192.0.2.10", "ttl": 300},
+			{"id": "2", "type": "CNAME", "name": "www", "data": "vultr.com", "ttl": 300},
+			{"id": "3", "type": "CNAME", "name": "old", "data": "legacy.vultr.com", "ttl": 3600},
+			{"id": "4", "type": "CNAME", "name": "cdn", "data": "gone.example.net", "ttl": 3600},
+			{"id": "5", "type": "CNAME", "name": "docs", "data": "docs.example.net", "ttl": 3600}
+		], "meta": {"total": 5, "links": {"next": "", "prev": ""}}}`
+		fmt.Fprint(writer, response)
+	})
+
+	summary, err := client.DomainRecord.Summarize(ctx, "vultr.com")
+	if err != nil {
+		t.Errorf("DomainRecord.Summarize returned %+v", err)
+	}
+
+	expected := &ZoneSummary{
+		Domain:        "vultr.com",
+		Records:       5,
+		RecordsByType: map[string]int{"A": 1, "CNAME": 4},
+		RecordsByTTL:  map[int]int{300: 2, 3600: 3},
+		DanglingCNAMEs: []DomainRecord{
+			{ID: "3", Type: "CNAME", Name: "old", Data: "legacy.vultr.com", TTL: 3600},
+			{ID: "4", Type: "CNAME", Name: "cdn", Data: "gone.example.net", TTL: 3600},
+		},
+	}
+
+	if !reflect.DeepEqual(summary, expected) {
+		t.Errorf("DomainRecord.Summarize returned %+v, expected %+v", summary, expected)
+	}
+}
+
+func TestDomainRecordsServiceHandler_ApplyToZones(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var mu sync.Mutex
+	running, peak := 0, 0
+	failed := errors.New("failed")
+
+	domains := []string{"a.com", "b.com", "c.com", "d.com", "e.com"}
+	results := client.DomainRecord.ApplyToZones(ctx, domains, 2, func(ctx context.Context, domain string) (*RecordSet, error) {
+		mu.Lock()
+		running++
+		if running > peak {
+			peak = running
+		}
+		mu.Unlock()
+
+		time.Sleep(5 * time.Millisecond)
+
+		mu.Lock()
+		running--
+		mu.Unlock()
+
+		if domain == "c.com" {
+			return nil, failed
+		}
+		return &RecordSet{Name: domain}, nil
+	})
+
+	if peak > 2 {
+		t.Errorf("DomainRecord.ApplyToZones ran %d domains at once, expected at most 2", peak)
+	}
+
+	for i, result := range results {
+		if result.Domain != domains[i] {
+			t.Errorf("DomainRecord.ApplyToZones returned %s at %d, expected %s", result.Domain, i, domains[i])
+		}
+
+		if result.Domain == "c.com" {
+			if !errors.Is(result.Error, failed) {
+				t.Errorf("DomainRecord.ApplyToZones returned %+v for c.com, expected %+v", result.Error, failed)
+			}
+			continue
+		}
+
+		if result.Error != nil || result.RecordSet.Name != result.Domain {
+			t.Errorf("DomainRecord.ApplyToZones returned %+v", result)
+		}
+	}
+
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	results = client.DomainRecord.ApplyToZones(canceled, domains, 1, func(ctx context.Context, domain string) (*RecordSet, error) {
+		return nil, nil
+	})
+
+	if !errors.Is(results[len(results)-1].Error, context.Canceled) {
+		t.Errorf("DomainRecord.ApplyToZones returned %+v, expected the last domain to be canceled", results[len(results)-1])
+	}
+}
+
+func TestDomainRecordsServiceHandler_ReplaceRecordSetInZones(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var mu sync.Mutex
+	var created []string
+	mux.HandleFunc("/v2/domains/vultr.com/records", func(writer http.ResponseWriter, request *http.Request) {
+		if request.Method == http.MethodPost {
+			mu.Lock()
+			created = append(created, "vultr.com")
+			mu.Unlock()
+			fmt.Fprint(writer, `{"record": {"id": "n1", "type": "TXT", "name": "", "data": "v=spf1 include:_spf.vultr.com ~all"}}`)
+			return
+		}
+		fmt.Fprint(writer, `{"records": [], "meta": {"total": 0, "links": {}}}`)
+	})
+
+	mux.HandleFunc("/v2/domains/missing.com/records", func(writer http.ResponseWriter, request *http.Request) {
+		http.Error(writer, `{"error": "domain not found", "status": 404}`, http.StatusNotFound)
+	})
+
+	client.SetRetryLimit(0)
+	results := client.DomainRecord.ReplaceRecordSetInZones(ctx, []string{"vultr.com", "missing.com"}, &RecordSetReq{
+		Type: "TXT",
+		Data: []string{"v=spf1 include:_spf.vultr.com ~all"},
+	}, 2)
+
+	if results[0].Error != nil || len(created) != 1 {
+		t.Errorf("DomainRecord.ReplaceRecordSetInZones returned %+v for vultr.com", results[0])
+	}
+
+	if !IsNotFound(results[1].Error) {
+		t.Errorf("DomainRecord.ReplaceRecordSetInZones returned %+v for missing.com, expected not found", results[1].Error)
+	}
+}
@@ -4,12 +4,20 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"sort"
+	"strings"
 
 	"github.com/google/go-querystring/query"
 )
 
 const domainPath = "/v2/domains"
 
+// VultrNameservers returns the nameservers a domain must be delegated to for
+// Vultr DNS to serve it
+func VultrNameservers() []string {
+	return []string{"ns1.vultr.com", "ns2.vultr.com"}
+}
+
 // DomainService is the interface to interact with the DNS endpoints on the Vultr API
 // https://www.vultr.com/api/#tag/dns
 type DomainService interface {
@@ -23,6 +31,8 @@ type DomainService interface {
 	UpdateSoa(ctx context.Context, domain string, soaReq *Soa) error
 
 	GetDNSSec(ctx context.Context, domain string) ([]string, *http.Response, error)
+
+	CheckDelegation(ctx context.Context, domain string) (*DomainDelegation, error)
 }
 
 // DomainServiceHandler handles interaction with the DNS methods for the Vultr API
@@ -64,6 +74,20 @@ type soaBase struct {
 	DNSSoa *Soa `json:"dns_soa,omitempty"`
 }
 
+// DomainDelegation represents how a domain is delegated in public DNS compared
+// to the Vultr nameservers
+type DomainDelegation struct {
+	Domain      string
+	Nameservers []string
+	Missing     []string
+	Unexpected  []string
+}
+
+// Delegated reports whether the domain is delegated to exactly the Vultr nameservers
+func (d *DomainDelegation) Delegated() bool {
+	return len(d.Missing) == 0 && len(d.Unexpected) == 0
+}
+
 type dnsSecBase struct {
 	DNSSec []string `json:"dns_sec,omitempty"`
 }
@@ -188,3 +212,40 @@ func (d *DomainServiceHandler) GetDNSSec(ctx context.Context, domain string) ([]
 
 	return dnsSec.DNSSec, resp, nil
 }
+
+// CheckDelegation looks up the public NS records of a domain and compares them
+// to the Vultr nameservers. The API does not expose registrar data such as
+// transfer or lock status, so this is limited to what DNS reports. Lookups use
+// the resolver set with SetDNSResolver.
+func (d *DomainServiceHandler) CheckDelegation(ctx context.Context, domain string) (*DomainDelegation, error) {
+	records, err := d.client.resolver().LookupNS(ctx, domain)
+	if err != nil {
+		return nil, err
+	}
+
+	delegation := &DomainDelegation{Domain: domain}
+	found := make(map[string]bool, len(records))
+	for _, record := range records {
+		host := strings.ToLower(strings.TrimSuffix(record.Host, "."))
+		found[host] = true
+		delegation.Nameservers = append(delegation.Nameservers, host)
+	}
+	sort.Strings(delegation.Nameservers)
+
+	nameservers := VultrNameservers()
+	expected := make(map[string]bool, len(nameservers))
+	for _, ns := range nameservers {
+		expected[ns] = true
+		if !found[ns] {
+			delegation.Missing = append(delegation.Missing, ns)
+		}
+	}
+
+	for _, ns := range delegation.Nameservers {
+		if !expected[ns] {
+			delegation.Unexpected = append(delegation.Unexpected, ns)
+		}
+	}
+
+	return delegation, nil
+}
@@ -1,7 +1,9 @@
 package govultr
 
 import (
+	"context"
 	"fmt"
+	"net"
 	"net/http"
 	"reflect"
 	"testing"
@@ -193,3 +195,52 @@ func TestDNSDomainServiceHandler_DNSSecInfo(t *testing.T) {
 		t.Errorf("Domain.GetDnsSec returned %+v, expected %+v", dnsSec, expected)
 	}
 }
+
+// stubResolver is a DNSResolver answering from fixed records
+type stubResolver struct {
+	hosts map[string][]string
+	ns    map[string][]*net.NS
+}
+
+func (r *stubResolver) LookupHost(_ context.Context, host string) ([]string, error) {
+	if addresses, ok := r.hosts[host]; ok {
+		return addresses, nil
+	}
+	return nil, &net.DNSError{Err: "no such host", Name: host, IsNotFound: true}
+}
+
+func (r *stubResolver) LookupNS(_ context.Context, name string) ([]*net.NS, error) {
+	if records, ok := r.ns[name]; ok {
+		return records, nil
+	}
+	return nil, &net.DNSError{Err: "no such host", Name: name, IsNotFound: true}
+}
+
+func TestDomainServiceHandler_CheckDelegation(t *testing.T) {
+	setup()
+	defer teardown()
+
+	client.SetDNSResolver(&stubResolver{
+		ns: map[string][]*net.NS{"vultr.com": {{Host: "NS1.vultr.com."}, {Host: "ns1.example.com."}}},
+	})
+
+	delegation, err := client.Domain.CheckDelegation(ctx, "vultr.com")
+	if err != nil {
+		t.Errorf("Domain.CheckDelegation returned %+v", err)
+	}
+
+	expected := &DomainDelegation{
+		Domain:      "vultr.com",
+		Nameservers: []string{"ns1.example.com", "ns1.vultr.com"},
+		Missing:     []string{"ns2.vultr.com"},
+		Unexpected:  []string{"ns1.example.com"},
+	}
+
+	if !reflect.DeepEqual(delegation, expected) {
+		t.Errorf("Domain.CheckDelegation returned %+v, expected %+v", delegation, expected)
+	}
+
+	if delegation.Delegated() {
+		t.Error("DomainDelegation.Delegated returned true, expected false")
+	}
+}
@@ -0,0 +1,221 @@
+package govultr
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/go-retryablehttp"
+)
+
+// requestIDHeader is the header a request ID is read from when the API sends one
+const requestIDHeader = "X-Request-Id"
+
+// ErrorCategory classifies errors returned by the client
+type ErrorCategory int
+
+// Error categories returned by ErrorCategoryOf
+const (
+	ErrorCategoryUnknown ErrorCategory = iota
+	ErrorCategoryThrottled
+	ErrorCategoryNotFound
+	ErrorCategoryConflict
+	ErrorCategoryValidation
+	ErrorCategoryAuthZ
+	ErrorCategoryTransient
+)
+
+var errorCategoryNames = map[ErrorCategory]string{
+	ErrorCategoryUnknown:    "unknown",
+	ErrorCategoryThrottled:  "throttled",
+	ErrorCategoryNotFound:   "not found",
+	ErrorCategoryConflict:   "conflict",
+	ErrorCategoryValidation: "validation",
+	ErrorCategoryAuthZ:      "authz",
+	ErrorCategoryTransient:  "transient",
+}
+
+func (c ErrorCategory) String() string {
+	return errorCategoryNames[c]
+}
+
+// IsRetryable reports whether a request failing with an error of this category
+// may succeed when sent again unchanged
+func (c ErrorCategory) IsRetryable() bool {
+	return c == ErrorCategoryThrottled || c == ErrorCategoryTransient
+}
+
+// statusCategory classifies an HTTP status. Status 0 is what retryablehttp
+// reports for a response without a valid status.
+func statusCategory(status int) ErrorCategory {
+	switch {
+	case status == http.StatusTooManyRequests:
+		return ErrorCategoryThrottled
+	case status == http.StatusNotFound:
+		return ErrorCategoryNotFound
+	case status == http.StatusConflict || status == http.StatusPreconditionFailed:
+		return ErrorCategoryConflict
+	case status == http.StatusBadRequest || status == http.StatusUnprocessableEntity:
+		return ErrorCategoryValidation
+	case status == http.StatusUnauthorized || status == http.StatusForbidden:
+		return ErrorCategoryAuthZ
+	case status == 0 || status == http.StatusRequestTimeout ||
+		(status >= http.StatusInternalServerError && status != http.StatusNotImplemented):
+		return ErrorCategoryTransient
+	}
+
+	return ErrorCategoryUnknown
+}
+
+// ErrorCategoryOf classifies an error returned by the client. API errors are
+// classified by status and failures to reach the API that retryablehttp would
+// retry are transient. Cancellation and errors raised by the client itself,
+// such as delete protection, are unknown.
+func ErrorCategoryOf(err error) ErrorCategory {
+	var errResp *ErrorResponse
+	if errors.As(err, &errResp) {
+		return errResp.Category()
+	}
+
+	if err == nil || errors.Is(err, context.Canceled) {
+		return ErrorCategoryUnknown
+	}
+
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		if retry, _ := retryablehttp.DefaultRetryPolicy(context.Background(), nil, urlErr); retry {
+			return ErrorCategoryTransient
+		}
+		return ErrorCategoryUnknown
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return ErrorCategoryTransient
+	}
+
+	return ErrorCategoryUnknown
+}
+
+// IsRetryable reports whether the request that failed with err may succeed
+// when sent again unchanged
+func IsRetryable(err error) bool {
+	return ErrorCategoryOf(err).IsRetryable()
+}
+
+// ErrorResponse is returned for any API response outside the 2xx range. Its
+// Error method returns the response body, as errors from this package always
+// have, so existing string handling keeps working.
+type ErrorResponse struct {
+	// Response is the HTTP response, with its body already read
+	Response *http.Response `json:"-"`
+	// StatusCode is the HTTP status of the response
+	StatusCode int `json:"-"`
+	// Code is the status the API reports in the error body, usually the same
+	// as StatusCode
+	Code int `json:"status"`
+	// Message is the error message from the API
+	Message string `json:"error"`
+	// RequestID identifies the request to Vultr support, when the API sends one
+	RequestID string `json:"-"`
+	// Body is the raw response body
+	Body []byte `json:"-"`
+}
+
+func (e *ErrorResponse) Error() string {
+	return string(e.Body)
+}
+
+// Category classifies the error by its HTTP status
+func (e *ErrorResponse) Category() ErrorCategory {
+	return statusCategory(e.StatusCode)
+}
+
+// IsRetryable reports whether the request may succeed when sent again unchanged
+func (e *ErrorResponse) IsRetryable() bool {
+	return e.Category().IsRetryable()
+}
+
+// newErrorResponse builds an ErrorResponse from a response and its body. A body
+// that is not a Vultr error object leaves Code and Message empty.
+func newErrorResponse(res *http.Response, body []byte) *ErrorResponse {
+	errResp := &ErrorResponse{
+		Response:   res,
+		StatusCode: res.StatusCode,
+		RequestID:  res.Header.Get(requestIDHeader),
+		Body:       body,
+	}
+	_ = json.Unmarshal(body, errResp)
+
+	return errResp
+}
+
+// retryError is returned when requests are retried until the retry limit is
+// reached and keeps the last error response available to errors.As
+type retryError struct {
+	attempts int
+	last     *ErrorResponse
+}
+
+func (e *retryError) Error() string {
+	return fmt.Sprintf("gave up after %d attempts, last error: %#v", e.attempts, strings.TrimSpace(string(e.last.Body)))
+}
+
+func (e *retryError) Unwrap() error {
+	return e.last
+}
+
+// IsNotFound reports whether err is an API response with status 404
+func IsNotFound(err error) bool {
+	return hasStatus(err, http.StatusNotFound)
+}
+
+// IsRateLimited reports whether err is an API response with status 429,
+// including one that was still rate limited after every retry
+func IsRateLimited(err error) bool {
+	return hasStatus(err, http.StatusTooManyRequests)
+}
+
+// quotaMessages are the lower case phrases the API uses when an account limit
+// has been reached
+var quotaMessages = []string{
+	"reached the maximum number of",
+	"instance limit reached",
+	"monthly fee limit",
+	"quota exceeded",
+}
+
+// IsQuotaExceeded reports whether err is an API response refusing a request
+// because an account limit, such as the instance or monthly fee limit, has
+// been reached. The API has no dedicated status for this, so the message of
+// 4xx responses other than 429 is checked against the limit messages it uses.
+func IsQuotaExceeded(err error) bool {
+	var errResp *ErrorResponse
+	if !errors.As(err, &errResp) {
+		return false
+	}
+
+	if errResp.StatusCode < http.StatusBadRequest || errResp.StatusCode >= http.StatusInternalServerError ||
+		errResp.StatusCode == http.StatusTooManyRequests {
+		return false
+	}
+
+	message := strings.ToLower(errResp.Message)
+	for _, phrase := range quotaMessages {
+		if strings.Contains(message, phrase) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func hasStatus(err error, status int) bool {
+	var errResp *ErrorResponse
+	return errors.As(err, &errResp) && errResp.StatusCode == status
+}
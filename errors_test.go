@@ -0,0 +1,132 @@
+package govultr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+func TestErrorResponse(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v2/instances/missing", func(writer http.ResponseWriter, request *http.Request) {
+		writer.Header().Set(requestIDHeader, "req-1")
+		http.Error(writer, `{"error": "instance not found", "status": 404}`, http.StatusNotFound)
+	})
+
+	_, _, err := client.Instance.Get(ctx, "missing")
+
+	var errResp *ErrorResponse
+	if !errors.As(err, &errResp) {
+		t.Fatalf("Instance.Get returned %+v, expected an ErrorResponse", err)
+	}
+
+	expected := &ErrorResponse{StatusCode: http.StatusNotFound, Code: 404, Message: "instance not found", RequestID: "req-1"}
+	got := &ErrorResponse{StatusCode: errResp.StatusCode, Code: errResp.Code, Message: errResp.Message, RequestID: errResp.RequestID}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("Instance.Get returned %+v, expected %+v", got, expected)
+	}
+
+	if err.Error() != `{"error": "instance not found", "status": 404}`+"\n" {
+		t.Errorf("ErrorResponse.Error returned %q, expected the response body", err.Error())
+	}
+
+	if !IsNotFound(err) || IsRateLimited(err) || IsQuotaExceeded(err) {
+		t.Errorf("error helpers misclassified %+v", err)
+	}
+}
+
+func TestErrorResponse_Retried(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v2/instances", func(writer http.ResponseWriter, request *http.Request) {
+		http.Error(writer, `{"error": "rate limit exceeded", "status": 429}`, http.StatusTooManyRequests)
+	})
+
+	client.SetRetryLimit(0)
+	_, _, _, err := client.Instance.List(ctx, nil)
+	if !IsRateLimited(err) {
+		t.Errorf("Instance.List returned %+v, expected a rate limited error", err)
+	}
+
+	if IsQuotaExceeded(err) {
+		t.Errorf("IsQuotaExceeded(%+v) returned true", err)
+	}
+}
+
+func TestIsQuotaExceeded(t *testing.T) {
+	tests := []struct {
+		err      error
+		expected bool
+	}{
+		{&ErrorResponse{StatusCode: http.StatusBadRequest, Message: "You have reached the maximum number of active instances"}, true},
+		{&ErrorResponse{StatusCode: http.StatusBadRequest, Message: "Monthly fee limit reached"}, true},
+		{&ErrorResponse{StatusCode: http.StatusBadRequest, Message: "Invalid plan"}, false},
+		{&ErrorResponse{StatusCode: http.StatusBadRequest, Message: "Label exceeds maximum length"}, false},
+		{&ErrorResponse{StatusCode: http.StatusBadRequest, Message: "Rate limit reached, the request exceeded the maximum"}, false},
+		{&ErrorResponse{StatusCode: http.StatusInternalServerError, Message: "quota service unavailable"}, false},
+		{errors.New("maximum"), false},
+	}
+
+	for _, test := range tests {
+		if got := IsQuotaExceeded(test.err); got != test.expected {
+			t.Errorf("IsQuotaExceeded(%+v) returned %t, expected %t", test.err, got, test.expected)
+		}
+	}
+}
+
+func TestErrorCategoryOf(t *testing.T) {
+	tests := []struct {
+		err       error
+		expected  ErrorCategory
+		retryable bool
+	}{
+		{&ErrorResponse{StatusCode: http.StatusTooManyRequests}, ErrorCategoryThrottled, true},
+		{&ErrorResponse{StatusCode: http.StatusNotFound}, ErrorCategoryNotFound, false},
+		{&ErrorResponse{StatusCode: http.StatusConflict}, ErrorCategoryConflict, false},
+		{&ErrorResponse{StatusCode: http.StatusBadRequest}, ErrorCategoryValidation, false},
+		{&ErrorResponse{StatusCode: http.StatusForbidden}, ErrorCategoryAuthZ, false},
+		{&ErrorResponse{StatusCode: http.StatusBadGateway}, ErrorCategoryTransient, true},
+		{&ErrorResponse{StatusCode: http.StatusNotImplemented}, ErrorCategoryUnknown, false},
+		{&retryError{attempts: 4, last: &ErrorResponse{StatusCode: http.StatusServiceUnavailable}}, ErrorCategoryTransient, true},
+		{&url.Error{Op: "Get", URL: "https://api.vultr.com", Err: errors.New("connection reset by peer")}, ErrorCategoryTransient, true},
+		{&url.Error{Op: "Get", URL: "https://api.vultr.com", Err: errors.New("stopped after 10 redirects")}, ErrorCategoryUnknown, false},
+		{fmt.Errorf("wrapped: %w", context.Canceled), ErrorCategoryUnknown, false},
+		{&DeleteProtectedError{}, ErrorCategoryUnknown, false},
+	}
+
+	for _, test := range tests {
+		if category := ErrorCategoryOf(test.err); category != test.expected {
+			t.Errorf("ErrorCategoryOf(%+v) returned %s, expected %s", test.err, category, test.expected)
+		}
+		if retryable := IsRetryable(test.err); retryable != test.retryable {
+			t.Errorf("IsRetryable(%+v) returned %t, expected %t", test.err, retryable, test.retryable)
+		}
+	}
+}
+
+func TestClient_RetryPolicyCategories(t *testing.T) {
+	setup()
+	defer teardown()
+
+	attempts := 0
+	mux.HandleFunc("/v2/instances/abc", func(writer http.ResponseWriter, request *http.Request) {
+		attempts++
+		http.Error(writer, `{"error": "conflict", "status": 409}`, http.StatusConflict)
+	})
+
+	_, _, err := client.Instance.Get(ctx, "abc")
+	if ErrorCategoryOf(err) != ErrorCategoryConflict {
+		t.Errorf("Instance.Get returned %+v, expected a conflict", err)
+	}
+
+	if attempts != 1 {
+		t.Errorf("Instance.Get sent %d requests, expected a conflict not to be retried", attempts)
+	}
+}
@@ -0,0 +1,67 @@
+package fake
+
+import (
+	"context"
+	"net/http"
+	"slices"
+
+	"github.com/vultr/govultr/v3"
+)
+
+// AccountService is an in-memory govultr.AccountService. Get, GetAuthInfo and
+// Require use the account set with Fake.SetAccount, other methods fall through
+// to the embedded service and fail.
+type AccountService struct {
+	govultr.AccountService
+	fake *Fake
+}
+
+// SetAccount sets the account returned by the Account service, which has no
+// ACLs until it is set
+func (f *Fake) SetAccount(account govultr.Account) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.account = clone(&account)
+}
+
+// Get returns the account
+func (s *AccountService) Get(_ context.Context) (*govultr.Account, *http.Response, error) {
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return clone(f.account), response(http.StatusOK), nil
+}
+
+// GetAuthInfo returns the name, email and ACLs of the account
+func (s *AccountService) GetAuthInfo(ctx context.Context) (*govultr.AccountAuthInfo, *http.Response, error) {
+	account, resp, err := s.Get(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &govultr.AccountAuthInfo{Name: account.Name, Email: account.Email, ACLs: account.ACL}, resp, nil
+}
+
+// Require returns a *govultr.MissingACLError listing any of acls the account
+// has not been granted
+func (s *AccountService) Require(ctx context.Context, acls ...string) error {
+	info, _, err := s.GetAuthInfo(ctx)
+	if err != nil {
+		return err
+	}
+
+	var missing []string
+	for _, acl := range acls {
+		if !slices.Contains(info.ACLs, acl) {
+			missing = append(missing, acl)
+		}
+	}
+
+	if len(missing) > 0 {
+		return &govultr.MissingACLError{Missing: missing}
+	}
+
+	return nil
+}
@@ -0,0 +1,225 @@
+package fake
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/vultr/govultr/v3"
+)
+
+const bareMetalService = "BareMetalServer"
+
+// BareMetalServerService is an in-memory govultr.BareMetalServerService.
+// Create, CreateAndWait, Get, Update, Delete, List, the power actions,
+// Reinstall and WaitForStatus are kept in memory, other methods fall through
+// to the embedded service and fail. Bare Metal servers have no power status,
+// so the power actions are only recorded.
+type BareMetalServerService struct {
+	govultr.BareMetalServerService
+	fake *Fake
+}
+
+// Create adds a Bare Metal server that is immediately active
+func (s *BareMetalServerService) Create(_ context.Context, bmCreate *govultr.BareMetalCreate) (*govultr.BareMetalServer, *http.Response, error) { //nolint:lll
+	if bmCreate == nil || bmCreate.Region == "" || bmCreate.Plan == "" {
+		return nil, nil, apiError(http.StatusBadRequest, "region and plan are required")
+	}
+
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	id := f.newID("baremetal")
+	server := &govultr.BareMetalServer{
+		ID:          id,
+		Plan:        bmCreate.Plan,
+		Region:      bmCreate.Region,
+		Label:       bmCreate.Label,
+		Tags:        slices.Clone(bmCreate.Tags),
+		OsID:        bmCreate.OsID,
+		AppID:       bmCreate.AppID,
+		ImageID:     bmCreate.ImageID,
+		MainIP:      fmt.Sprintf("198.51.100.%d", f.counters["baremetal"]%254+1),
+		NetmaskV4:   "255.255.255.0",
+		GatewayV4:   "198.51.100.254",
+		DateCreated: f.now(),
+		Status:      "active",
+	}
+	if bmCreate.EnableIPv6 != nil && *bmCreate.EnableIPv6 {
+		server.V6Network = fmt.Sprintf("2001:db8:b:%x::", f.counters["baremetal"])
+		server.V6MainIP = server.V6Network + "1"
+		server.V6NetworkSize = 64
+	}
+	f.bareMetals.add(id, server)
+	f.record(bareMetalService, "Create", id)
+
+	return clone(server), response(http.StatusAccepted), nil
+}
+
+// CreateAndWait adds a Bare Metal server, which is active immediately
+func (s *BareMetalServerService) CreateAndWait(ctx context.Context, bmCreate *govultr.BareMetalCreate, _ *govultr.WaitOptions) (*govultr.BareMetalServer, *http.Response, error) { //nolint:lll
+	return s.Create(ctx, bmCreate)
+}
+
+// Get returns a Bare Metal server
+func (s *BareMetalServerService) Get(_ context.Context, serverID string) (*govultr.BareMetalServer, *http.Response, error) {
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	server, ok := f.bareMetals.get(serverID)
+	if !ok {
+		return nil, nil, notFound("bare metal server", serverID)
+	}
+
+	return clone(server), response(http.StatusOK), nil
+}
+
+// WaitForStatus returns a Bare Metal server if its status already is status
+func (s *BareMetalServerService) WaitForStatus(ctx context.Context, serverID, status string, _ *govultr.WaitOptions) (*govultr.BareMetalServer, *http.Response, error) { //nolint:lll
+	server, resp, err := s.Get(ctx, serverID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err = reached("bare metal server", serverID, status, server.Status); err != nil {
+		return nil, nil, err
+	}
+
+	return server, resp, nil
+}
+
+// Update changes the label, tags and image of a Bare Metal server. Tags are
+// left alone when nil.
+func (s *BareMetalServerService) Update(_ context.Context, serverID string, bmReq *govultr.BareMetalUpdate) (*govultr.BareMetalServer, *http.Response, error) { //nolint:lll
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	server, ok := f.bareMetals.get(serverID)
+	if !ok {
+		return nil, nil, notFound("bare metal server", serverID)
+	}
+
+	if bmReq != nil {
+		if bmReq.Label != "" {
+			server.Label = bmReq.Label
+		}
+		if bmReq.Tags != nil {
+			server.Tags = slices.Clone(bmReq.Tags)
+		}
+		if bmReq.OsID != 0 {
+			server.OsID, server.AppID, server.ImageID = bmReq.OsID, 0, ""
+		}
+		if bmReq.AppID != 0 {
+			server.OsID, server.AppID, server.ImageID = 0, bmReq.AppID, ""
+		}
+		if bmReq.ImageID != "" {
+			server.OsID, server.AppID, server.ImageID = 0, 0, bmReq.ImageID
+		}
+	}
+	f.record(bareMetalService, "Update", serverID)
+
+	return clone(server), response(http.StatusAccepted), nil
+}
+
+// Delete removes a Bare Metal server
+func (s *BareMetalServerService) Delete(_ context.Context, serverID string) error {
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if !f.bareMetals.remove(serverID) {
+		return notFound("bare metal server", serverID)
+	}
+	f.record(bareMetalService, "Delete", serverID)
+
+	return nil
+}
+
+// List returns Bare Metal servers, filtered by the label, tag, main IP and
+// region of options like the API does
+func (s *BareMetalServerService) List(_ context.Context, options *govultr.ListOptions) ([]govultr.BareMetalServer, *govultr.Meta, *http.Response, error) { //nolint:lll
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	servers, meta := f.bareMetals.list(options, func(server *govultr.BareMetalServer) bool {
+		return options == nil ||
+			(options.Label == "" || server.Label == options.Label) &&
+				(options.Tag == "" || slices.Contains(server.Tags, options.Tag)) &&
+				(options.MainIP == "" || server.MainIP == options.MainIP) &&
+				(options.Region == "" || server.Region == options.Region)
+	})
+
+	return servers, meta, response(http.StatusOK), nil
+}
+
+// Reinstall reinstalls a Bare Metal server, which is immediately active again
+func (s *BareMetalServerService) Reinstall(_ context.Context, serverID string) (*govultr.BareMetalServer, *http.Response, error) {
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	server, ok := f.bareMetals.get(serverID)
+	if !ok {
+		return nil, nil, notFound("bare metal server", serverID)
+	}
+
+	server.Status = "active"
+	f.record(bareMetalService, "Reinstall", serverID)
+
+	return clone(server), response(http.StatusAccepted), nil
+}
+
+// Start records a start of a Bare Metal server
+func (s *BareMetalServerService) Start(_ context.Context, serverID string) error {
+	return s.power("Start", serverID)
+}
+
+// Halt records a halt of a Bare Metal server
+func (s *BareMetalServerService) Halt(_ context.Context, serverID string) error {
+	return s.power("Halt", serverID)
+}
+
+// Reboot records a reboot of a Bare Metal server
+func (s *BareMetalServerService) Reboot(_ context.Context, serverID string) error {
+	return s.power("Reboot", serverID)
+}
+
+// MassStart records a start of several Bare Metal servers
+func (s *BareMetalServerService) MassStart(_ context.Context, serverList []string) error {
+	return s.power("MassStart", serverList...)
+}
+
+// MassHalt records a halt of several Bare Metal servers
+func (s *BareMetalServerService) MassHalt(_ context.Context, serverList []string) error {
+	return s.power("MassHalt", serverList...)
+}
+
+// MassReboot records a reboot of several Bare Metal servers
+func (s *BareMetalServerService) MassReboot(_ context.Context, serverList []string) error {
+	return s.power("MassReboot", serverList...)
+}
+
+// power records a power action on servers, recording none of them when one
+// does not exist
+func (s *BareMetalServerService) power(method string, serverIDs ...string) error {
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for _, id := range serverIDs {
+		if _, ok := f.bareMetals.get(id); !ok {
+			return notFound("bare metal server", id)
+		}
+	}
+
+	for _, id := range serverIDs {
+		f.record(bareMetalService, method, id)
+	}
+
+	return nil
+}
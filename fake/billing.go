@@ -0,0 +1,95 @@
+package fake
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"strconv"
+
+	"github.com/vultr/govultr/v3"
+)
+
+// BillingService is an in-memory govultr.BillingService. ListHistory,
+// ListInvoices, ListAllInvoices, GetInvoice and ListInvoiceItems return what
+// was added with Fake.AddHistory and Fake.AddInvoice, other methods fall
+// through to the embedded service and fail.
+type BillingService struct {
+	govultr.BillingService
+	fake *Fake
+}
+
+// AddHistory adds an entry to the billing history
+func (f *Fake) AddHistory(history govultr.History) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.history.add(strconv.Itoa(history.ID), &history)
+}
+
+// AddInvoice adds an invoice with its items
+func (f *Fake) AddInvoice(invoice govultr.Invoice, items ...govultr.InvoiceItem) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	id := strconv.Itoa(invoice.ID)
+	f.invoices.add(id, &invoice)
+	f.invoiceItems[id] = slices.Clone(items)
+}
+
+// ListHistory returns the billing history
+func (s *BillingService) ListHistory(_ context.Context, options *govultr.ListOptions) ([]govultr.History, *govultr.Meta, *http.Response, error) { //nolint:lll
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	history, meta := f.history.list(options, nil)
+
+	return history, meta, response(http.StatusOK), nil
+}
+
+// ListInvoices returns invoices
+func (s *BillingService) ListInvoices(_ context.Context, options *govultr.ListOptions) ([]govultr.Invoice, *govultr.Meta, *http.Response, error) { //nolint:lll
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	invoices, meta := f.invoices.list(options, nil)
+
+	return invoices, meta, response(http.StatusOK), nil
+}
+
+// ListAllInvoices returns every invoice
+func (s *BillingService) ListAllInvoices(ctx context.Context) ([]govultr.Invoice, error) {
+	invoices, _, _, err := s.ListInvoices(ctx, nil)
+	return invoices, err
+}
+
+// GetInvoice returns an invoice
+func (s *BillingService) GetInvoice(_ context.Context, invoiceID string) (*govultr.Invoice, *http.Response, error) {
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	invoice, ok := f.invoices.get(invoiceID)
+	if !ok {
+		return nil, nil, notFound("invoice", invoiceID)
+	}
+
+	return clone(invoice), response(http.StatusOK), nil
+}
+
+// ListInvoiceItems returns the items of an invoice
+func (s *BillingService) ListInvoiceItems(_ context.Context, invoiceID int, options *govultr.ListOptions) ([]govultr.InvoiceItem, *govultr.Meta, *http.Response, error) { //nolint:lll
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	items, ok := f.invoiceItems[strconv.Itoa(invoiceID)]
+	if !ok {
+		return nil, nil, nil, notFound("invoice", strconv.Itoa(invoiceID))
+	}
+
+	list, meta := paginate(slices.Clone(items), options)
+
+	return list, meta, response(http.StatusOK), nil
+}
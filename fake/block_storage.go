@@ -0,0 +1,195 @@
+package fake
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/vultr/govultr/v3"
+)
+
+const blockStorageService = "BlockStorage"
+
+// BlockStorageService is an in-memory govultr.BlockStorageService. Create,
+// Get, Update, Delete, List, Attach, Detach and the waiters are kept in
+// memory, other methods fall through to the embedded service and fail.
+type BlockStorageService struct {
+	govultr.BlockStorageService
+	fake *Fake
+}
+
+// Create adds a block storage volume that is immediately active
+func (s *BlockStorageService) Create(_ context.Context, blockReq *govultr.BlockStorageCreate) (*govultr.BlockStorage, *http.Response, error) { //nolint:lll
+	if blockReq == nil || blockReq.Region == "" || blockReq.SizeGB <= 0 {
+		return nil, nil, apiError(http.StatusBadRequest, "region and size_gb are required")
+	}
+
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	id := f.newID("block")
+	blockType := blockReq.BlockType
+	if blockType == "" {
+		blockType = "high_perf"
+	}
+	block := &govultr.BlockStorage{
+		ID:          id,
+		Status:      "active",
+		SizeGB:      blockReq.SizeGB,
+		Region:      blockReq.Region,
+		DateCreated: f.now(),
+		Label:       blockReq.Label,
+		MountID:     fmt.Sprintf("%s-%s", blockReq.Region, id),
+		BlockType:   blockType,
+	}
+	f.blocks.add(id, block)
+	f.record(blockStorageService, "Create", id)
+
+	return clone(block), response(http.StatusAccepted), nil
+}
+
+// Get returns a block storage volume
+func (s *BlockStorageService) Get(_ context.Context, blockID string) (*govultr.BlockStorage, *http.Response, error) {
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	block, ok := f.blocks.get(blockID)
+	if !ok {
+		return nil, nil, notFound("block storage", blockID)
+	}
+
+	return clone(block), response(http.StatusOK), nil
+}
+
+// Update changes the size and label of a block storage volume
+func (s *BlockStorageService) Update(_ context.Context, blockID string, blockReq *govultr.BlockStorageUpdate) error {
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	block, ok := f.blocks.get(blockID)
+	if !ok {
+		return notFound("block storage", blockID)
+	}
+
+	if blockReq != nil {
+		if blockReq.SizeGB != 0 {
+			if blockReq.SizeGB < block.SizeGB {
+				return apiError(http.StatusBadRequest, "block storage can not be shrunk")
+			}
+			block.SizeGB = blockReq.SizeGB
+		}
+		if blockReq.Label != "" {
+			block.Label = blockReq.Label
+		}
+	}
+	f.record(blockStorageService, "Update", blockID)
+
+	return nil
+}
+
+// Delete removes a block storage volume
+func (s *BlockStorageService) Delete(_ context.Context, blockID string) error {
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if !f.blocks.remove(blockID) {
+		return notFound("block storage", blockID)
+	}
+	f.record(blockStorageService, "Delete", blockID)
+
+	return nil
+}
+
+// List returns block storage volumes
+func (s *BlockStorageService) List(_ context.Context, options *govultr.ListOptions) ([]govultr.BlockStorage, *govultr.Meta, *http.Response, error) { //nolint:lll
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	blocks, meta := f.blocks.list(options, nil)
+
+	return blocks, meta, response(http.StatusOK), nil
+}
+
+// Attach attaches a block storage volume to an existing instance
+func (s *BlockStorageService) Attach(_ context.Context, blockID string, attach *govultr.BlockStorageAttach) error {
+	if attach == nil || attach.InstanceID == "" {
+		return apiError(http.StatusBadRequest, "instance_id is required")
+	}
+
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	block, ok := f.blocks.get(blockID)
+	if !ok {
+		return notFound("block storage", blockID)
+	}
+
+	if _, ok = f.instances.get(attach.InstanceID); !ok {
+		return notFound("instance", attach.InstanceID)
+	}
+
+	if block.AttachedToInstance != "" {
+		return apiError(http.StatusBadRequest, "block storage %s is already attached to %s", blockID, block.AttachedToInstance)
+	}
+
+	block.AttachedToInstance = attach.InstanceID
+	f.record(blockStorageService, "Attach", blockID)
+
+	return nil
+}
+
+// Detach detaches a block storage volume from its instance
+func (s *BlockStorageService) Detach(_ context.Context, blockID string, _ *govultr.BlockStorageDetach) error {
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	block, ok := f.blocks.get(blockID)
+	if !ok {
+		return notFound("block storage", blockID)
+	}
+
+	if block.AttachedToInstance == "" {
+		return apiError(http.StatusBadRequest, "block storage %s is not attached", blockID)
+	}
+
+	block.AttachedToInstance = ""
+	f.record(blockStorageService, "Detach", blockID)
+
+	return nil
+}
+
+// WaitForStatus returns a block storage volume if its status already is status
+func (s *BlockStorageService) WaitForStatus(ctx context.Context, blockID, status string, _ *govultr.WaitOptions) (*govultr.BlockStorage, *http.Response, error) { //nolint:lll
+	block, resp, err := s.Get(ctx, blockID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err = reached("block storage", blockID, status, block.Status); err != nil {
+		return nil, nil, err
+	}
+
+	return block, resp, nil
+}
+
+// WaitForAttachment returns a block storage volume if it already is attached
+// to instanceID
+func (s *BlockStorageService) WaitForAttachment(ctx context.Context, blockID, instanceID string, _ *govultr.WaitOptions) (*govultr.BlockStorage, *http.Response, error) { //nolint:lll
+	block, resp, err := s.Get(ctx, blockID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if block.AttachedToInstance != instanceID {
+		return nil, nil, fmt.Errorf("fake: block storage %s is not attached to %s and will never be", blockID, instanceID)
+	}
+
+	return block, resp, nil
+}
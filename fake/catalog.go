@@ -0,0 +1,235 @@
+package fake
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"strconv"
+
+	"github.com/vultr/govultr/v3"
+)
+
+// ApplicationService is an in-memory govultr.ApplicationService listing the
+// applications added with Fake.AddApplication
+type ApplicationService struct {
+	govultr.ApplicationService
+	fake *Fake
+}
+
+// BackupService is an in-memory govultr.BackupService returning the backups
+// added with Fake.AddBackup
+type BackupService struct {
+	govultr.BackupService
+	fake *Fake
+}
+
+// MarketplaceService is an in-memory govultr.MarketplaceService returning the
+// variables set with Fake.SetAppVariables
+type MarketplaceService struct {
+	govultr.MarketplaceService
+	fake *Fake
+}
+
+// OSService is an in-memory govultr.OSService listing the operating systems
+// added with Fake.AddOS
+type OSService struct {
+	govultr.OSService
+	fake *Fake
+}
+
+// PlanService is an in-memory govultr.PlanService. List and ListBareMetal
+// return the plans added with Fake.AddPlan and Fake.AddBareMetalPlan, other
+// methods fall through to the embedded service and fail.
+type PlanService struct {
+	govultr.PlanService
+	fake *Fake
+}
+
+// RegionService is an in-memory govultr.RegionService. List returns the
+// regions added with Fake.AddRegion and Availability the plans whose locations
+// include a region, other methods fall through to the embedded service and
+// fail.
+type RegionService struct {
+	govultr.RegionService
+	fake *Fake
+}
+
+// AddApplication adds an application to the catalog
+func (f *Fake) AddApplication(application govultr.Application) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.applications.add(strconv.Itoa(application.ID), &application)
+}
+
+// AddBackup adds an instance backup
+func (f *Fake) AddBackup(backup govultr.Backup) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.backups.add(backup.ID, &backup)
+}
+
+// SetAppVariables sets the variables of a Marketplace app
+func (f *Fake) SetAppVariables(imageID string, variables ...govultr.MarketplaceAppVariable) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.appVariables[imageID] = slices.Clone(variables)
+}
+
+// AddOS adds an operating system to the catalog
+func (f *Fake) AddOS(os govultr.OS) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.operatingSystems.add(strconv.Itoa(os.ID), &os)
+}
+
+// AddPlan adds a plan to the catalog
+func (f *Fake) AddPlan(plan govultr.Plan) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.plans.add(plan.ID, &plan)
+}
+
+// AddBareMetalPlan adds a Bare Metal plan to the catalog
+func (f *Fake) AddBareMetalPlan(plan govultr.BareMetalPlan) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.bareMetalPlans.add(plan.ID, &plan)
+}
+
+// AddRegion adds a region to the catalog
+func (f *Fake) AddRegion(region govultr.Region) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.regions.add(region.ID, &region)
+}
+
+// List returns applications
+func (s *ApplicationService) List(_ context.Context, options *govultr.ListOptions) ([]govultr.Application, *govultr.Meta, *http.Response, error) { //nolint:lll
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	applications, meta := f.applications.list(options, nil)
+
+	return applications, meta, response(http.StatusOK), nil
+}
+
+// Get returns a backup
+func (s *BackupService) Get(_ context.Context, backupID string) (*govultr.Backup, *http.Response, error) {
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	backup, ok := f.backups.get(backupID)
+	if !ok {
+		return nil, nil, notFound("backup", backupID)
+	}
+
+	return clone(backup), response(http.StatusOK), nil
+}
+
+// List returns backups, filtered by the description of options like the API does
+func (s *BackupService) List(_ context.Context, options *govultr.ListOptions) ([]govultr.Backup, *govultr.Meta, *http.Response, error) {
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	backups, meta := f.backups.list(options, func(backup *govultr.Backup) bool {
+		return options == nil || options.Description == "" || backup.Description == options.Description
+	})
+
+	return backups, meta, response(http.StatusOK), nil
+}
+
+// ListAppVariables returns the variables of a Marketplace app
+func (s *MarketplaceService) ListAppVariables(_ context.Context, imageID string) ([]govultr.MarketplaceAppVariable, *http.Response, error) { //nolint:lll
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	variables, ok := f.appVariables[imageID]
+	if !ok {
+		return nil, nil, notFound("marketplace app", imageID)
+	}
+
+	return slices.Clone(variables), response(http.StatusOK), nil
+}
+
+// List returns operating systems
+func (s *OSService) List(_ context.Context, options *govultr.ListOptions) ([]govultr.OS, *govultr.Meta, *http.Response, error) {
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	operatingSystems, meta := f.operatingSystems.list(options, nil)
+
+	return operatingSystems, meta, response(http.StatusOK), nil
+}
+
+// List returns plans, all of them when planType is empty
+func (s *PlanService) List(_ context.Context, planType string, options *govultr.ListOptions) ([]govultr.Plan, *govultr.Meta, *http.Response, error) { //nolint:lll
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	plans, meta := f.plans.list(options, func(plan *govultr.Plan) bool {
+		return planType == "" || plan.Type == planType
+	})
+
+	return plans, meta, response(http.StatusOK), nil
+}
+
+// ListBareMetal returns Bare Metal plans
+func (s *PlanService) ListBareMetal(_ context.Context, options *govultr.ListOptions) ([]govultr.BareMetalPlan, *govultr.Meta, *http.Response, error) { //nolint:lll
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	plans, meta := f.bareMetalPlans.list(options, nil)
+
+	return plans, meta, response(http.StatusOK), nil
+}
+
+// Availability returns the plans of planType, or of every type when it is
+// empty, whose locations include a region
+func (s *RegionService) Availability(_ context.Context, regionID, planType string) (*govultr.PlanAvailability, *http.Response, error) { //nolint:lll
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if _, ok := f.regions.get(regionID); !ok {
+		return nil, nil, notFound("region", regionID)
+	}
+
+	availability := &govultr.PlanAvailability{AvailablePlans: []string{}}
+	for _, id := range f.plans.ids {
+		if plan, _ := f.plans.get(id); (planType == "" || plan.Type == planType) && slices.Contains(plan.Locations, regionID) {
+			availability.AvailablePlans = append(availability.AvailablePlans, plan.ID)
+		}
+	}
+	for _, id := range f.bareMetalPlans.ids {
+		if plan, _ := f.bareMetalPlans.get(id); (planType == "" || plan.Type == planType) && slices.Contains(plan.Locations, regionID) {
+			availability.AvailablePlans = append(availability.AvailablePlans, plan.ID)
+		}
+	}
+
+	return availability, response(http.StatusOK), nil
+}
+
+// List returns regions
+func (s *RegionService) List(_ context.Context, options *govultr.ListOptions) ([]govultr.Region, *govultr.Meta, *http.Response, error) {
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	regions, meta := f.regions.list(options, nil)
+
+	return regions, meta, response(http.StatusOK), nil
+}
@@ -0,0 +1,185 @@
+package fake
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/vultr/govultr/v3"
+)
+
+const cdnService = "CDN"
+
+// CDNService is an in-memory govultr.CDNService. The pull and push zones are
+// kept in memory, other methods fall through to the embedded service and fail.
+type CDNService struct {
+	govultr.CDNService
+	fake *Fake
+}
+
+// ListPullZones returns pull zones
+func (s *CDNService) ListPullZones(_ context.Context) ([]govultr.CDNZone, *govultr.Meta, *http.Response, error) {
+	return s.listZones(s.fake.pullZones)
+}
+
+// GetPullZone returns a pull zone
+func (s *CDNService) GetPullZone(_ context.Context, zoneID string) (*govultr.CDNZone, *http.Response, error) {
+	return s.getZone(s.fake.pullZones, "pull zone", zoneID)
+}
+
+// CreatePullZone adds an active pull zone for an origin
+func (s *CDNService) CreatePullZone(_ context.Context, zoneReq *govultr.CDNZoneReq) (*govultr.CDNZone, *http.Response, error) {
+	if zoneReq == nil || zoneReq.Label == "" || zoneReq.OriginScheme == "" || zoneReq.OriginDomain == "" {
+		return nil, nil, apiError(http.StatusBadRequest, "label, origin_scheme and origin_domain are required")
+	}
+
+	return s.createZone(s.fake.pullZones, "CreatePullZone", zoneReq)
+}
+
+// UpdatePullZone changes the settings of a pull zone
+func (s *CDNService) UpdatePullZone(_ context.Context, zoneID string, zoneReq *govultr.CDNZoneReq) (*govultr.CDNZone, *http.Response, error) { //nolint:lll
+	return s.updateZone(s.fake.pullZones, "UpdatePullZone", "pull zone", zoneID, zoneReq)
+}
+
+// DeletePullZone removes a pull zone
+func (s *CDNService) DeletePullZone(_ context.Context, zoneID string) error {
+	return s.deleteZone(s.fake.pullZones, "DeletePullZone", "pull zone", zoneID)
+}
+
+// PurgePullZone records a purge of a pull zone in its purge date
+func (s *CDNService) PurgePullZone(_ context.Context, zoneID string) error {
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	zone, ok := f.pullZones.get(zoneID)
+	if !ok {
+		return notFound("pull zone", zoneID)
+	}
+
+	zone.DatePurged = f.now()
+	f.record(cdnService, "PurgePullZone", zoneID)
+
+	return nil
+}
+
+// ListPushZones returns push zones
+func (s *CDNService) ListPushZones(_ context.Context) ([]govultr.CDNZone, *govultr.Meta, *http.Response, error) {
+	return s.listZones(s.fake.pushZones)
+}
+
+// GetPushZone returns a push zone
+func (s *CDNService) GetPushZone(_ context.Context, zoneID string) (*govultr.CDNZone, *http.Response, error) {
+	return s.getZone(s.fake.pushZones, "push zone", zoneID)
+}
+
+// CreatePushZone adds an active push zone
+func (s *CDNService) CreatePushZone(_ context.Context, zoneReq *govultr.CDNZoneReq) (*govultr.CDNZone, *http.Response, error) {
+	if zoneReq == nil || zoneReq.Label == "" {
+		return nil, nil, apiError(http.StatusBadRequest, "label is required")
+	}
+
+	return s.createZone(s.fake.pushZones, "CreatePushZone", zoneReq)
+}
+
+// UpdatePushZone changes the settings of a push zone
+func (s *CDNService) UpdatePushZone(_ context.Context, zoneID string, zoneReq *govultr.CDNZoneReq) (*govultr.CDNZone, *http.Response, error) { //nolint:lll
+	return s.updateZone(s.fake.pushZones, "UpdatePushZone", "push zone", zoneID, zoneReq)
+}
+
+// DeletePushZone removes a push zone
+func (s *CDNService) DeletePushZone(_ context.Context, zoneID string) error {
+	return s.deleteZone(s.fake.pushZones, "DeletePushZone", "push zone", zoneID)
+}
+
+func (s *CDNService) listZones(zones *collection[govultr.CDNZone]) ([]govultr.CDNZone, *govultr.Meta, *http.Response, error) {
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	list, meta := zones.list(nil, nil)
+
+	return list, meta, response(http.StatusOK), nil
+}
+
+func (s *CDNService) getZone(zones *collection[govultr.CDNZone], kind, zoneID string) (*govultr.CDNZone, *http.Response, error) {
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	zone, ok := zones.get(zoneID)
+	if !ok {
+		return nil, nil, notFound(kind, zoneID)
+	}
+
+	return clone(zone), response(http.StatusOK), nil
+}
+
+func (s *CDNService) createZone(zones *collection[govultr.CDNZone], method string, zoneReq *govultr.CDNZoneReq) (*govultr.CDNZone, *http.Response, error) { //nolint:lll
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	id := f.newID("cdn")
+	zone := &govultr.CDNZone{
+		ID:          id,
+		DateCreated: f.now(),
+		Status:      "active",
+		CDNURL:      fmt.Sprintf("%s.vultrcdn.com", id),
+	}
+	setZone(zone, zoneReq)
+	zones.add(id, zone)
+	f.record(cdnService, method, id)
+
+	return clone(zone), response(http.StatusCreated), nil
+}
+
+func (s *CDNService) updateZone(zones *collection[govultr.CDNZone], method, kind, zoneID string, zoneReq *govultr.CDNZoneReq) (*govultr.CDNZone, *http.Response, error) { //nolint:lll
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	zone, ok := zones.get(zoneID)
+	if !ok {
+		return nil, nil, notFound(kind, zoneID)
+	}
+
+	if zoneReq != nil {
+		setZone(zone, zoneReq)
+	}
+	f.record(cdnService, method, zoneID)
+
+	return clone(zone), response(http.StatusAccepted), nil
+}
+
+func (s *CDNService) deleteZone(zones *collection[govultr.CDNZone], method, kind, zoneID string) error {
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if !zones.remove(zoneID) {
+		return notFound(kind, zoneID)
+	}
+	f.record(cdnService, method, zoneID)
+
+	return nil
+}
+
+// setZone copies the settings of a request to a zone. The flags are always
+// sent, so they are always copied.
+func setZone(zone *govultr.CDNZone, zoneReq *govultr.CDNZoneReq) {
+	if zoneReq.Label != "" {
+		zone.Label = zoneReq.Label
+	}
+	if zoneReq.OriginScheme != "" {
+		zone.OriginScheme = zoneReq.OriginScheme
+	}
+	if zoneReq.OriginDomain != "" {
+		zone.OriginDomain = zoneReq.OriginDomain
+	}
+	if zoneReq.Regions != nil {
+		zone.Regions = slices.Clone(zoneReq.Regions)
+	}
+	zone.CORS, zone.GZIP, zone.BlockAI, zone.BlockBadBots = zoneReq.CORS, zoneReq.GZIP, zoneReq.BlockAI, zoneReq.BlockBadBots
+}
@@ -0,0 +1,122 @@
+package fake
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/vultr/govultr/v3"
+)
+
+const containerRegistryService = "ContainerRegistry"
+
+// ContainerRegistryService is an in-memory govultr.ContainerRegistryService.
+// Registries are kept in memory, other methods fall through to the embedded
+// service and fail.
+type ContainerRegistryService struct {
+	govultr.ContainerRegistryService
+	fake *Fake
+}
+
+// Create adds a registry
+func (s *ContainerRegistryService) Create(_ context.Context, createReq *govultr.ContainerRegistryReq) (*govultr.ContainerRegistry, *http.Response, error) { //nolint:lll
+	if createReq == nil || createReq.Name == "" || createReq.Region == "" || createReq.Plan == "" {
+		return nil, nil, apiError(http.StatusBadRequest, "name, region and plan are required")
+	}
+
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for _, id := range f.registries.ids {
+		if registry, _ := f.registries.get(id); registry.Name == createReq.Name {
+			return nil, nil, apiError(http.StatusBadRequest, "registry name %s is already in use", createReq.Name)
+		}
+	}
+
+	id := f.newID("vcr")
+	host := fmt.Sprintf("%s.vultrcr.com", createReq.Region)
+	registry := &govultr.ContainerRegistry{
+		ID:          id,
+		Name:        createReq.Name,
+		URN:         fmt.Sprintf("%s/%s", host, createReq.Name),
+		DateCreated: f.now(),
+		Public:      createReq.Public,
+		RootUser: govultr.ContainerRegistryUser{
+			UserName:    id,
+			Root:        true,
+			DateCreated: f.now(),
+		},
+		Metadata: govultr.ContainerRegistryMetadata{
+			Region: govultr.ContainerRegistryRegion{Name: createReq.Region, URN: host, BaseURL: "https://" + host},
+		},
+	}
+	f.registries.add(id, registry)
+	f.record(containerRegistryService, "Create", id)
+
+	return clone(registry), response(http.StatusCreated), nil
+}
+
+// Get returns a registry
+func (s *ContainerRegistryService) Get(_ context.Context, vcrID string) (*govultr.ContainerRegistry, *http.Response, error) { //nolint:lll
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	registry, ok := f.registries.get(vcrID)
+	if !ok {
+		return nil, nil, notFound("registry", vcrID)
+	}
+
+	return clone(registry), response(http.StatusOK), nil
+}
+
+// Update changes whether a registry is public. The plan is accepted but not
+// reflected on the registry.
+func (s *ContainerRegistryService) Update(_ context.Context, vcrID string, updateReq *govultr.ContainerRegistryUpdateReq) (*govultr.ContainerRegistry, *http.Response, error) { //nolint:lll
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	registry, ok := f.registries.get(vcrID)
+	if !ok {
+		return nil, nil, notFound("registry", vcrID)
+	}
+
+	if updateReq != nil && updateReq.Public != nil {
+		registry.Public = *updateReq.Public
+	}
+	f.record(containerRegistryService, "Update", vcrID)
+
+	return clone(registry), response(http.StatusOK), nil
+}
+
+// Delete removes a registry
+func (s *ContainerRegistryService) Delete(_ context.Context, vcrID string) error {
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if !f.registries.remove(vcrID) {
+		return notFound("registry", vcrID)
+	}
+	f.record(containerRegistryService, "Delete", vcrID)
+
+	return nil
+}
+
+// List returns registries
+func (s *ContainerRegistryService) List(_ context.Context, options *govultr.ListOptions) ([]govultr.ContainerRegistry, *govultr.Meta, *http.Response, error) { //nolint:lll
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	registries, meta := f.registries.list(options, nil)
+
+	return registries, meta, response(http.StatusOK), nil
+}
+
+// CreateAndWait adds a registry, which is ready as soon as it is created
+func (s *ContainerRegistryService) CreateAndWait(ctx context.Context, createReq *govultr.ContainerRegistryReq, _ *govultr.WaitOptions) (*govultr.ContainerRegistry, *http.Response, error) { //nolint:lll
+	return s.Create(ctx, createReq)
+}
@@ -0,0 +1,449 @@
+package fake
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/vultr/govultr/v3"
+)
+
+const databaseService = "Database"
+
+// DatabaseService is an in-memory govultr.DatabaseService. Create, Get,
+// Update, Delete, List, Resize, AttachVPC, DetachVPC, the users, the logical
+// databases and WaitForStatus are kept in memory, other methods fall through
+// to the embedded service and fail.
+type DatabaseService struct {
+	govultr.DatabaseService
+	fake *Fake
+}
+
+// Create adds a Managed Database that is immediately running, with the
+// default vultradmin user and defaultdb database
+func (s *DatabaseService) Create(_ context.Context, databaseReq *govultr.DatabaseCreateReq) (*govultr.Database, *http.Response, error) { //nolint:lll
+	if databaseReq == nil || databaseReq.DatabaseEngine == "" || databaseReq.Region == "" || databaseReq.Plan == "" {
+		return nil, nil, apiError(http.StatusBadRequest, "database_engine, region and plan are required")
+	}
+
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	id := f.newID("database")
+	database := &govultr.Database{
+		ID:                     id,
+		DateCreated:            f.now(),
+		Plan:                   databaseReq.Plan,
+		Region:                 databaseReq.Region,
+		DatabaseEngine:         databaseReq.DatabaseEngine,
+		DatabaseEngineVersion:  databaseReq.DatabaseEngineVersion,
+		VPCID:                  databaseReq.VPCID,
+		Status:                 "Running",
+		Label:                  databaseReq.Label,
+		Tag:                    databaseReq.Tag,
+		DBName:                 "defaultdb",
+		Host:                   fmt.Sprintf("%s.vultrdb.com", id),
+		User:                   "vultradmin",
+		Password:               "fake-" + id,
+		Port:                   "16751",
+		MaintenanceDOW:         databaseReq.MaintenanceDOW,
+		MaintenanceTime:        databaseReq.MaintenanceTime,
+		TrustedIPs:             slices.Clone(databaseReq.TrustedIPs),
+		MySQLSQLModes:          slices.Clone(databaseReq.MySQLSQLModes),
+		MySQLRequirePrimaryKey: databaseReq.MySQLRequirePrimaryKey,
+		MySQLSlowQueryLog:      databaseReq.MySQLSlowQueryLog,
+		MySQLLongQueryTime:     databaseReq.MySQLLongQueryTime,
+		RedisEvictionPolicy:    databaseReq.RedisEvictionPolicy,
+	}
+	if database.VPCID != "" {
+		database.PublicHost = "public-" + database.Host
+	}
+	f.databases.add(id, database)
+
+	f.databaseUsers[id] = newCollection[govultr.DatabaseUser]()
+	f.databaseUsers[id].add(database.User, &govultr.DatabaseUser{Username: database.User, Password: database.Password})
+	f.databaseDBs[id] = newCollection[govultr.DatabaseDB]()
+	f.databaseDBs[id].add(database.DBName, &govultr.DatabaseDB{Name: database.DBName})
+	f.record(databaseService, "Create", id)
+
+	return clone(database), response(http.StatusAccepted), nil
+}
+
+// Get returns a Managed Database
+func (s *DatabaseService) Get(_ context.Context, databaseID string) (*govultr.Database, *http.Response, error) {
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	database, ok := f.databases.get(databaseID)
+	if !ok {
+		return nil, nil, notFound("database", databaseID)
+	}
+
+	return clone(database), response(http.StatusOK), nil
+}
+
+// WaitForStatus returns a Managed Database if its status already is status
+func (s *DatabaseService) WaitForStatus(ctx context.Context, databaseID, status string, _ *govultr.WaitOptions) (*govultr.Database, *http.Response, error) { //nolint:lll
+	database, resp, err := s.Get(ctx, databaseID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err = reached("database", databaseID, status, database.Status); err != nil {
+		return nil, nil, err
+	}
+
+	return database, resp, nil
+}
+
+// Update changes the settings of a Managed Database set in databaseReq. An
+// empty VPCID detaches it from its VPC.
+func (s *DatabaseService) Update(_ context.Context, databaseID string, databaseReq *govultr.DatabaseUpdateReq) (*govultr.Database, *http.Response, error) { //nolint:lll
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	database, ok := f.databases.get(databaseID)
+	if !ok {
+		return nil, nil, notFound("database", databaseID)
+	}
+
+	if databaseReq != nil {
+		if databaseReq.Plan != "" {
+			database.Plan = databaseReq.Plan
+		}
+		if databaseReq.Label != "" {
+			database.Label = databaseReq.Label
+		}
+		if databaseReq.Tag != "" {
+			database.Tag = databaseReq.Tag
+		}
+		if databaseReq.VPCID != nil {
+			database.VPCID, database.PublicHost = *databaseReq.VPCID, ""
+			if database.VPCID != "" {
+				database.PublicHost = "public-" + database.Host
+			}
+		}
+		if databaseReq.MaintenanceDOW != "" {
+			database.MaintenanceDOW = databaseReq.MaintenanceDOW
+		}
+		if databaseReq.MaintenanceTime != "" {
+			database.MaintenanceTime = databaseReq.MaintenanceTime
+		}
+		if databaseReq.ClusterTimeZone != "" {
+			database.ClusterTimeZone = databaseReq.ClusterTimeZone
+		}
+		if databaseReq.TrustedIPs != nil {
+			database.TrustedIPs = slices.Clone(databaseReq.TrustedIPs)
+		}
+		if databaseReq.RedisEvictionPolicy != "" {
+			database.RedisEvictionPolicy = databaseReq.RedisEvictionPolicy
+		}
+	}
+	f.record(databaseService, "Update", databaseID)
+
+	return clone(database), response(http.StatusAccepted), nil
+}
+
+// Delete removes a Managed Database with its users and logical databases
+func (s *DatabaseService) Delete(_ context.Context, databaseID string) error {
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if !f.databases.remove(databaseID) {
+		return notFound("database", databaseID)
+	}
+	delete(f.databaseUsers, databaseID)
+	delete(f.databaseDBs, databaseID)
+	f.record(databaseService, "Delete", databaseID)
+
+	return nil
+}
+
+// List returns Managed Databases, filtered by the label, tag and region of
+// options like the API does. Every database is on the first page.
+func (s *DatabaseService) List(_ context.Context, options *govultr.DBListOptions) ([]govultr.Database, *govultr.Meta, *http.Response, error) { //nolint:lll
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	databases, meta := f.databases.list(nil, func(database *govultr.Database) bool {
+		return options == nil ||
+			(options.Label == "" || database.Label == options.Label) &&
+				(options.Tag == "" || database.Tag == options.Tag) &&
+				(options.Region == "" || database.Region == options.Region)
+	})
+
+	return databases, meta, response(http.StatusOK), nil
+}
+
+// Resize changes the plan of a Managed Database, which is running on the new
+// plan immediately
+func (s *DatabaseService) Resize(ctx context.Context, databaseID, plan string, _ *govultr.WaitOptions) (*govultr.Database, *http.Response, error) { //nolint:lll
+	if plan == "" {
+		return nil, nil, apiError(http.StatusBadRequest, "plan is required")
+	}
+
+	return s.Update(ctx, databaseID, &govultr.DatabaseUpdateReq{Plan: plan})
+}
+
+// AttachVPC places a Managed Database on a VPC
+func (s *DatabaseService) AttachVPC(ctx context.Context, databaseID, vpcID string) (*govultr.Database, *http.Response, error) {
+	return s.Update(ctx, databaseID, &govultr.DatabaseUpdateReq{VPCID: &vpcID})
+}
+
+// DetachVPC removes a Managed Database from its VPC
+func (s *DatabaseService) DetachVPC(ctx context.Context, databaseID string) (*govultr.Database, *http.Response, error) {
+	vpcID := ""
+	return s.Update(ctx, databaseID, &govultr.DatabaseUpdateReq{VPCID: &vpcID})
+}
+
+// ListUsers returns the users of a Managed Database
+func (s *DatabaseService) ListUsers(_ context.Context, databaseID string) ([]govultr.DatabaseUser, *govultr.Meta, *http.Response, error) { //nolint:lll
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	users, ok := f.databaseUsers[databaseID]
+	if !ok {
+		return nil, nil, nil, notFound("database", databaseID)
+	}
+
+	list, meta := users.list(nil, nil)
+
+	return list, meta, response(http.StatusOK), nil
+}
+
+// CreateUser adds a user to a Managed Database, generating a password unless
+// databaseUserReq has one
+func (s *DatabaseService) CreateUser(_ context.Context, databaseID string, databaseUserReq *govultr.DatabaseUserCreateReq) (*govultr.DatabaseUser, *http.Response, error) { //nolint:lll
+	if databaseUserReq == nil || databaseUserReq.Username == "" {
+		return nil, nil, apiError(http.StatusBadRequest, "username is required")
+	}
+
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	users, ok := f.databaseUsers[databaseID]
+	if !ok {
+		return nil, nil, notFound("database", databaseID)
+	}
+
+	if _, ok = users.get(databaseUserReq.Username); ok {
+		return nil, nil, apiError(http.StatusBadRequest, "user %s already exists", databaseUserReq.Username)
+	}
+
+	user := &govultr.DatabaseUser{
+		Username:   databaseUserReq.Username,
+		Password:   databaseUserReq.Password,
+		Encryption: databaseUserReq.Encryption,
+	}
+	if user.Password == "" {
+		user.Password = fmt.Sprintf("fake-%s-%s", databaseID, user.Username)
+	}
+	users.add(user.Username, user)
+	f.record(databaseService, "CreateUser", databaseID)
+
+	return clone(user), response(http.StatusAccepted), nil
+}
+
+// GetUser returns a user of a Managed Database
+func (s *DatabaseService) GetUser(_ context.Context, databaseID, username string) (*govultr.DatabaseUser, *http.Response, error) { //nolint:lll
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	user, err := f.databaseUser(databaseID, username)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return clone(user), response(http.StatusOK), nil
+}
+
+// UpdateUser changes the password of a user of a Managed Database
+func (s *DatabaseService) UpdateUser(_ context.Context, databaseID, username string, databaseUserReq *govultr.DatabaseUserUpdateReq) (*govultr.DatabaseUser, *http.Response, error) { //nolint:lll
+	if databaseUserReq == nil || databaseUserReq.Password == "" {
+		return nil, nil, apiError(http.StatusBadRequest, "password is required")
+	}
+
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	user, err := f.databaseUser(databaseID, username)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	user.Password = databaseUserReq.Password
+	f.record(databaseService, "UpdateUser", databaseID)
+
+	return clone(user), response(http.StatusAccepted), nil
+}
+
+// DeleteUser removes a user from a Managed Database
+func (s *DatabaseService) DeleteUser(_ context.Context, databaseID, username string) error {
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if _, err := f.databaseUser(databaseID, username); err != nil {
+		return err
+	}
+
+	f.databaseUsers[databaseID].remove(username)
+	f.record(databaseService, "DeleteUser", databaseID)
+
+	return nil
+}
+
+// ListDBs returns the logical databases of a Managed Database
+func (s *DatabaseService) ListDBs(_ context.Context, databaseID string) ([]govultr.DatabaseDB, *govultr.Meta, *http.Response, error) { //nolint:lll
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	dbs, ok := f.databaseDBs[databaseID]
+	if !ok {
+		return nil, nil, nil, notFound("database", databaseID)
+	}
+
+	list, meta := dbs.list(nil, nil)
+
+	return list, meta, response(http.StatusOK), nil
+}
+
+// CreateDB adds a logical database to a Managed Database
+func (s *DatabaseService) CreateDB(_ context.Context, databaseID string, databaseDBReq *govultr.DatabaseDBCreateReq) (*govultr.DatabaseDB, *http.Response, error) { //nolint:lll
+	if databaseDBReq == nil || databaseDBReq.Name == "" {
+		return nil, nil, apiError(http.StatusBadRequest, "name is required")
+	}
+
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	db, err := f.addDatabaseDB(databaseID, databaseDBReq.Name)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return clone(db), response(http.StatusAccepted), nil
+}
+
+// GetDB returns a logical database of a Managed Database
+func (s *DatabaseService) GetDB(_ context.Context, databaseID, dbname string) (*govultr.DatabaseDB, *http.Response, error) {
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	dbs, ok := f.databaseDBs[databaseID]
+	if !ok {
+		return nil, nil, notFound("database", databaseID)
+	}
+
+	db, ok := dbs.get(dbname)
+	if !ok {
+		return nil, nil, notFound("logical database", dbname)
+	}
+
+	return clone(db), response(http.StatusOK), nil
+}
+
+// DeleteDB removes a logical database from a Managed Database
+func (s *DatabaseService) DeleteDB(_ context.Context, databaseID, dbname string) error {
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	dbs, ok := f.databaseDBs[databaseID]
+	if !ok {
+		return notFound("database", databaseID)
+	}
+
+	if !dbs.remove(dbname) {
+		return notFound("logical database", dbname)
+	}
+	f.record(databaseService, "DeleteDB", databaseID)
+
+	return nil
+}
+
+// DBExists reports whether a Managed Database has a logical database
+func (s *DatabaseService) DBExists(_ context.Context, databaseID, dbname string) (bool, error) {
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	dbs, ok := f.databaseDBs[databaseID]
+	if !ok {
+		return false, notFound("database", databaseID)
+	}
+
+	_, ok = dbs.get(dbname)
+
+	return ok, nil
+}
+
+// EnsureDB adds a logical database to a Managed Database unless it exists,
+// reporting whether it was created
+func (s *DatabaseService) EnsureDB(_ context.Context, databaseID, dbname string) (*govultr.DatabaseDB, bool, error) {
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	dbs, ok := f.databaseDBs[databaseID]
+	if !ok {
+		return nil, false, notFound("database", databaseID)
+	}
+
+	if db, ok := dbs.get(dbname); ok {
+		return clone(db), false, nil
+	}
+
+	db, err := f.addDatabaseDB(databaseID, dbname)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return clone(db), true, nil
+}
+
+// databaseUser returns a user of a Managed Database. Callers must hold f.mu.
+func (f *Fake) databaseUser(databaseID, username string) (*govultr.DatabaseUser, error) {
+	users, ok := f.databaseUsers[databaseID]
+	if !ok {
+		return nil, notFound("database", databaseID)
+	}
+
+	user, ok := users.get(username)
+	if !ok {
+		return nil, notFound("database user", username)
+	}
+
+	return user, nil
+}
+
+// addDatabaseDB stores a new logical database. Callers must hold f.mu.
+func (f *Fake) addDatabaseDB(databaseID, dbname string) (*govultr.DatabaseDB, error) {
+	dbs, ok := f.databaseDBs[databaseID]
+	if !ok {
+		return nil, notFound("database", databaseID)
+	}
+
+	if _, ok = dbs.get(dbname); ok {
+		return nil, apiError(http.StatusBadRequest, "logical database %s already exists", dbname)
+	}
+
+	db := &govultr.DatabaseDB{Name: dbname}
+	dbs.add(dbname, db)
+	f.record(databaseService, "CreateDB", databaseID)
+
+	return db, nil
+}
@@ -0,0 +1,300 @@
+package fake
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/vultr/govultr/v3"
+)
+
+const (
+	domainService       = "Domain"
+	domainRecordService = "DomainRecord"
+)
+
+// DomainService is an in-memory govultr.DomainService. Create, Get, Update,
+// Delete, List, GetSoa and UpdateSoa are kept in memory, other methods fall
+// through to the embedded service and fail.
+type DomainService struct {
+	govultr.DomainService
+	fake *Fake
+}
+
+// Create adds a domain. With an IP the zone gets an A record for the apex and
+// a CNAME for www like the API adds.
+func (s *DomainService) Create(_ context.Context, domainReq *govultr.DomainReq) (*govultr.Domain, *http.Response, error) {
+	if domainReq == nil || domainReq.Domain == "" {
+		return nil, nil, apiError(http.StatusBadRequest, "domain is required")
+	}
+
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	name := strings.ToLower(domainReq.Domain)
+	if _, ok := f.domains.get(name); ok {
+		return nil, nil, apiError(http.StatusBadRequest, "domain %s already exists", name)
+	}
+
+	dnsSec := domainReq.DNSSec
+	if dnsSec == "" {
+		dnsSec = "disabled"
+	}
+	domain := &govultr.Domain{Domain: name, DateCreated: f.now(), DNSSec: dnsSec}
+	f.domains.add(name, domain)
+	f.soas[name] = &govultr.Soa{NSPrimary: "ns1.vultr.com", Email: "dnsadm@vultr.com"}
+	f.domainRecords[name] = newCollection[govultr.DomainRecord]()
+
+	if domainReq.IP != "" {
+		f.addRecord(name, &govultr.DomainRecordReq{Type: "A", Data: domainReq.IP})
+		f.addRecord(name, &govultr.DomainRecordReq{Name: "www", Type: "CNAME", Data: name})
+	}
+	f.record(domainService, "Create", name)
+
+	return clone(domain), response(http.StatusCreated), nil
+}
+
+// Get returns a domain
+func (s *DomainService) Get(_ context.Context, domain string) (*govultr.Domain, *http.Response, error) {
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	found, ok := f.domains.get(strings.ToLower(domain))
+	if !ok {
+		return nil, nil, notFound("domain", domain)
+	}
+
+	return clone(found), response(http.StatusOK), nil
+}
+
+// Update enables or disables DNSSEC on a domain
+func (s *DomainService) Update(_ context.Context, domain, dnsSec string) error {
+	if dnsSec != "enabled" && dnsSec != "disabled" {
+		return apiError(http.StatusBadRequest, "dns_sec must be enabled or disabled")
+	}
+
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	found, ok := f.domains.get(strings.ToLower(domain))
+	if !ok {
+		return notFound("domain", domain)
+	}
+
+	found.DNSSec = dnsSec
+	f.record(domainService, "Update", found.Domain)
+
+	return nil
+}
+
+// Delete removes a domain and its records
+func (s *DomainService) Delete(_ context.Context, domain string) error {
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	name := strings.ToLower(domain)
+	if !f.domains.remove(name) {
+		return notFound("domain", domain)
+	}
+	delete(f.soas, name)
+	delete(f.domainRecords, name)
+	f.record(domainService, "Delete", name)
+
+	return nil
+}
+
+// List returns domains
+func (s *DomainService) List(_ context.Context, options *govultr.ListOptions) ([]govultr.Domain, *govultr.Meta, *http.Response, error) { //nolint:lll
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	domains, meta := f.domains.list(options, nil)
+
+	return domains, meta, response(http.StatusOK), nil
+}
+
+// GetSoa returns the SOA information of a domain
+func (s *DomainService) GetSoa(_ context.Context, domain string) (*govultr.Soa, *http.Response, error) {
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	soa, ok := f.soas[strings.ToLower(domain)]
+	if !ok {
+		return nil, nil, notFound("domain", domain)
+	}
+
+	return clone(soa), response(http.StatusOK), nil
+}
+
+// UpdateSoa changes the SOA information of a domain, leaving empty fields alone
+func (s *DomainService) UpdateSoa(_ context.Context, domain string, soaReq *govultr.Soa) error {
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	name := strings.ToLower(domain)
+	soa, ok := f.soas[name]
+	if !ok {
+		return notFound("domain", domain)
+	}
+
+	if soaReq != nil {
+		if soaReq.NSPrimary != "" {
+			soa.NSPrimary = soaReq.NSPrimary
+		}
+		if soaReq.Email != "" {
+			soa.Email = soaReq.Email
+		}
+	}
+	f.record(domainService, "UpdateSoa", name)
+
+	return nil
+}
+
+// DomainRecordService is an in-memory govultr.DomainRecordService. Create, Get,
+// Update, Delete and List are kept in memory, other methods fall through to the
+// embedded service and fail.
+type DomainRecordService struct {
+	govultr.DomainRecordService
+	fake *Fake
+}
+
+// Create adds a record to a domain
+func (s *DomainRecordService) Create(_ context.Context, domain string, domainRecordReq *govultr.DomainRecordReq) (*govultr.DomainRecord, *http.Response, error) { //nolint:lll
+	if domainRecordReq == nil || domainRecordReq.Type == "" || domainRecordReq.Data == "" {
+		return nil, nil, apiError(http.StatusBadRequest, "type and data are required")
+	}
+
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	name := strings.ToLower(domain)
+	if _, ok := f.domainRecords[name]; !ok {
+		return nil, nil, notFound("domain", domain)
+	}
+
+	record := f.addRecord(name, domainRecordReq)
+	f.record(domainRecordService, "Create", record.ID)
+
+	return clone(record), response(http.StatusCreated), nil
+}
+
+// Get returns a record of a domain
+func (s *DomainRecordService) Get(_ context.Context, domain, recordID string) (*govultr.DomainRecord, *http.Response, error) { //nolint:lll
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	records, ok := f.domainRecords[strings.ToLower(domain)]
+	if !ok {
+		return nil, nil, notFound("domain", domain)
+	}
+
+	record, ok := records.get(recordID)
+	if !ok {
+		return nil, nil, notFound("record", recordID)
+	}
+
+	return clone(record), response(http.StatusOK), nil
+}
+
+// Update changes the name, data, TTL and priority of a record. The type of a
+// record can not be changed.
+func (s *DomainRecordService) Update(_ context.Context, domain, recordID string, domainRecordReq *govultr.DomainRecordReq) error {
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	records, ok := f.domainRecords[strings.ToLower(domain)]
+	if !ok {
+		return notFound("domain", domain)
+	}
+
+	record, ok := records.get(recordID)
+	if !ok {
+		return notFound("record", recordID)
+	}
+
+	if domainRecordReq != nil {
+		if domainRecordReq.Type != "" && !strings.EqualFold(domainRecordReq.Type, record.Type) {
+			return apiError(http.StatusBadRequest, "the type of record %s can not be changed", recordID)
+		}
+
+		record.Name = domainRecordReq.Name
+		if domainRecordReq.Data != "" {
+			record.Data = domainRecordReq.Data
+		}
+		if domainRecordReq.TTL != 0 {
+			record.TTL = domainRecordReq.TTL
+		}
+		if domainRecordReq.Priority != nil {
+			record.Priority = *domainRecordReq.Priority
+		}
+	}
+	f.record(domainRecordService, "Update", recordID)
+
+	return nil
+}
+
+// Delete removes a record from a domain
+func (s *DomainRecordService) Delete(_ context.Context, domain, recordID string) error {
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	records, ok := f.domainRecords[strings.ToLower(domain)]
+	if !ok {
+		return notFound("domain", domain)
+	}
+
+	if !records.remove(recordID) {
+		return notFound("record", recordID)
+	}
+	f.record(domainRecordService, "Delete", recordID)
+
+	return nil
+}
+
+// List returns the records of a domain
+func (s *DomainRecordService) List(_ context.Context, domain string, options *govultr.ListOptions) ([]govultr.DomainRecord, *govultr.Meta, *http.Response, error) { //nolint:lll
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	records, ok := f.domainRecords[strings.ToLower(domain)]
+	if !ok {
+		return nil, nil, nil, notFound("domain", domain)
+	}
+
+	list, meta := records.list(options, nil)
+
+	return list, meta, response(http.StatusOK), nil
+}
+
+// addRecord stores a new record on an existing domain, with a TTL of 300 and a
+// priority of 0 unless the request sets them. Callers must hold f.mu.
+func (f *Fake) addRecord(domain string, domainRecordReq *govultr.DomainRecordReq) *govultr.DomainRecord {
+	record := &govultr.DomainRecord{
+		ID:   f.newID("record"),
+		Type: strings.ToUpper(domainRecordReq.Type),
+		Name: domainRecordReq.Name,
+		Data: domainRecordReq.Data,
+		TTL:  domainRecordReq.TTL,
+	}
+	if record.TTL == 0 {
+		record.TTL = 300
+	}
+	if domainRecordReq.Priority != nil {
+		record.Priority = *domainRecordReq.Priority
+	}
+	f.domainRecords[domain].add(record.ID, record)
+
+	return record
+}
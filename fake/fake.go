@@ -0,0 +1,348 @@
+// Package fake provides in-memory implementations of the govultr services for
+// unit tests that should not make HTTP requests. Resources get deterministic
+// IDs and every mutation is recorded so tests can assert on what was changed.
+//
+// Every service has an in-memory implementation. Resources created through
+// the client are kept by the Fake, and the read-only catalogs, such as plans,
+// regions, invoices and the account, are filled in with the Add and Set
+// methods of Fake. Methods not listed on the type of their service, mostly
+// helpers that combine several requests, return an error naming the request
+// they would have sent, and no request leaves the process.
+package fake
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/vultr/govultr/v3"
+)
+
+// Epoch returns the creation time given to resources unless Fake.Now is set
+func Epoch() time.Time {
+	return time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+}
+
+// Mutation represents a change made through a fake service
+type Mutation struct {
+	Service string
+	Method  string
+	ID      string
+}
+
+// Fake holds the in-memory state shared by the fake services of a client
+type Fake struct {
+	// Now returns the creation time of new resources, Epoch when nil
+	Now func() time.Time
+
+	mu        sync.Mutex
+	counters  map[string]int
+	mutations []Mutation
+
+	instances  *collection[govultr.Instance]
+	clusters   *collection[govultr.Cluster]
+	registries *collection[govultr.ContainerRegistry]
+	sshKeys    *collection[govultr.SSHKey]
+
+	blocks         *collection[govultr.BlockStorage]
+	reservedIPs    *collection[govultr.ReservedIP]
+	snapshots      *collection[govultr.Snapshot]
+	vpcs           *collection[govultr.VPC]
+	vpc2s          *collection[govultr.VPC2]
+	vpc2Nodes      map[string][]string
+	firewallGroups *collection[govultr.FirewallGroup]
+	firewallRules  map[string]*collection[govultr.FirewallRule]
+
+	domains       *collection[govultr.Domain]
+	soas          map[string]*govultr.Soa
+	domainRecords map[string]*collection[govultr.DomainRecord]
+	scripts       *collection[govultr.StartupScript]
+	users         *collection[govultr.User]
+	isos          *collection[govultr.ISO]
+
+	bareMetals            *collection[govultr.BareMetalServer]
+	loadBalancers         *collection[govultr.LoadBalancer]
+	databases             *collection[govultr.Database]
+	databaseUsers         map[string]*collection[govultr.DatabaseUser]
+	databaseDBs           map[string]*collection[govultr.DatabaseDB]
+	objectStorages        *collection[govultr.ObjectStorage]
+	objectStorageClusters *collection[govultr.ObjectStorageCluster]
+	pullZones             *collection[govultr.CDNZone]
+	pushZones             *collection[govultr.CDNZone]
+	inferences            *collection[govultr.Inference]
+
+	account          *govultr.Account
+	history          *collection[govultr.History]
+	invoices         *collection[govultr.Invoice]
+	invoiceItems     map[string][]govultr.InvoiceItem
+	applications     *collection[govultr.Application]
+	backups          *collection[govultr.Backup]
+	appVariables     map[string][]govultr.MarketplaceAppVariable
+	operatingSystems *collection[govultr.OS]
+	plans            *collection[govultr.Plan]
+	bareMetalPlans   *collection[govultr.BareMetalPlan]
+	regions          *collection[govultr.Region]
+}
+
+// New returns an empty Fake
+func New() *Fake {
+	return &Fake{
+		counters:   make(map[string]int),
+		instances:  newCollection[govultr.Instance](),
+		clusters:   newCollection[govultr.Cluster](),
+		registries: newCollection[govultr.ContainerRegistry](),
+		sshKeys:    newCollection[govultr.SSHKey](),
+
+		blocks:         newCollection[govultr.BlockStorage](),
+		reservedIPs:    newCollection[govultr.ReservedIP](),
+		snapshots:      newCollection[govultr.Snapshot](),
+		vpcs:           newCollection[govultr.VPC](),
+		vpc2s:          newCollection[govultr.VPC2](),
+		vpc2Nodes:      make(map[string][]string),
+		firewallGroups: newCollection[govultr.FirewallGroup](),
+		firewallRules:  make(map[string]*collection[govultr.FirewallRule]),
+
+		domains:       newCollection[govultr.Domain](),
+		soas:          make(map[string]*govultr.Soa),
+		domainRecords: make(map[string]*collection[govultr.DomainRecord]),
+		scripts:       newCollection[govultr.StartupScript](),
+		users:         newCollection[govultr.User](),
+		isos:          newCollection[govultr.ISO](),
+
+		bareMetals:            newCollection[govultr.BareMetalServer](),
+		loadBalancers:         newCollection[govultr.LoadBalancer](),
+		databases:             newCollection[govultr.Database](),
+		databaseUsers:         make(map[string]*collection[govultr.DatabaseUser]),
+		databaseDBs:           make(map[string]*collection[govultr.DatabaseDB]),
+		objectStorages:        newCollection[govultr.ObjectStorage](),
+		objectStorageClusters: newCollection[govultr.ObjectStorageCluster](),
+		pullZones:             newCollection[govultr.CDNZone](),
+		pushZones:             newCollection[govultr.CDNZone](),
+		inferences:            newCollection[govultr.Inference](),
+
+		account:          &govultr.Account{},
+		history:          newCollection[govultr.History](),
+		invoices:         newCollection[govultr.Invoice](),
+		invoiceItems:     make(map[string][]govultr.InvoiceItem),
+		applications:     newCollection[govultr.Application](),
+		backups:          newCollection[govultr.Backup](),
+		appVariables:     make(map[string][]govultr.MarketplaceAppVariable),
+		operatingSystems: newCollection[govultr.OS](),
+		plans:            newCollection[govultr.Plan](),
+		bareMetalPlans:   newCollection[govultr.BareMetalPlan](),
+		regions:          newCollection[govultr.Region](),
+	}
+}
+
+// Client returns a govultr client whose services are backed by f. Methods
+// without an in-memory implementation return an error instead of sending
+// requests.
+func (f *Fake) Client() *govultr.Client {
+	client := govultr.NewClient(&http.Client{Transport: notImplemented{}})
+	client.SetRetryLimit(0)
+
+	client.Instance = &InstanceService{InstanceService: client.Instance, fake: f}
+	client.Kubernetes = &KubernetesService{KubernetesService: client.Kubernetes, fake: f}
+	client.ContainerRegistry = &ContainerRegistryService{ContainerRegistryService: client.ContainerRegistry, fake: f}
+	client.SSHKey = &SSHKeyService{SSHKeyService: client.SSHKey, fake: f}
+	client.BlockStorage = &BlockStorageService{BlockStorageService: client.BlockStorage, fake: f}
+	client.ReservedIP = &ReservedIPService{ReservedIPService: client.ReservedIP, fake: f}
+	client.Snapshot = &SnapshotService{SnapshotService: client.Snapshot, fake: f}
+	client.VPC = &VPCService{VPCService: client.VPC, fake: f}
+	client.VPC2 = &VPC2Service{VPC2Service: client.VPC2, fake: f}
+	client.Network = &NetworkService{NetworkService: client.Network, fake: f}
+	client.FirewallGroup = &FirewallGroupService{FirewallGroupService: client.FirewallGroup, fake: f}
+	client.FirewallRule = &FirewallRuleService{FireWallRuleService: client.FirewallRule, fake: f}
+	client.Domain = &DomainService{DomainService: client.Domain, fake: f}
+	client.DomainRecord = &DomainRecordService{DomainRecordService: client.DomainRecord, fake: f}
+	client.StartupScript = &StartupScriptService{StartupScriptService: client.StartupScript, fake: f}
+	client.User = &UserService{UserService: client.User, fake: f}
+	client.ISO = &ISOService{ISOService: client.ISO, fake: f}
+	client.BareMetalServer = &BareMetalServerService{BareMetalServerService: client.BareMetalServer, fake: f}
+	client.LoadBalancer = &LoadBalancerService{LoadBalancerService: client.LoadBalancer, fake: f}
+	client.Database = &DatabaseService{DatabaseService: client.Database, fake: f}
+	client.ObjectStorage = &ObjectStorageService{ObjectStorageService: client.ObjectStorage, fake: f}
+	client.CDN = &CDNService{CDNService: client.CDN, fake: f}
+	client.Inference = &InferenceService{InferenceService: client.Inference, fake: f}
+	client.Account = &AccountService{AccountService: client.Account, fake: f}
+	client.Billing = &BillingService{BillingService: client.Billing, fake: f}
+	client.Application = &ApplicationService{ApplicationService: client.Application, fake: f}
+	client.Backup = &BackupService{BackupService: client.Backup, fake: f}
+	client.Marketplace = &MarketplaceService{MarketplaceService: client.Marketplace, fake: f}
+	client.OS = &OSService{OSService: client.OS, fake: f}
+	client.Plan = &PlanService{PlanService: client.Plan, fake: f}
+	client.Region = &RegionService{RegionService: client.Region, fake: f}
+
+	return client
+}
+
+// Mutations returns the changes made through f in the order they were made
+func (f *Fake) Mutations() []Mutation {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return append([]Mutation(nil), f.mutations...)
+}
+
+// ResetMutations forgets the changes recorded so far, keeping all resources
+func (f *Fake) ResetMutations() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.mutations = nil
+}
+
+// newID returns the next ID for a kind of resource, such as "instance-1".
+// Callers must hold f.mu.
+func (f *Fake) newID(kind string) string {
+	f.counters[kind]++
+	return fmt.Sprintf("%s-%d", kind, f.counters[kind])
+}
+
+// record appends a mutation. Callers must hold f.mu.
+func (f *Fake) record(service, method, id string) {
+	f.mutations = append(f.mutations, Mutation{Service: service, Method: method, ID: id})
+}
+
+// now returns the creation time of new resources in the format of the API
+func (f *Fake) now() string {
+	if f.Now != nil {
+		return f.Now().UTC().Format(time.RFC3339)
+	}
+	return Epoch().Format(time.RFC3339)
+}
+
+// notImplemented fails every request sent by a fake client
+type notImplemented struct{}
+
+func (notImplemented) RoundTrip(r *http.Request) (*http.Response, error) {
+	return nil, fmt.Errorf("fake: %s %s is not implemented", r.Method, r.URL.Path)
+}
+
+// collection keeps resources of one type by ID in the order they were added
+type collection[T any] struct {
+	ids   []string
+	items map[string]*T
+}
+
+func newCollection[T any]() *collection[T] {
+	return &collection[T]{items: make(map[string]*T)}
+}
+
+func (c *collection[T]) add(id string, item *T) {
+	c.ids = append(c.ids, id)
+	c.items[id] = item
+}
+
+func (c *collection[T]) get(id string) (*T, bool) {
+	item, ok := c.items[id]
+	return item, ok
+}
+
+func (c *collection[T]) remove(id string) bool {
+	if _, ok := c.items[id]; !ok {
+		return false
+	}
+
+	delete(c.items, id)
+	for i := range c.ids {
+		if c.ids[i] == id {
+			c.ids = append(c.ids[:i], c.ids[i+1:]...)
+			break
+		}
+	}
+
+	return true
+}
+
+// list returns copies of the items matching keep, one page at a time like the
+// API. The cursor of the next page is the offset of its first item.
+func (c *collection[T]) list(options *govultr.ListOptions, keep func(*T) bool) ([]T, *govultr.Meta) {
+	var matched []T
+	for _, id := range c.ids {
+		if keep == nil || keep(c.items[id]) {
+			matched = append(matched, *clone(c.items[id]))
+		}
+	}
+
+	return paginate(matched, options)
+}
+
+func paginate[T any](items []T, options *govultr.ListOptions) ([]T, *govultr.Meta) {
+	meta := &govultr.Meta{Total: len(items), Links: &govultr.Links{}}
+	if options == nil || options.PerPage <= 0 {
+		return items, meta
+	}
+
+	start, _ := strconv.Atoi(options.Cursor)
+	if start < 0 || start > len(items) {
+		start = len(items)
+	}
+
+	end := start + options.PerPage
+	if end < len(items) {
+		meta.Links.Next = strconv.Itoa(end)
+	} else {
+		end = len(items)
+	}
+
+	if start > 0 {
+		meta.Links.Prev = strconv.Itoa(max(start-options.PerPage, 0))
+	}
+
+	return items[start:end], meta
+}
+
+// clone returns a deep copy so callers cannot change the stored resource
+func clone[T any](item *T) *T {
+	data, err := json.Marshal(item)
+	if err != nil {
+		panic(err)
+	}
+
+	copied := new(T)
+	if err := json.Unmarshal(data, copied); err != nil {
+		panic(err)
+	}
+
+	return copied
+}
+
+func response(status int) *http.Response {
+	return &http.Response{Status: http.StatusText(status), StatusCode: status, Header: http.Header{}}
+}
+
+// apiError returns an error shaped like the ones the API client returns
+func apiError(status int, format string, args ...interface{}) error {
+	message := fmt.Sprintf(format, args...)
+	body, _ := json.Marshal(map[string]interface{}{"error": message, "status": status})
+
+	return &govultr.ErrorResponse{
+		Response:   response(status),
+		StatusCode: status,
+		Code:       status,
+		Message:    message,
+		Body:       body,
+	}
+}
+
+func notFound(kind, id string) error {
+	return apiError(http.StatusNotFound, "%s %s not found", kind, id)
+}
+
+// reached returns an error unless one of the statuses of a resource is status.
+// Fake resources change status only when a fake method changes them, so a
+// wait for any other status would never end.
+func reached(kind, id, status string, statuses ...string) error {
+	for _, s := range statuses {
+		if strings.EqualFold(s, status) {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("fake: %s %s is %s and will never be %s", kind, id, strings.Join(statuses, "/"), status)
+}
@@ -0,0 +1,550 @@
+package fake
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/vultr/govultr/v3"
+)
+
+func TestFake_Instance(t *testing.T) {
+	f := New()
+	client := f.Client()
+	ctx := context.Background()
+
+	created, _, err := client.Instance.Create(ctx, &govultr.InstanceCreateReq{Region: "ewr", Plan: "vc2-1c-1gb", Label: "web", Tags: []string{"a"}})
+	if err != nil {
+		t.Fatalf("Instance.Create returned %+v", err)
+	}
+
+	if created.ID != "instance-1" || created.DateCreated != "2024-01-01T00:00:00Z" || created.PowerStatus != "running" {
+		t.Errorf("Instance.Create returned %+v", created)
+	}
+
+	created.Label = "changed"
+	if _, _, err = client.Instance.Update(ctx, "instance-1", &govultr.InstanceUpdateReq{Plan: "vc2-2c-4gb"}); err != nil {
+		t.Fatalf("Instance.Update returned %+v", err)
+	}
+
+	if err = client.Instance.Halt(ctx, "instance-1"); err != nil {
+		t.Fatalf("Instance.Halt returned %+v", err)
+	}
+
+	instance, _, err := client.Instance.Get(ctx, "instance-1")
+	if err != nil {
+		t.Fatalf("Instance.Get returned %+v", err)
+	}
+
+	if instance.Label != "web" || instance.Plan != "vc2-2c-4gb" || instance.PowerStatus != "stopped" {
+		t.Errorf("Instance.Get returned %+v", instance)
+	}
+
+	if _, _, err = client.Instance.Create(ctx, &govultr.InstanceCreateReq{Region: "ewr", Plan: "vc2-1c-1gb", Label: "db"}); err != nil {
+		t.Fatalf("Instance.Create returned %+v", err)
+	}
+
+	instances, meta, _, err := client.Instance.List(ctx, &govultr.ListOptions{Tag: "a"})
+	if err != nil {
+		t.Fatalf("Instance.List returned %+v", err)
+	}
+
+	if len(instances) != 1 || instances[0].ID != "instance-1" || meta.Total != 1 {
+		t.Errorf("Instance.List returned %+v, expected instance-1", instances)
+	}
+
+	if err = client.Instance.Delete(ctx, "instance-1"); err != nil {
+		t.Fatalf("Instance.Delete returned %+v", err)
+	}
+
+	if _, _, err = client.Instance.Get(ctx, "instance-1"); !govultr.IsNotFound(err) {
+		t.Errorf("Instance.Get returned %+v, expected a not found error", err)
+	}
+
+	expected := []Mutation{
+		{Service: "Instance", Method: "Create", ID: "instance-1"},
+		{Service: "Instance", Method: "Update", ID: "instance-1"},
+		{Service: "Instance", Method: "Halt", ID: "instance-1"},
+		{Service: "Instance", Method: "Create", ID: "instance-2"},
+		{Service: "Instance", Method: "Delete", ID: "instance-1"},
+	}
+	if mutations := f.Mutations(); !reflect.DeepEqual(mutations, expected) {
+		t.Errorf("Fake.Mutations returned %+v, expected %+v", mutations, expected)
+	}
+}
+
+func TestFake_Kubernetes(t *testing.T) {
+	f := New()
+	client := f.Client()
+	ctx := context.Background()
+
+	cluster, _, err := client.Kubernetes.CreateCluster(ctx, &govultr.ClusterReq{
+		Label:     "prod",
+		Region:    "ewr",
+		Version:   "v1.29.0+1",
+		NodePools: []govultr.NodePoolReq{{Label: "default", Plan: "vc2-2c-4gb", NodeQuantity: 2}},
+	})
+	if err != nil {
+		t.Fatalf("Kubernetes.CreateCluster returned %+v", err)
+	}
+
+	if cluster.ID != "vke-1" || len(cluster.NodePools) != 1 || len(cluster.NodePools[0].Nodes) != 2 {
+		t.Errorf("Kubernetes.CreateCluster returned %+v", cluster)
+	}
+
+	pool, _, err := client.Kubernetes.UpdateNodePool(ctx, "vke-1", "nodepool-1", &govultr.NodePoolReqUpdate{NodeQuantity: 3})
+	if err != nil {
+		t.Fatalf("Kubernetes.UpdateNodePool returned %+v", err)
+	}
+
+	nodes := []string{pool.Nodes[0].ID, pool.Nodes[1].ID, pool.Nodes[2].ID}
+	if pool.NodeQuantity != 3 || !reflect.DeepEqual(nodes, []string{"node-1", "node-2", "node-3"}) {
+		t.Errorf("Kubernetes.UpdateNodePool returned %+v", pool)
+	}
+
+	if _, _, err = client.Kubernetes.CreateNodePool(ctx, "vke-1", &govultr.NodePoolReq{Label: "batch", Plan: "vc2-1c-2gb", NodeQuantity: 1}); err != nil {
+		t.Fatalf("Kubernetes.CreateNodePool returned %+v", err)
+	}
+
+	if err = client.Kubernetes.DeleteNodePool(ctx, "vke-1", "nodepool-1"); err != nil {
+		t.Fatalf("Kubernetes.DeleteNodePool returned %+v", err)
+	}
+
+	pools, _, _, err := client.Kubernetes.ListNodePools(ctx, "vke-1", nil)
+	if err != nil {
+		t.Fatalf("Kubernetes.ListNodePools returned %+v", err)
+	}
+
+	if len(pools) != 1 || pools[0].ID != "nodepool-2" {
+		t.Errorf("Kubernetes.ListNodePools returned %+v, expected nodepool-2", pools)
+	}
+
+	if _, _, err = client.Kubernetes.GetNodePool(ctx, "vke-1", "nodepool-1"); !govultr.IsNotFound(err) {
+		t.Errorf("Kubernetes.GetNodePool returned %+v, expected a not found error", err)
+	}
+}
+
+func TestFake_ContainerRegistryAndSSHKey(t *testing.T) {
+	f := New()
+	client := f.Client()
+	ctx := context.Background()
+
+	registry, _, err := client.ContainerRegistry.Create(ctx, &govultr.ContainerRegistryReq{Name: "team", Region: "sjc", Plan: "start_up"})
+	if err != nil {
+		t.Fatalf("ContainerRegistry.Create returned %+v", err)
+	}
+
+	if registry.ID != "vcr-1" || registry.URN != "sjc.vultrcr.com/team" {
+		t.Errorf("ContainerRegistry.Create returned %+v", registry)
+	}
+
+	if _, _, err = client.ContainerRegistry.Create(ctx, &govultr.ContainerRegistryReq{Name: "team", Region: "ewr", Plan: "start_up"}); err == nil {
+		t.Error("ContainerRegistry.Create accepted a name in use")
+	}
+
+	if _, _, err = client.SSHKey.Create(ctx, &govultr.SSHKeyReq{Name: "laptop", SSHKey: "ssh-ed25519 AAAA"}); err != nil {
+		t.Fatalf("SSHKey.Create returned %+v", err)
+	}
+
+	for i := 0; i < 4; i++ {
+		if _, _, err = client.SSHKey.Create(ctx, &govultr.SSHKeyReq{Name: "ci", SSHKey: "ssh-ed25519 BBBB"}); err != nil {
+			t.Fatalf("SSHKey.Create returned %+v", err)
+		}
+	}
+
+	keys, meta, _, err := client.SSHKey.List(ctx, &govultr.ListOptions{PerPage: 2, Cursor: "2"})
+	if err != nil {
+		t.Fatalf("SSHKey.List returned %+v", err)
+	}
+
+	if len(keys) != 2 || keys[0].ID != "sshkey-3" || meta.Total != 5 || meta.Links.Next != "4" || meta.Links.Prev != "0" {
+		t.Errorf("SSHKey.List returned %+v with %+v", keys, meta)
+	}
+}
+
+func TestFake_NotImplemented(t *testing.T) {
+	client := New().Client()
+
+	_, _, err := client.Instance.GetBandwidth(context.Background(), "instance-1")
+	if err == nil || !strings.Contains(err.Error(), "fake: GET /v2/instances/instance-1/bandwidth is not implemented") {
+		t.Errorf("Instance.GetBandwidth returned %+v, expected a not implemented error", err)
+	}
+
+	if _, _, err = client.Database.GetUsage(context.Background(), "database-1"); err == nil {
+		t.Error("Database.GetUsage returned no error")
+	}
+}
+
+func TestFake_Waiters(t *testing.T) {
+	f := New()
+	client := f.Client()
+	ctx := context.Background()
+
+	if _, _, err := client.Instance.Create(ctx, &govultr.InstanceCreateReq{Region: "ewr", Plan: "vc2-1c-1gb"}); err != nil {
+		t.Fatalf("Instance.Create returned %+v", err)
+	}
+
+	if _, _, err := client.Instance.WaitForStatus(ctx, "instance-1", "Running", nil); err != nil {
+		t.Errorf("Instance.WaitForStatus returned %+v", err)
+	}
+
+	if _, _, err := client.Instance.WaitForStatus(ctx, "instance-1", "stopped", nil); err == nil {
+		t.Error("Instance.WaitForStatus expected an error for a status the instance will never reach")
+	}
+
+	instance, _, err := client.Instance.ReinstallAndWait(ctx, "instance-1", &govultr.ReinstallOptions{Hostname: "web"}, nil)
+	if err != nil || instance.Hostname != "web" {
+		t.Errorf("Instance.ReinstallAndWait returned %+v, %+v", instance, err)
+	}
+
+	instance, _, err = client.Instance.EnableIPv6(ctx, "instance-1", nil)
+	if err != nil || !instance.HasIPv6() {
+		t.Errorf("Instance.EnableIPv6 returned %+v, %+v", instance, err)
+	}
+
+	registry, _, err := client.ContainerRegistry.CreateAndWait(ctx, &govultr.ContainerRegistryReq{Name: "images", Region: "ewr", Plan: "start_up"}, nil)
+	if err != nil || registry.ID != "vcr-1" {
+		t.Errorf("ContainerRegistry.CreateAndWait returned %+v, %+v", registry, err)
+	}
+
+	cluster, _, err := client.Kubernetes.CreateCluster(ctx, &govultr.ClusterReq{
+		Region:    "ewr",
+		Version:   "v1.29.2+1",
+		NodePools: []govultr.NodePoolReq{{Plan: "vc2-2c-4gb", NodeQuantity: 1}},
+	})
+	if err != nil {
+		t.Fatalf("Kubernetes.CreateCluster returned %+v", err)
+	}
+
+	if _, _, err = client.Kubernetes.WaitForClusterStatus(ctx, cluster.ID, "active", nil); err != nil {
+		t.Errorf("Kubernetes.WaitForClusterStatus returned %+v", err)
+	}
+
+	expected := []Mutation{
+		{Service: instanceService, Method: "Create", ID: "instance-1"},
+		{Service: instanceService, Method: "Reinstall", ID: "instance-1"},
+		{Service: instanceService, Method: "EnableIPv6", ID: "instance-1"},
+		{Service: containerRegistryService, Method: "Create", ID: "vcr-1"},
+	}
+	if mutations := f.Mutations(); !reflect.DeepEqual(mutations[:len(expected)], expected) {
+		t.Errorf("Fake.Mutations returned %+v, expected %+v first", mutations, expected)
+	}
+}
+
+func TestFake_StorageAndNetworking(t *testing.T) {
+	f := New()
+	client := f.Client()
+	ctx := context.Background()
+
+	instance, _, err := client.Instance.Create(ctx, &govultr.InstanceCreateReq{Region: "ewr", Plan: "vc2-1c-1gb", OsID: 1743})
+	if err != nil {
+		t.Fatalf("Instance.Create returned %+v", err)
+	}
+
+	block, _, err := client.BlockStorage.Create(ctx, &govultr.BlockStorageCreate{Region: "ewr", SizeGB: 10, Label: "data"})
+	if err != nil {
+		t.Fatalf("BlockStorage.Create returned %+v", err)
+	}
+
+	if err = client.BlockStorage.Attach(ctx, block.ID, &govultr.BlockStorageAttach{InstanceID: instance.ID}); err != nil {
+		t.Fatalf("BlockStorage.Attach returned %+v", err)
+	}
+
+	if _, _, err = client.BlockStorage.WaitForAttachment(ctx, block.ID, instance.ID, nil); err != nil {
+		t.Errorf("BlockStorage.WaitForAttachment returned %+v", err)
+	}
+
+	if err = client.BlockStorage.Update(ctx, block.ID, &govultr.BlockStorageUpdate{SizeGB: 5}); err == nil {
+		t.Error("BlockStorage.Update accepted a smaller size")
+	}
+
+	rip, _, err := client.ReservedIP.Create(ctx, &govultr.ReservedIPReq{Region: "ewr", IPType: "v4", InstanceID: instance.ID})
+	if err != nil {
+		t.Fatalf("ReservedIP.Create returned %+v", err)
+	}
+
+	var attached *govultr.ReservedIPAttachedError
+	if err = client.ReservedIP.DeleteGuarded(ctx, rip.ID, nil); !errors.As(err, &attached) || attached.InstanceID != instance.ID {
+		t.Errorf("ReservedIP.DeleteGuarded returned %+v, expected a ReservedIPAttachedError", err)
+	}
+
+	converted, _, err := client.ReservedIP.Convert(ctx, &govultr.ReservedIPConvertReq{IPAddress: instance.MainIP})
+	if err != nil || converted.InstanceID != instance.ID || converted.Subnet != instance.MainIP {
+		t.Errorf("ReservedIP.Convert returned %+v, %+v", converted, err)
+	}
+
+	snapshot, _, err := client.Snapshot.CreateWithMetadata(ctx, instance.ID, &govultr.SnapshotMetadata{ContentHash: "abc"})
+	if err != nil || snapshot.OsID != 1743 {
+		t.Fatalf("Snapshot.CreateWithMetadata returned %+v, %+v", snapshot, err)
+	}
+
+	again, _, err := client.Snapshot.CreateWithMetadata(ctx, instance.ID, &govultr.SnapshotMetadata{ContentHash: "abc"})
+	if err != nil || again.ID != snapshot.ID {
+		t.Errorf("Snapshot.CreateWithMetadata returned %+v, expected %s", again, snapshot.ID)
+	}
+
+	vpc, _, err := client.VPC.CreateAndWait(ctx, &govultr.VPCReq{Region: "ewr", Description: "private"}, nil)
+	if err != nil || vpc.V4Subnet != "10.0.1.0" || vpc.V4SubnetMask != 24 {
+		t.Fatalf("VPC.CreateAndWait returned %+v, %+v", vpc, err)
+	}
+
+	networks, _, _, err := client.Network.List(ctx, nil)
+	if err != nil || len(networks) != 1 || networks[0].NetworkID != vpc.ID {
+		t.Errorf("Network.List returned %+v, %+v", networks, err)
+	}
+
+	vpc2, _, err := client.VPC2.Create(ctx, &govultr.VPC2Req{Region: "ewr", IPBlock: "10.99.0.0", PrefixLength: 24})
+	if err != nil {
+		t.Fatalf("VPC2.Create returned %+v", err)
+	}
+
+	if err = client.VPC2.Attach(ctx, vpc2.ID, &govultr.VPC2AttachDetachReq{Nodes: []string{instance.ID}}); err != nil {
+		t.Fatalf("VPC2.Attach returned %+v", err)
+	}
+
+	nodes, _, _, err := client.VPC2.ListNodes(ctx, vpc2.ID, nil)
+	if err != nil || len(nodes) != 1 || nodes[0].IPAddress != "10.99.0.1" {
+		t.Errorf("VPC2.ListNodes returned %+v, %+v", nodes, err)
+	}
+
+	if err = client.VPC2.Delete(ctx, vpc2.ID); err == nil {
+		t.Error("VPC2.Delete deleted a network with nodes attached")
+	}
+
+	group, _, err := client.FirewallGroup.Create(ctx, &govultr.FirewallGroupReq{Description: "web"})
+	if err != nil {
+		t.Fatalf("FirewallGroup.Create returned %+v", err)
+	}
+
+	rule, _, err := client.FirewallRule.Create(ctx, group.ID, &govultr.FirewallRuleReq{IPType: "v4", Protocol: "tcp", Subnet: "0.0.0.0", Port: "443"})
+	if err != nil {
+		t.Fatalf("FirewallRule.Create returned %+v", err)
+	}
+
+	if _, _, err = client.Instance.Update(ctx, instance.ID, &govultr.InstanceUpdateReq{FirewallGroupID: group.ID}); err != nil {
+		t.Fatalf("Instance.Update returned %+v", err)
+	}
+
+	group, _, err = client.FirewallGroup.Get(ctx, group.ID)
+	if err != nil || group.RuleCount != 1 || group.InstanceCount != 1 {
+		t.Errorf("FirewallGroup.Get returned %+v, %+v", group, err)
+	}
+
+	if err = client.FirewallRule.Delete(ctx, group.ID, rule.ID); err != nil {
+		t.Errorf("FirewallRule.Delete returned %+v", err)
+	}
+
+	if _, _, err = client.FirewallRule.Get(ctx, group.ID, rule.ID); !govultr.IsNotFound(err) {
+		t.Errorf("FirewallRule.Get returned %+v, expected a not found error", err)
+	}
+}
+
+func TestFake_DomainsAndAccountResources(t *testing.T) {
+	f := New()
+	client := f.Client()
+	ctx := context.Background()
+
+	if _, _, err := client.Domain.Create(ctx, &govultr.DomainReq{Domain: "Example.com", IP: "192.0.2.1"}); err != nil {
+		t.Fatalf("Domain.Create returned %+v", err)
+	}
+
+	if _, _, err := client.Domain.Create(ctx, &govultr.DomainReq{Domain: "example.com"}); err == nil {
+		t.Error("Domain.Create accepted a domain that exists")
+	}
+
+	records, _, _, err := client.DomainRecord.List(ctx, "example.com", nil)
+	if err != nil || len(records) != 2 || records[0].Type != "A" || records[1].Name != "www" {
+		t.Fatalf("DomainRecord.List returned %+v, %+v", records, err)
+	}
+
+	if err = client.DomainRecord.Update(ctx, "example.com", records[0].ID, &govultr.DomainRecordReq{Type: "AAAA", Data: "2001:db8::1"}); err == nil {
+		t.Error("DomainRecord.Update changed the type of a record")
+	}
+
+	if err = client.DomainRecord.Update(ctx, "example.com", records[0].ID, &govultr.DomainRecordReq{Data: "192.0.2.2", TTL: 60}); err != nil {
+		t.Errorf("DomainRecord.Update returned %+v", err)
+	}
+
+	record, _, err := client.DomainRecord.Get(ctx, "example.com", records[0].ID)
+	if err != nil || record.Data != "192.0.2.2" || record.TTL != 60 {
+		t.Errorf("DomainRecord.Get returned %+v, %+v", record, err)
+	}
+
+	if err = client.Domain.Delete(ctx, "example.com"); err != nil {
+		t.Fatalf("Domain.Delete returned %+v", err)
+	}
+
+	if _, _, _, err = client.DomainRecord.List(ctx, "example.com", nil); !govultr.IsNotFound(err) {
+		t.Errorf("DomainRecord.List returned %+v, expected a not found error", err)
+	}
+
+	script, _, err := client.StartupScript.Create(ctx, &govultr.StartupScriptReq{Name: "init", Script: "IyEvYmluL3NoCg=="})
+	if err != nil || script.Type != "boot" {
+		t.Errorf("StartupScript.Create returned %+v, %+v", script, err)
+	}
+
+	disabled := false
+	if _, _, err = client.User.Create(ctx, &govultr.UserReq{Email: "ops@example.com", Name: "ops", Password: "secret"}); err != nil {
+		t.Fatalf("User.Create returned %+v", err)
+	}
+
+	if _, _, err = client.User.Create(ctx, &govultr.UserReq{Email: "audit@example.com", Name: "audit", Password: "secret", APIEnabled: &disabled}); err != nil {
+		t.Fatalf("User.Create returned %+v", err)
+	}
+
+	users, err := client.User.ListAPIEnabled(ctx)
+	if err != nil || len(users) != 1 || users[0].Email != "ops@example.com" || users[0].APIKey != "" {
+		t.Errorf("User.ListAPIEnabled returned %+v, %+v", users, err)
+	}
+
+	iso, _, err := client.ISO.Create(ctx, &govultr.ISOReq{URL: "https://example.com/images/alpine.iso"})
+	if err != nil || iso.FileName != "alpine.iso" || iso.Status != "complete" {
+		t.Errorf("ISO.Create returned %+v, %+v", iso, err)
+	}
+}
+
+func TestFake_ManagedServices(t *testing.T) {
+	f := New()
+	client := f.Client()
+	ctx := context.Background()
+
+	server, _, err := client.BareMetalServer.CreateAndWait(ctx, &govultr.BareMetalCreate{Region: "ewr", Plan: "vbm-4c-32gb", Tags: []string{"db"}}, nil)
+	if err != nil || server.Status != "active" {
+		t.Fatalf("BareMetalServer.CreateAndWait returned %+v, %+v", server, err)
+	}
+
+	if err = client.BareMetalServer.MassHalt(ctx, []string{server.ID, "baremetal-9"}); !govultr.IsNotFound(err) {
+		t.Errorf("BareMetalServer.MassHalt returned %+v, expected a not found error", err)
+	}
+
+	first, _, _ := client.Instance.Create(ctx, &govultr.InstanceCreateReq{Region: "ewr", Plan: "vc2-1c-1gb"})
+	second, _, _ := client.Instance.Create(ctx, &govultr.InstanceCreateReq{Region: "ewr", Plan: "vc2-1c-1gb"})
+	lb, _, err := client.LoadBalancer.Create(ctx, &govultr.LoadBalancerReq{
+		Region:          "ewr",
+		Label:           "web",
+		Instances:       []string{first.ID, second.ID},
+		ForwardingRules: []govultr.ForwardingRule{{FrontendProtocol: "http", FrontendPort: 80, BackendProtocol: "http", BackendPort: 8080}},
+		FirewallRules:   []govultr.LBFirewallRule{{Port: 80, IPType: "v4", Source: "0.0.0.0/0"}},
+	})
+	if err != nil || len(lb.ForwardingRules) != 1 || len(lb.FirewallRules) != 1 {
+		t.Fatalf("LoadBalancer.Create returned %+v, %+v", lb, err)
+	}
+
+	if err = client.LoadBalancer.DrainInstance(ctx, lb.ID, first.ID, nil); err != nil {
+		t.Errorf("LoadBalancer.DrainInstance returned %+v", err)
+	}
+
+	if err = client.LoadBalancer.DrainInstance(ctx, lb.ID, second.ID, nil); err == nil {
+		t.Error("LoadBalancer.DrainInstance drained the only instance")
+	}
+
+	found, err := client.LoadBalancer.FindByIP(ctx, lb.IPV6)
+	if err != nil || found.ID != lb.ID || !reflect.DeepEqual(found.Instances, []string{second.ID}) {
+		t.Errorf("LoadBalancer.FindByIP returned %+v, %+v", found, err)
+	}
+
+	if err = client.LoadBalancer.Update(ctx, lb.ID, &govultr.LoadBalancerReq{Label: "api"}); err != nil {
+		t.Fatalf("LoadBalancer.Update returned %+v", err)
+	}
+
+	if rules, _, _, errList := client.LoadBalancer.ListFirewallRules(ctx, lb.ID, nil); errList != nil || len(rules) != 0 {
+		t.Errorf("LoadBalancer.ListFirewallRules returned %+v, %+v, expected the update to reset them", rules, errList)
+	}
+
+	database, _, err := client.Database.Create(ctx, &govultr.DatabaseCreateReq{DatabaseEngine: "pg", Region: "ewr", Plan: "vultr-dbaas-startup-cc-1-55-2"})
+	if err != nil {
+		t.Fatalf("Database.Create returned %+v", err)
+	}
+
+	if _, created, errEnsure := client.Database.EnsureDB(ctx, database.ID, "app"); errEnsure != nil || !created {
+		t.Errorf("Database.EnsureDB returned %t, %+v, expected it to create app", created, errEnsure)
+	}
+
+	if _, created, errEnsure := client.Database.EnsureDB(ctx, database.ID, "app"); errEnsure != nil || created {
+		t.Errorf("Database.EnsureDB returned %t, %+v, expected app to exist", created, errEnsure)
+	}
+
+	resized, _, err := client.Database.Resize(ctx, database.ID, "vultr-dbaas-business-cc-2-80-4", nil)
+	if err != nil || resized.Plan != "vultr-dbaas-business-cc-2-80-4" || resized.Status != "Running" {
+		t.Errorf("Database.Resize returned %+v, %+v", resized, err)
+	}
+
+	f.AddObjectStorageCluster(govultr.ObjectStorageCluster{ID: 2, Region: "ewr", Hostname: "ewr1.vultrobjects.com", Deploy: "yes"})
+	cluster, err := client.ObjectStorage.ClusterForRegion(ctx, "EWR")
+	if err != nil || cluster.ID != 2 {
+		t.Fatalf("ObjectStorage.ClusterForRegion returned %+v, %+v", cluster, err)
+	}
+
+	storage, _, err := client.ObjectStorage.Create(ctx, cluster.ID, "backups")
+	if err != nil || storage.S3Hostname != "ewr1.vultrobjects.com" {
+		t.Fatalf("ObjectStorage.Create returned %+v, %+v", storage, err)
+	}
+
+	keys, _, err := client.ObjectStorage.RegenerateKeys(ctx, storage.ID)
+	if err != nil || keys.S3AccessKey == storage.S3AccessKey {
+		t.Errorf("ObjectStorage.RegenerateKeys returned %+v, %+v", keys, err)
+	}
+
+	zone, _, err := client.CDN.CreatePullZone(ctx, &govultr.CDNZoneReq{Label: "assets", OriginScheme: "https", OriginDomain: "example.com"})
+	if err != nil {
+		t.Fatalf("CDN.CreatePullZone returned %+v", err)
+	}
+
+	if err = client.CDN.PurgePullZone(ctx, zone.ID); err != nil {
+		t.Errorf("CDN.PurgePullZone returned %+v", err)
+	}
+
+	if _, _, err = client.CDN.GetPushZone(ctx, zone.ID); !govultr.IsNotFound(err) {
+		t.Errorf("CDN.GetPushZone returned %+v, expected a not found error", err)
+	}
+
+	subscription, _, err := client.Inference.Create(ctx, &govultr.InferenceCreateUpdateReq{Label: "chat"})
+	if err != nil || subscription.APIKey == "" {
+		t.Errorf("Inference.Create returned %+v, %+v", subscription, err)
+	}
+}
+
+func TestFake_Catalogs(t *testing.T) {
+	f := New()
+	client := f.Client()
+	ctx := context.Background()
+
+	f.SetAccount(govultr.Account{Name: "ops", Email: "ops@example.com", ACL: []string{"manage_servers"}})
+	f.AddRegion(govultr.Region{ID: "ewr", City: "New Jersey", Country: "US"})
+	f.AddPlan(govultr.Plan{ID: "vc2-1c-1gb", Type: "vc2", Locations: []string{"ewr"}})
+	f.AddPlan(govultr.Plan{ID: "vhf-1c-1gb", Type: "vhf", Locations: []string{"sjc"}})
+	f.AddBareMetalPlan(govultr.BareMetalPlan{ID: "vbm-4c-32gb", Type: "SSD", Locations: []string{"ewr"}})
+	f.AddInvoice(govultr.Invoice{ID: 7, Amount: 5}, govultr.InvoiceItem{Product: "Cloud Compute", Total: 5})
+	f.SetAppVariables("wordpress", govultr.MarketplaceAppVariable{Name: "site_name"})
+
+	var missing *govultr.MissingACLError
+	if err := client.Account.Require(ctx, "manage_servers", "billing"); !errors.As(err, &missing) || !reflect.DeepEqual(missing.Missing, []string{"billing"}) {
+		t.Errorf("Account.Require returned %+v, expected billing to be missing", err)
+	}
+
+	availability, _, err := client.Region.Availability(ctx, "ewr", "")
+	if expected := []string{"vc2-1c-1gb", "vbm-4c-32gb"}; err != nil || !reflect.DeepEqual(availability.AvailablePlans, expected) {
+		t.Errorf("Region.Availability returned %+v, %+v, expected %+v", availability, err, expected)
+	}
+
+	plans, _, _, err := client.Plan.List(ctx, "vhf", nil)
+	if err != nil || len(plans) != 1 || plans[0].ID != "vhf-1c-1gb" {
+		t.Errorf("Plan.List returned %+v, %+v", plans, err)
+	}
+
+	items, _, _, err := client.Billing.ListInvoiceItems(ctx, 7, nil)
+	if err != nil || len(items) != 1 || items[0].Total != 5 {
+		t.Errorf("Billing.ListInvoiceItems returned %+v, %+v", items, err)
+	}
+
+	if _, _, err = client.Billing.GetInvoice(ctx, "8"); !govultr.IsNotFound(err) {
+		t.Errorf("Billing.GetInvoice returned %+v, expected a not found error", err)
+	}
+
+	variables, _, err := client.Marketplace.ListAppVariables(ctx, "wordpress")
+	if err != nil || len(variables) != 1 || variables[0].Name != "site_name" {
+		t.Errorf("Marketplace.ListAppVariables returned %+v, %+v", variables, err)
+	}
+}
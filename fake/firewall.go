@@ -0,0 +1,237 @@
+package fake
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/vultr/govultr/v3"
+)
+
+const (
+	firewallGroupService = "FirewallGroup"
+	firewallRuleService  = "FirewallRule"
+
+	// maxFirewallRules is the rule limit of a firewall group
+	maxFirewallRules = 50
+)
+
+// FirewallGroupService is an in-memory govultr.FirewallGroupService. Create,
+// Get, Update, Delete and List are kept in memory, other methods fall through
+// to the embedded service and fail.
+type FirewallGroupService struct {
+	govultr.FirewallGroupService
+	fake *Fake
+}
+
+// Create adds an empty firewall group
+func (s *FirewallGroupService) Create(_ context.Context, fwGroupReq *govultr.FirewallGroupReq) (*govultr.FirewallGroup, *http.Response, error) { //nolint:lll
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	id := f.newID("firewall")
+	group := &govultr.FirewallGroup{ID: id, DateCreated: f.now(), DateModified: f.now(), MaxRuleCount: maxFirewallRules}
+	if fwGroupReq != nil {
+		group.Description = fwGroupReq.Description
+	}
+	f.firewallGroups.add(id, group)
+	f.firewallRules[id] = newCollection[govultr.FirewallRule]()
+	f.record(firewallGroupService, "Create", id)
+
+	return clone(group), response(http.StatusCreated), nil
+}
+
+// Get returns a firewall group with the number of instances using it
+func (s *FirewallGroupService) Get(_ context.Context, groupID string) (*govultr.FirewallGroup, *http.Response, error) {
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	group, ok := f.firewallGroup(groupID)
+	if !ok {
+		return nil, nil, notFound("firewall group", groupID)
+	}
+
+	return group, response(http.StatusOK), nil
+}
+
+// Update changes the description of a firewall group
+func (s *FirewallGroupService) Update(_ context.Context, fwGroupID string, fwGroupReq *govultr.FirewallGroupReq) error {
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	group, ok := f.firewallGroups.get(fwGroupID)
+	if !ok {
+		return notFound("firewall group", fwGroupID)
+	}
+
+	if fwGroupReq != nil {
+		group.Description = fwGroupReq.Description
+	}
+	group.DateModified = f.now()
+	f.record(firewallGroupService, "Update", fwGroupID)
+
+	return nil
+}
+
+// Delete removes a firewall group and its rules
+func (s *FirewallGroupService) Delete(_ context.Context, fwGroupID string) error {
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if !f.firewallGroups.remove(fwGroupID) {
+		return notFound("firewall group", fwGroupID)
+	}
+	delete(f.firewallRules, fwGroupID)
+	f.record(firewallGroupService, "Delete", fwGroupID)
+
+	return nil
+}
+
+// List returns firewall groups
+func (s *FirewallGroupService) List(_ context.Context, options *govultr.ListOptions) ([]govultr.FirewallGroup, *govultr.Meta, *http.Response, error) { //nolint:lll
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	groups, meta := f.firewallGroups.list(options, nil)
+	for i := range groups {
+		group, _ := f.firewallGroup(groups[i].ID)
+		groups[i] = *group
+	}
+
+	return groups, meta, response(http.StatusOK), nil
+}
+
+// firewallGroup returns a copy of a firewall group with its counts filled in.
+// Callers must hold f.mu.
+func (f *Fake) firewallGroup(groupID string) (*govultr.FirewallGroup, bool) {
+	group, ok := f.firewallGroups.get(groupID)
+	if !ok {
+		return nil, false
+	}
+
+	group = clone(group)
+	group.RuleCount = len(f.firewallRules[groupID].ids)
+	group.InstanceCount = 0
+	for _, id := range f.instances.ids {
+		if instance, _ := f.instances.get(id); instance.FirewallGroupID == groupID {
+			group.InstanceCount++
+		}
+	}
+
+	return group, true
+}
+
+// FirewallRuleService is an in-memory govultr.FireWallRuleService. Create,
+// Get, Delete and List are kept in memory, other methods fall through to the
+// embedded service and fail.
+type FirewallRuleService struct {
+	govultr.FireWallRuleService
+	fake *Fake
+}
+
+// Create adds a rule to a firewall group
+func (s *FirewallRuleService) Create(_ context.Context, fwGroupID string, fwRuleReq *govultr.FirewallRuleReq) (*govultr.FirewallRule, *http.Response, error) { //nolint:lll
+	if fwRuleReq == nil || fwRuleReq.IPType == "" || fwRuleReq.Protocol == "" || fwRuleReq.Subnet == "" {
+		return nil, nil, apiError(http.StatusBadRequest, "ip_type, protocol and subnet are required")
+	}
+
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	rules, ok := f.firewallRules[fwGroupID]
+	if !ok {
+		return nil, nil, notFound("firewall group", fwGroupID)
+	}
+
+	if len(rules.ids) >= maxFirewallRules {
+		return nil, nil, apiError(http.StatusBadRequest, "firewall group %s has reached its limit of %d rules", fwGroupID, maxFirewallRules)
+	}
+
+	f.counters["firewallrule"]++
+	rule := &govultr.FirewallRule{
+		ID:         f.counters["firewallrule"],
+		Action:     "accept",
+		Type:       fwRuleReq.IPType,
+		IPType:     fwRuleReq.IPType,
+		Protocol:   fwRuleReq.Protocol,
+		Port:       fwRuleReq.Port,
+		Subnet:     fwRuleReq.Subnet,
+		SubnetSize: fwRuleReq.SubnetSize,
+		Source:     fwRuleReq.Source,
+		Notes:      fwRuleReq.Notes,
+	}
+	rules.add(strconv.Itoa(rule.ID), rule)
+	f.touchFirewallGroup(fwGroupID)
+	f.record(firewallRuleService, "Create", fwGroupID)
+
+	return clone(rule), response(http.StatusCreated), nil
+}
+
+// Get returns a rule of a firewall group
+func (s *FirewallRuleService) Get(_ context.Context, fwGroupID string, fwRuleID int) (*govultr.FirewallRule, *http.Response, error) { //nolint:lll
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	rules, ok := f.firewallRules[fwGroupID]
+	if !ok {
+		return nil, nil, notFound("firewall group", fwGroupID)
+	}
+
+	rule, ok := rules.get(strconv.Itoa(fwRuleID))
+	if !ok {
+		return nil, nil, notFound("firewall rule", strconv.Itoa(fwRuleID))
+	}
+
+	return clone(rule), response(http.StatusOK), nil
+}
+
+// Delete removes a rule from a firewall group
+func (s *FirewallRuleService) Delete(_ context.Context, fwGroupID string, fwRuleID int) error {
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	rules, ok := f.firewallRules[fwGroupID]
+	if !ok {
+		return notFound("firewall group", fwGroupID)
+	}
+
+	if !rules.remove(strconv.Itoa(fwRuleID)) {
+		return notFound("firewall rule", strconv.Itoa(fwRuleID))
+	}
+	f.touchFirewallGroup(fwGroupID)
+	f.record(firewallRuleService, "Delete", fwGroupID)
+
+	return nil
+}
+
+// List returns the rules of a firewall group
+func (s *FirewallRuleService) List(_ context.Context, fwGroupID string, options *govultr.ListOptions) ([]govultr.FirewallRule, *govultr.Meta, *http.Response, error) { //nolint:lll
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	rules, ok := f.firewallRules[fwGroupID]
+	if !ok {
+		return nil, nil, nil, notFound("firewall group", fwGroupID)
+	}
+
+	list, meta := rules.list(options, nil)
+
+	return list, meta, response(http.StatusOK), nil
+}
+
+// touchFirewallGroup updates the modification date of a firewall group.
+// Callers must hold f.mu.
+func (f *Fake) touchFirewallGroup(groupID string) {
+	if group, ok := f.firewallGroups.get(groupID); ok {
+		group.DateModified = f.now()
+	}
+}
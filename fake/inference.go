@@ -0,0 +1,112 @@
+package fake
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/vultr/govultr/v3"
+)
+
+const inferenceService = "Inference"
+
+// InferenceService is an in-memory govultr.InferenceService. Every method is
+// kept in memory, and subscriptions have no usage.
+type InferenceService struct {
+	govultr.InferenceService
+	fake *Fake
+}
+
+// List returns Serverless Inference subscriptions
+func (s *InferenceService) List(_ context.Context) ([]govultr.Inference, *http.Response, error) {
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	subscriptions, _ := f.inferences.list(nil, nil)
+
+	return subscriptions, response(http.StatusOK), nil
+}
+
+// Create adds a Serverless Inference subscription with an API key
+func (s *InferenceService) Create(_ context.Context, inferenceReq *govultr.InferenceCreateUpdateReq) (*govultr.Inference, *http.Response, error) { //nolint:lll
+	if inferenceReq == nil || inferenceReq.Label == "" {
+		return nil, nil, apiError(http.StatusBadRequest, "label is required")
+	}
+
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	id := f.newID("inference")
+	subscription := &govultr.Inference{
+		ID:          id,
+		DateCreated: f.now(),
+		Label:       inferenceReq.Label,
+		APIKey:      "FAKE" + strings.ToUpper(strings.ReplaceAll(id, "-", "")),
+	}
+	f.inferences.add(id, subscription)
+	f.record(inferenceService, "Create", id)
+
+	return clone(subscription), response(http.StatusCreated), nil
+}
+
+// Get returns a Serverless Inference subscription
+func (s *InferenceService) Get(_ context.Context, inferenceID string) (*govultr.Inference, *http.Response, error) {
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	subscription, ok := f.inferences.get(inferenceID)
+	if !ok {
+		return nil, nil, notFound("inference subscription", inferenceID)
+	}
+
+	return clone(subscription), response(http.StatusOK), nil
+}
+
+// Update changes the label of a Serverless Inference subscription
+func (s *InferenceService) Update(_ context.Context, inferenceID string, inferenceReq *govultr.InferenceCreateUpdateReq) (*govultr.Inference, *http.Response, error) { //nolint:lll
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	subscription, ok := f.inferences.get(inferenceID)
+	if !ok {
+		return nil, nil, notFound("inference subscription", inferenceID)
+	}
+
+	if inferenceReq != nil && inferenceReq.Label != "" {
+		subscription.Label = inferenceReq.Label
+	}
+	f.record(inferenceService, "Update", inferenceID)
+
+	return clone(subscription), response(http.StatusAccepted), nil
+}
+
+// Delete removes a Serverless Inference subscription
+func (s *InferenceService) Delete(_ context.Context, inferenceID string) error {
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if !f.inferences.remove(inferenceID) {
+		return notFound("inference subscription", inferenceID)
+	}
+	f.record(inferenceService, "Delete", inferenceID)
+
+	return nil
+}
+
+// GetUsage returns no usage for an existing Serverless Inference subscription
+func (s *InferenceService) GetUsage(_ context.Context, inferenceID string) (*govultr.InferenceUsage, *http.Response, error) {
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if _, ok := f.inferences.get(inferenceID); !ok {
+		return nil, nil, notFound("inference subscription", inferenceID)
+	}
+
+	return &govultr.InferenceUsage{}, response(http.StatusOK), nil
+}
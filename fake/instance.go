@@ -0,0 +1,258 @@
+package fake
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/vultr/govultr/v3"
+)
+
+const instanceService = "Instance"
+
+// InstanceService is an in-memory govultr.InstanceService. Create, Get, Update,
+// Delete, List, the power actions, Reinstall, EnableIPv6 and the waiters are
+// kept in memory, other methods fall through to the embedded service and fail.
+type InstanceService struct {
+	govultr.InstanceService
+	fake *Fake
+}
+
+// Create adds an instance that is immediately active and running
+func (s *InstanceService) Create(_ context.Context, instanceReq *govultr.InstanceCreateReq) (*govultr.Instance, *http.Response, error) { //nolint:lll
+	if instanceReq == nil || instanceReq.Region == "" || instanceReq.Plan == "" {
+		return nil, nil, apiError(http.StatusBadRequest, "region and plan are required")
+	}
+
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	id := f.newID("instance")
+	instance := &govultr.Instance{
+		ID:              id,
+		Plan:            instanceReq.Plan,
+		Region:          instanceReq.Region,
+		Label:           instanceReq.Label,
+		Hostname:        instanceReq.Hostname,
+		Tags:            slices.Clone(instanceReq.Tags),
+		OsID:            instanceReq.OsID,
+		AppID:           instanceReq.AppID,
+		ImageID:         instanceReq.ImageID,
+		FirewallGroupID: instanceReq.FirewallGroupID,
+		MainIP:          fmt.Sprintf("192.0.2.%d", f.counters["instance"]%254+1),
+		DateCreated:     f.now(),
+		Status:          "active",
+		PowerStatus:     "running",
+		ServerStatus:    "ok",
+	}
+	f.instances.add(id, instance)
+	f.record(instanceService, "Create", id)
+
+	return clone(instance), response(http.StatusAccepted), nil
+}
+
+// Get returns an instance
+func (s *InstanceService) Get(_ context.Context, instanceID string) (*govultr.Instance, *http.Response, error) {
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	instance, ok := f.instances.get(instanceID)
+	if !ok {
+		return nil, nil, notFound("instance", instanceID)
+	}
+
+	return clone(instance), response(http.StatusOK), nil
+}
+
+// Update changes the plan, label, tags, image and firewall group of an
+// instance. Tags are left alone when nil.
+func (s *InstanceService) Update(_ context.Context, instanceID string, instanceReq *govultr.InstanceUpdateReq) (*govultr.Instance, *http.Response, error) { //nolint:lll
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	instance, ok := f.instances.get(instanceID)
+	if !ok {
+		return nil, nil, notFound("instance", instanceID)
+	}
+
+	if instanceReq != nil {
+		if instanceReq.Plan != "" {
+			instance.Plan = instanceReq.Plan
+		}
+		if instanceReq.Label != "" {
+			instance.Label = instanceReq.Label
+		}
+		if instanceReq.Tags != nil {
+			instance.Tags = slices.Clone(instanceReq.Tags)
+		}
+		if instanceReq.OsID != 0 {
+			instance.OsID, instance.AppID, instance.ImageID = instanceReq.OsID, 0, ""
+		}
+		if instanceReq.AppID != 0 {
+			instance.OsID, instance.AppID, instance.ImageID = 0, instanceReq.AppID, ""
+		}
+		if instanceReq.ImageID != "" {
+			instance.OsID, instance.AppID, instance.ImageID = 0, 0, instanceReq.ImageID
+		}
+		if instanceReq.FirewallGroupID != "" {
+			instance.FirewallGroupID = instanceReq.FirewallGroupID
+		}
+	}
+	f.record(instanceService, "Update", instanceID)
+
+	return clone(instance), response(http.StatusAccepted), nil
+}
+
+// Delete removes an instance
+func (s *InstanceService) Delete(_ context.Context, instanceID string) error {
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if !f.instances.remove(instanceID) {
+		return notFound("instance", instanceID)
+	}
+	f.record(instanceService, "Delete", instanceID)
+
+	return nil
+}
+
+// List returns instances, filtered by the label, tag, main IP and region of
+// options like the API does
+func (s *InstanceService) List(_ context.Context, options *govultr.ListOptions) ([]govultr.Instance, *govultr.Meta, *http.Response, error) { //nolint:lll
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	instances, meta := f.instances.list(options, func(instance *govultr.Instance) bool {
+		return options == nil ||
+			(options.Label == "" || instance.Label == options.Label) &&
+				(options.Tag == "" || slices.Contains(instance.Tags, options.Tag)) &&
+				(options.MainIP == "" || instance.MainIP == options.MainIP) &&
+				(options.Region == "" || instance.Region == options.Region)
+	})
+
+	return instances, meta, response(http.StatusOK), nil
+}
+
+// Start powers on an instance
+func (s *InstanceService) Start(_ context.Context, instanceID string) error {
+	return s.setPower("Start", "running", instanceID)
+}
+
+// Halt powers off an instance
+func (s *InstanceService) Halt(_ context.Context, instanceID string) error {
+	return s.setPower("Halt", "stopped", instanceID)
+}
+
+// Reboot reboots an instance, leaving it running
+func (s *InstanceService) Reboot(_ context.Context, instanceID string) error {
+	return s.setPower("Reboot", "running", instanceID)
+}
+
+// MassStart powers on several instances
+func (s *InstanceService) MassStart(_ context.Context, instanceList []string) error {
+	return s.setPower("MassStart", "running", instanceList...)
+}
+
+// MassHalt powers off several instances
+func (s *InstanceService) MassHalt(_ context.Context, instanceList []string) error {
+	return s.setPower("MassHalt", "stopped", instanceList...)
+}
+
+// MassReboot reboots several instances, leaving them running
+func (s *InstanceService) MassReboot(_ context.Context, instanceList []string) error {
+	return s.setPower("MassReboot", "running", instanceList...)
+}
+
+// setPower sets the power status of instances, changing none of them when one
+// does not exist
+func (s *InstanceService) setPower(method, status string, instanceIDs ...string) error {
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for _, id := range instanceIDs {
+		if _, ok := f.instances.get(id); !ok {
+			return notFound("instance", id)
+		}
+	}
+
+	for _, id := range instanceIDs {
+		instance, _ := f.instances.get(id)
+		instance.PowerStatus = status
+		f.record(instanceService, method, id)
+	}
+
+	return nil
+}
+
+// WaitForStatus returns an instance if its status, power status or server
+// status already is status
+func (s *InstanceService) WaitForStatus(ctx context.Context, instanceID, status string, _ *govultr.WaitOptions) (*govultr.Instance, *http.Response, error) { //nolint:lll
+	instance, resp, err := s.Get(ctx, instanceID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err = reached("instance", instanceID, status, instance.Status, instance.PowerStatus, instance.ServerStatus); err != nil {
+		return nil, nil, err
+	}
+
+	return instance, resp, nil
+}
+
+// EnableIPv6 gives an instance an IPv6 address
+func (s *InstanceService) EnableIPv6(_ context.Context, instanceID string, _ *govultr.WaitOptions) (*govultr.Instance, *http.Response, error) { //nolint:lll
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	instance, ok := f.instances.get(instanceID)
+	if !ok {
+		return nil, nil, notFound("instance", instanceID)
+	}
+
+	if instance.V6MainIP == "" {
+		instance.V6Network = fmt.Sprintf("2001:db8:%x::", f.counters["instance"])
+		instance.V6MainIP = instance.V6Network + "1"
+		instance.V6NetworkSize = 64
+	}
+	f.record(instanceService, "EnableIPv6", instanceID)
+
+	return clone(instance), response(http.StatusOK), nil
+}
+
+// Reinstall reinstalls an instance, which is immediately active again
+func (s *InstanceService) Reinstall(_ context.Context, instanceID string, reinstallReq *govultr.ReinstallReq) (*govultr.Instance, *http.Response, error) { //nolint:lll
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	instance, ok := f.instances.get(instanceID)
+	if !ok {
+		return nil, nil, notFound("instance", instanceID)
+	}
+
+	if reinstallReq != nil && reinstallReq.Hostname != "" {
+		instance.Hostname = reinstallReq.Hostname
+	}
+	instance.Status, instance.PowerStatus, instance.ServerStatus = "active", "running", "ok"
+	f.record(instanceService, "Reinstall", instanceID)
+
+	return clone(instance), response(http.StatusAccepted), nil
+}
+
+// ReinstallAndWait reinstalls an instance. User data is accepted but not kept.
+func (s *InstanceService) ReinstallAndWait(ctx context.Context, instanceID string, reinstallOptions *govultr.ReinstallOptions, _ *govultr.WaitOptions) (*govultr.Instance, *http.Response, error) { //nolint:lll
+	reinstallReq := &govultr.ReinstallReq{}
+	if reinstallOptions != nil {
+		reinstallReq.Hostname = reinstallOptions.Hostname
+	}
+
+	return s.Reinstall(ctx, instanceID, reinstallReq)
+}
@@ -0,0 +1,83 @@
+package fake
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/vultr/govultr/v3"
+)
+
+const isoService = "ISO"
+
+// ISOService is an in-memory govultr.ISOService. Create, Get, Delete and List
+// are kept in memory, other methods fall through to the embedded service and
+// fail.
+type ISOService struct {
+	govultr.ISOService
+	fake *Fake
+}
+
+// Create adds an ISO that is immediately complete, named after the last
+// element of its URL
+func (s *ISOService) Create(_ context.Context, isoReq *govultr.ISOReq) (*govultr.ISO, *http.Response, error) {
+	if isoReq == nil || isoReq.URL == "" {
+		return nil, nil, apiError(http.StatusBadRequest, "url is required")
+	}
+
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	id := f.newID("iso")
+	iso := &govultr.ISO{
+		ID:          id,
+		DateCreated: f.now(),
+		FileName:    isoReq.URL[strings.LastIndex(isoReq.URL, "/")+1:],
+		Size:        512 << 20,
+		Status:      "complete",
+	}
+	f.isos.add(id, iso)
+	f.record(isoService, "Create", id)
+
+	return clone(iso), response(http.StatusCreated), nil
+}
+
+// Get returns an ISO
+func (s *ISOService) Get(_ context.Context, isoID string) (*govultr.ISO, *http.Response, error) {
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	iso, ok := f.isos.get(isoID)
+	if !ok {
+		return nil, nil, notFound("iso", isoID)
+	}
+
+	return clone(iso), response(http.StatusOK), nil
+}
+
+// Delete removes an ISO
+func (s *ISOService) Delete(_ context.Context, isoID string) error {
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if !f.isos.remove(isoID) {
+		return notFound("iso", isoID)
+	}
+	f.record(isoService, "Delete", isoID)
+
+	return nil
+}
+
+// List returns ISOs
+func (s *ISOService) List(_ context.Context, options *govultr.ListOptions) ([]govultr.ISO, *govultr.Meta, *http.Response, error) {
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	isos, meta := f.isos.list(options, nil)
+
+	return isos, meta, response(http.StatusOK), nil
+}
@@ -0,0 +1,297 @@
+package fake
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"net/http"
+
+	"github.com/vultr/govultr/v3"
+)
+
+const kubernetesService = "Kubernetes"
+
+// KubernetesService is an in-memory govultr.KubernetesService. Clusters and
+// node pools are kept in memory, other methods fall through to the embedded
+// service and fail. Node pools are scaled by adding or removing nodes.
+type KubernetesService struct {
+	govultr.KubernetesService
+	fake *Fake
+}
+
+// CreateCluster adds an active cluster with its node pools
+func (s *KubernetesService) CreateCluster(_ context.Context, createReq *govultr.ClusterReq) (*govultr.Cluster, *http.Response, error) { //nolint:lll
+	if createReq == nil || createReq.Region == "" || createReq.Version == "" || len(createReq.NodePools) == 0 {
+		return nil, nil, apiError(http.StatusBadRequest, "region, version and at least one node pool are required")
+	}
+
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	id := f.newID("vke")
+	cluster := &govultr.Cluster{
+		ID:              id,
+		Label:           createReq.Label,
+		DateCreated:     f.now(),
+		Endpoint:        fmt.Sprintf("%s.vultr-k8s.com", id),
+		Version:         createReq.Version,
+		Region:          createReq.Region,
+		Status:          "active",
+		HAControlPlanes: createReq.HAControlPlanes,
+	}
+	for i := range createReq.NodePools {
+		cluster.NodePools = append(cluster.NodePools, *f.newNodePool(&createReq.NodePools[i]))
+	}
+	f.clusters.add(id, cluster)
+	f.record(kubernetesService, "CreateCluster", id)
+
+	return clone(cluster), response(http.StatusCreated), nil
+}
+
+// GetCluster returns a cluster
+func (s *KubernetesService) GetCluster(_ context.Context, id string) (*govultr.Cluster, *http.Response, error) {
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	cluster, ok := f.clusters.get(id)
+	if !ok {
+		return nil, nil, notFound("cluster", id)
+	}
+
+	return clone(cluster), response(http.StatusOK), nil
+}
+
+// ListClusters returns clusters
+func (s *KubernetesService) ListClusters(_ context.Context, options *govultr.ListOptions) ([]govultr.Cluster, *govultr.Meta, *http.Response, error) { //nolint:lll
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	clusters, meta := f.clusters.list(options, nil)
+
+	return clusters, meta, response(http.StatusOK), nil
+}
+
+// UpdateCluster changes the label of a cluster
+func (s *KubernetesService) UpdateCluster(_ context.Context, vkeID string, updateReq *govultr.ClusterReqUpdate) error {
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	cluster, ok := f.clusters.get(vkeID)
+	if !ok {
+		return notFound("cluster", vkeID)
+	}
+
+	if updateReq != nil {
+		cluster.Label = updateReq.Label
+	}
+	f.record(kubernetesService, "UpdateCluster", vkeID)
+
+	return nil
+}
+
+// DeleteCluster removes a cluster
+func (s *KubernetesService) DeleteCluster(_ context.Context, id string) error {
+	return s.deleteCluster("DeleteCluster", id)
+}
+
+// DeleteClusterWithResources removes a cluster. The fake keeps no resources
+// linked to clusters, so this is the same as DeleteCluster.
+func (s *KubernetesService) DeleteClusterWithResources(_ context.Context, id string) error {
+	return s.deleteCluster("DeleteClusterWithResources", id)
+}
+
+func (s *KubernetesService) deleteCluster(method, id string) error {
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if !f.clusters.remove(id) {
+		return notFound("cluster", id)
+	}
+	f.record(kubernetesService, method, id)
+
+	return nil
+}
+
+// CreateNodePool adds a node pool to a cluster
+func (s *KubernetesService) CreateNodePool(_ context.Context, vkeID string, nodePoolReq *govultr.NodePoolReq) (*govultr.NodePool, *http.Response, error) { //nolint:lll
+	if nodePoolReq == nil || nodePoolReq.Plan == "" || nodePoolReq.NodeQuantity < 1 {
+		return nil, nil, apiError(http.StatusBadRequest, "plan and a node quantity of at least 1 are required")
+	}
+
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	cluster, ok := f.clusters.get(vkeID)
+	if !ok {
+		return nil, nil, notFound("cluster", vkeID)
+	}
+
+	pool := f.newNodePool(nodePoolReq)
+	cluster.NodePools = append(cluster.NodePools, *pool)
+	f.record(kubernetesService, "CreateNodePool", pool.ID)
+
+	return clone(pool), response(http.StatusCreated), nil
+}
+
+// ListNodePools returns the node pools of a cluster
+func (s *KubernetesService) ListNodePools(_ context.Context, vkeID string, options *govultr.ListOptions) ([]govultr.NodePool, *govultr.Meta, *http.Response, error) { //nolint:lll
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	cluster, ok := f.clusters.get(vkeID)
+	if !ok {
+		return nil, nil, nil, notFound("cluster", vkeID)
+	}
+
+	pools, meta := paginate(clone(cluster).NodePools, options)
+
+	return pools, meta, response(http.StatusOK), nil
+}
+
+// GetNodePool returns a node pool of a cluster
+func (s *KubernetesService) GetNodePool(_ context.Context, vkeID, nodePoolID string) (*govultr.NodePool, *http.Response, error) { //nolint:lll
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	_, pool, err := f.nodePool(vkeID, nodePoolID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return clone(pool), response(http.StatusOK), nil
+}
+
+// UpdateNodePool changes the size, tag, autoscaler and labels of a node pool
+func (s *KubernetesService) UpdateNodePool(_ context.Context, vkeID, nodePoolID string, updateReq *govultr.NodePoolReqUpdate) (*govultr.NodePool, *http.Response, error) { //nolint:lll
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	_, pool, err := f.nodePool(vkeID, nodePoolID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if updateReq != nil {
+		if updateReq.NodeQuantity > 0 {
+			f.scaleNodePool(pool, updateReq.NodeQuantity)
+		}
+		if updateReq.Tag != nil {
+			pool.Tag = *updateReq.Tag
+		}
+		if updateReq.MinNodes > 0 {
+			pool.MinNodes = updateReq.MinNodes
+		}
+		if updateReq.MaxNodes > 0 {
+			pool.MaxNodes = updateReq.MaxNodes
+		}
+		if updateReq.AutoScaler != nil {
+			pool.AutoScaler = *updateReq.AutoScaler
+		}
+		if updateReq.Labels != nil {
+			pool.Labels = maps.Clone(updateReq.Labels)
+		}
+		pool.DateUpdated = f.now()
+	}
+	f.record(kubernetesService, "UpdateNodePool", nodePoolID)
+
+	return clone(pool), response(http.StatusAccepted), nil
+}
+
+// DeleteNodePool removes a node pool from a cluster
+func (s *KubernetesService) DeleteNodePool(_ context.Context, vkeID, nodePoolID string) error {
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	cluster, _, err := f.nodePool(vkeID, nodePoolID)
+	if err != nil {
+		return err
+	}
+
+	for i := range cluster.NodePools {
+		if cluster.NodePools[i].ID == nodePoolID {
+			cluster.NodePools = append(cluster.NodePools[:i], cluster.NodePools[i+1:]...)
+			break
+		}
+	}
+	f.record(kubernetesService, "DeleteNodePool", nodePoolID)
+
+	return nil
+}
+
+// nodePool finds a node pool of a cluster. Callers must hold f.mu.
+func (f *Fake) nodePool(vkeID, nodePoolID string) (*govultr.Cluster, *govultr.NodePool, error) {
+	cluster, ok := f.clusters.get(vkeID)
+	if !ok {
+		return nil, nil, notFound("cluster", vkeID)
+	}
+
+	for i := range cluster.NodePools {
+		if cluster.NodePools[i].ID == nodePoolID {
+			return cluster, &cluster.NodePools[i], nil
+		}
+	}
+
+	return nil, nil, notFound("node pool", nodePoolID)
+}
+
+// newNodePool returns an active node pool with its nodes. Callers must hold f.mu.
+func (f *Fake) newNodePool(req *govultr.NodePoolReq) *govultr.NodePool {
+	pool := &govultr.NodePool{
+		ID:          f.newID("nodepool"),
+		DateCreated: f.now(),
+		DateUpdated: f.now(),
+		Label:       req.Label,
+		Plan:        req.Plan,
+		Status:      "active",
+		MinNodes:    req.MinNodes,
+		MaxNodes:    req.MaxNodes,
+		Tag:         req.Tag,
+		Labels:      maps.Clone(req.Labels),
+	}
+	if req.AutoScaler != nil {
+		pool.AutoScaler = *req.AutoScaler
+	}
+	f.scaleNodePool(pool, req.NodeQuantity)
+
+	return pool
+}
+
+// scaleNodePool adds or removes the newest nodes of a pool until it has
+// quantity nodes. Callers must hold f.mu.
+func (f *Fake) scaleNodePool(pool *govultr.NodePool, quantity int) {
+	for len(pool.Nodes) < quantity {
+		id := f.newID("node")
+		pool.Nodes = append(pool.Nodes, govultr.Node{
+			ID:          id,
+			DateCreated: f.now(),
+			Label:       fmt.Sprintf("%s-%s", pool.Label, id),
+			Status:      "active",
+		})
+	}
+	pool.Nodes = pool.Nodes[:quantity]
+	pool.NodeQuantity = quantity
+}
+
+// WaitForClusterStatus returns a cluster if its status already is status
+func (s *KubernetesService) WaitForClusterStatus(ctx context.Context, vkeID, status string, _ *govultr.WaitOptions) (*govultr.Cluster, *http.Response, error) { //nolint:lll
+	cluster, resp, err := s.GetCluster(ctx, vkeID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err = reached("cluster", vkeID, status, cluster.Status); err != nil {
+		return nil, nil, err
+	}
+
+	return cluster, resp, nil
+}
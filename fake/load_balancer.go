@@ -0,0 +1,403 @@
+package fake
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/netip"
+	"slices"
+
+	"github.com/vultr/govultr/v3"
+)
+
+const loadBalancerService = "LoadBalancer"
+
+// LoadBalancerService is an in-memory govultr.LoadBalancerService. Every
+// method is kept in memory.
+type LoadBalancerService struct {
+	govultr.LoadBalancerService
+	fake *Fake
+}
+
+// Create adds a load balancer that is immediately active
+func (s *LoadBalancerService) Create(_ context.Context, createReq *govultr.LoadBalancerReq) (*govultr.LoadBalancer, *http.Response, error) { //nolint:lll
+	if createReq == nil || createReq.Region == "" {
+		return nil, nil, apiError(http.StatusBadRequest, "region is required")
+	}
+
+	createReq = clone(createReq)
+
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for _, id := range createReq.Instances {
+		if _, ok := f.instances.get(id); !ok {
+			return nil, nil, notFound("instance", id)
+		}
+	}
+
+	id := f.newID("lb")
+	lb := &govultr.LoadBalancer{
+		ID:          id,
+		DateCreated: f.now(),
+		Region:      createReq.Region,
+		Label:       createReq.Label,
+		Status:      "active",
+		IPV4:        fmt.Sprintf("198.18.0.%d", f.counters["lb"]%254+1),
+		IPV6:        fmt.Sprintf("2001:db8:1b::%x", f.counters["lb"]),
+		Nodes:       max(createReq.Nodes, 1),
+		HealthCheck: createReq.HealthCheck,
+		GenericInfo: &govultr.GenericInfo{BalancingAlgorithm: "roundrobin"},
+		SSLInfo:     new(bool),
+	}
+	s.apply(lb, createReq)
+	f.loadBalancers.add(id, lb)
+	f.record(loadBalancerService, "Create", id)
+
+	return clone(lb), response(http.StatusAccepted), nil
+}
+
+// CreateAndWait adds a load balancer, which is active immediately. The probe
+// is not made.
+func (s *LoadBalancerService) CreateAndWait(ctx context.Context, createReq *govultr.LoadBalancerReq, _ *govultr.WaitOptions, _ *govultr.LoadBalancerProbe) (*govultr.LoadBalancer, *http.Response, error) { //nolint:lll
+	return s.Create(ctx, createReq)
+}
+
+// Get returns a load balancer
+func (s *LoadBalancerService) Get(_ context.Context, lbID string) (*govultr.LoadBalancer, *http.Response, error) {
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	lb, ok := f.loadBalancers.get(lbID)
+	if !ok {
+		return nil, nil, notFound("load balancer", lbID)
+	}
+
+	return clone(lb), response(http.StatusOK), nil
+}
+
+// WaitForStatus returns a load balancer if its status already is status
+func (s *LoadBalancerService) WaitForStatus(ctx context.Context, lbID, status string, _ *govultr.WaitOptions) (*govultr.LoadBalancer, *http.Response, error) { //nolint:lll
+	lb, resp, err := s.Get(ctx, lbID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err = reached("load balancer", lbID, status, lb.Status); err != nil {
+		return nil, nil, err
+	}
+
+	return lb, resp, nil
+}
+
+// Update changes the settings of a load balancer set in updateReq. Like the
+// API, the firewall rules are replaced by those of updateReq even when it has
+// none.
+func (s *LoadBalancerService) Update(_ context.Context, lbID string, updateReq *govultr.LoadBalancerReq) error {
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	lb, ok := f.loadBalancers.get(lbID)
+	if !ok {
+		return notFound("load balancer", lbID)
+	}
+
+	if updateReq == nil {
+		updateReq = &govultr.LoadBalancerReq{}
+	}
+	updateReq = clone(updateReq)
+
+	for _, id := range updateReq.Instances {
+		if _, ok = f.instances.get(id); !ok {
+			return notFound("instance", id)
+		}
+	}
+
+	if updateReq.Label != "" {
+		lb.Label = updateReq.Label
+	}
+	if updateReq.Nodes != 0 {
+		lb.Nodes = updateReq.Nodes
+	}
+	if updateReq.HealthCheck != nil {
+		lb.HealthCheck = updateReq.HealthCheck
+	}
+	lb.FirewallRules = nil
+	s.apply(lb, updateReq)
+	f.record(loadBalancerService, "Update", lbID)
+
+	return nil
+}
+
+// Delete removes a load balancer
+func (s *LoadBalancerService) Delete(_ context.Context, lbID string) error {
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if !f.loadBalancers.remove(lbID) {
+		return notFound("load balancer", lbID)
+	}
+	f.record(loadBalancerService, "Delete", lbID)
+
+	return nil
+}
+
+// DrainInstance takes an instance out of rotation on a load balancer
+func (s *LoadBalancerService) DrainInstance(_ context.Context, lbID, instanceID string, _ *govultr.WaitOptions) error {
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	lb, ok := f.loadBalancers.get(lbID)
+	if !ok {
+		return notFound("load balancer", lbID)
+	}
+
+	if !slices.Contains(lb.Instances, instanceID) {
+		return nil
+	}
+
+	if len(lb.Instances) == 1 {
+		return fmt.Errorf("instance %s is the only instance on load balancer %s", instanceID, lbID)
+	}
+
+	lb.Instances = slices.DeleteFunc(lb.Instances, func(id string) bool { return id == instanceID })
+	f.record(loadBalancerService, "DrainInstance", lbID)
+
+	return nil
+}
+
+// RestoreInstance puts an instance back into rotation on a load balancer
+func (s *LoadBalancerService) RestoreInstance(_ context.Context, lbID, instanceID string, _ *govultr.WaitOptions) error {
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	lb, ok := f.loadBalancers.get(lbID)
+	if !ok {
+		return notFound("load balancer", lbID)
+	}
+
+	if slices.Contains(lb.Instances, instanceID) {
+		return nil
+	}
+
+	if _, ok = f.instances.get(instanceID); !ok {
+		return notFound("instance", instanceID)
+	}
+
+	lb.Instances = append(lb.Instances, instanceID)
+	f.record(loadBalancerService, "RestoreInstance", lbID)
+
+	return nil
+}
+
+// List returns load balancers
+func (s *LoadBalancerService) List(_ context.Context, options *govultr.ListOptions) ([]govultr.LoadBalancer, *govultr.Meta, *http.Response, error) { //nolint:lll
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	lbs, meta := f.loadBalancers.list(options, nil)
+
+	return lbs, meta, response(http.StatusOK), nil
+}
+
+// ListByLabel returns every load balancer with the given label
+func (s *LoadBalancerService) ListByLabel(_ context.Context, label string) ([]govultr.LoadBalancer, error) {
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	lbs, _ := f.loadBalancers.list(nil, func(lb *govultr.LoadBalancer) bool {
+		return lb.Label == label
+	})
+
+	return lbs, nil
+}
+
+// FindByIP returns the load balancer whose IPv4 or IPv6 frontend address is ip
+func (s *LoadBalancerService) FindByIP(_ context.Context, ip string) (*govultr.LoadBalancer, error) {
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return nil, fmt.Errorf("invalid IP address %q", ip)
+	}
+
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for _, id := range f.loadBalancers.ids {
+		lb, _ := f.loadBalancers.get(id)
+		for _, lbIP := range []string{lb.IPV4, lb.IPV6} {
+			if lbAddr, errParse := netip.ParseAddr(lbIP); errParse == nil && lbAddr.Unmap() == addr.Unmap() {
+				return clone(lb), nil
+			}
+		}
+	}
+
+	return nil, fmt.Errorf("no load balancer with IP %s", ip)
+}
+
+// CreateForwardingRule adds a forwarding rule to a load balancer
+func (s *LoadBalancerService) CreateForwardingRule(_ context.Context, lbID string, rule *govultr.ForwardingRule) (*govultr.ForwardingRule, *http.Response, error) { //nolint:lll
+	if rule == nil || rule.FrontendProtocol == "" || rule.FrontendPort == 0 || rule.BackendProtocol == "" || rule.BackendPort == 0 {
+		return nil, nil, apiError(http.StatusBadRequest, "frontend and backend protocols and ports are required")
+	}
+
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	lb, ok := f.loadBalancers.get(lbID)
+	if !ok {
+		return nil, nil, notFound("load balancer", lbID)
+	}
+
+	created := *rule
+	created.RuleID = f.newID("lbrule")
+	lb.ForwardingRules = append(lb.ForwardingRules, created)
+	f.record(loadBalancerService, "CreateForwardingRule", lbID)
+
+	return &created, response(http.StatusCreated), nil
+}
+
+// GetForwardingRule returns a forwarding rule of a load balancer
+func (s *LoadBalancerService) GetForwardingRule(_ context.Context, lbID, ruleID string) (*govultr.ForwardingRule, *http.Response, error) { //nolint:lll
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	lb, ok := f.loadBalancers.get(lbID)
+	if !ok {
+		return nil, nil, notFound("load balancer", lbID)
+	}
+
+	for i := range lb.ForwardingRules {
+		if lb.ForwardingRules[i].RuleID == ruleID {
+			rule := lb.ForwardingRules[i]
+			return &rule, response(http.StatusOK), nil
+		}
+	}
+
+	return nil, nil, notFound("forwarding rule", ruleID)
+}
+
+// DeleteForwardingRule removes a forwarding rule from a load balancer
+func (s *LoadBalancerService) DeleteForwardingRule(_ context.Context, lbID, ruleID string) error {
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	lb, ok := f.loadBalancers.get(lbID)
+	if !ok {
+		return notFound("load balancer", lbID)
+	}
+
+	rules := slices.DeleteFunc(lb.ForwardingRules, func(rule govultr.ForwardingRule) bool { return rule.RuleID == ruleID })
+	if len(rules) == len(lb.ForwardingRules) {
+		return notFound("forwarding rule", ruleID)
+	}
+
+	lb.ForwardingRules = rules
+	f.record(loadBalancerService, "DeleteForwardingRule", lbID)
+
+	return nil
+}
+
+// ListForwardingRules returns the forwarding rules of a load balancer
+func (s *LoadBalancerService) ListForwardingRules(_ context.Context, lbID string, options *govultr.ListOptions) ([]govultr.ForwardingRule, *govultr.Meta, *http.Response, error) { //nolint:lll
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	lb, ok := f.loadBalancers.get(lbID)
+	if !ok {
+		return nil, nil, nil, notFound("load balancer", lbID)
+	}
+
+	rules, meta := paginate(slices.Clone(lb.ForwardingRules), options)
+
+	return rules, meta, response(http.StatusOK), nil
+}
+
+// ListFirewallRules returns the firewall rules of a load balancer
+func (s *LoadBalancerService) ListFirewallRules(_ context.Context, lbID string, options *govultr.ListOptions) ([]govultr.LBFirewallRule, *govultr.Meta, *http.Response, error) { //nolint:lll
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	lb, ok := f.loadBalancers.get(lbID)
+	if !ok {
+		return nil, nil, nil, notFound("load balancer", lbID)
+	}
+
+	rules, meta := paginate(slices.Clone(lb.FirewallRules), options)
+
+	return rules, meta, response(http.StatusOK), nil
+}
+
+// GetFirewallRule returns a firewall rule of a load balancer
+func (s *LoadBalancerService) GetFirewallRule(_ context.Context, lbID, ruleID string) (*govultr.LBFirewallRule, *http.Response, error) { //nolint:lll
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	lb, ok := f.loadBalancers.get(lbID)
+	if !ok {
+		return nil, nil, notFound("load balancer", lbID)
+	}
+
+	for i := range lb.FirewallRules {
+		if lb.FirewallRules[i].RuleID == ruleID {
+			rule := lb.FirewallRules[i]
+			return &rule, response(http.StatusOK), nil
+		}
+	}
+
+	return nil, nil, notFound("firewall rule", ruleID)
+}
+
+// apply copies the instances, rules and generic settings of a request to a
+// load balancer, giving new rules IDs. Callers must hold f.mu.
+func (s *LoadBalancerService) apply(lb *govultr.LoadBalancer, req *govultr.LoadBalancerReq) {
+	f := s.fake
+	if req.Instances != nil {
+		lb.Instances = slices.Clone(req.Instances)
+	}
+	if req.ForwardingRules != nil {
+		lb.ForwardingRules = nil
+		for _, rule := range req.ForwardingRules {
+			rule.RuleID = f.newID("lbrule")
+			lb.ForwardingRules = append(lb.ForwardingRules, rule)
+		}
+	}
+	for _, rule := range req.FirewallRules {
+		rule.RuleID = f.newID("lbfirewall")
+		lb.FirewallRules = append(lb.FirewallRules, rule)
+	}
+
+	if req.BalancingAlgorithm != "" {
+		lb.GenericInfo.BalancingAlgorithm = req.BalancingAlgorithm
+	}
+	if req.SSLRedirect != nil {
+		lb.GenericInfo.SSLRedirect = req.SSLRedirect
+	}
+	if req.ProxyProtocol != nil {
+		lb.GenericInfo.ProxyProtocol = req.ProxyProtocol
+	}
+	if req.StickySessions != nil {
+		lb.GenericInfo.StickySessions = req.StickySessions
+	}
+	if req.VPC != nil {
+		lb.GenericInfo.VPC = *req.VPC
+	}
+	if req.SSL != nil {
+		hasSSL := true
+		lb.SSLInfo = &hasSSL
+	}
+}
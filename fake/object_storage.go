@@ -0,0 +1,183 @@
+package fake
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/vultr/govultr/v3"
+)
+
+const objectStorageService = "ObjectStorage"
+
+// ObjectStorageService is an in-memory govultr.ObjectStorageService. Create,
+// Get, Update, Delete, List, ListCluster, ClusterForRegion and RegenerateKeys
+// are kept in memory, other methods fall through to the embedded service and
+// fail. Clusters are added with Fake.AddObjectStorageCluster.
+type ObjectStorageService struct {
+	govultr.ObjectStorageService
+	fake *Fake
+}
+
+// AddObjectStorageCluster adds a cluster object storage can be created on
+func (f *Fake) AddObjectStorageCluster(cluster govultr.ObjectStorageCluster) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.objectStorageClusters.add(strconv.Itoa(cluster.ID), &cluster)
+}
+
+// Create adds an active object storage subscription on a cluster
+func (s *ObjectStorageService) Create(_ context.Context, clusterID int, label string) (*govultr.ObjectStorage, *http.Response, error) { //nolint:lll
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	cluster, ok := f.objectStorageClusters.get(strconv.Itoa(clusterID))
+	if !ok {
+		return nil, nil, notFound("object storage cluster", strconv.Itoa(clusterID))
+	}
+
+	id := f.newID("objectstorage")
+	storage := &govultr.ObjectStorage{
+		ID:                   id,
+		DateCreated:          f.now(),
+		ObjectStoreClusterID: cluster.ID,
+		Region:               cluster.Region,
+		Label:                label,
+		Status:               "active",
+		S3Keys:               f.newS3Keys(cluster.Hostname),
+	}
+	f.objectStorages.add(id, storage)
+	f.record(objectStorageService, "Create", id)
+
+	return clone(storage), response(http.StatusCreated), nil
+}
+
+// Get returns an object storage subscription
+func (s *ObjectStorageService) Get(_ context.Context, id string) (*govultr.ObjectStorage, *http.Response, error) {
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	storage, ok := f.objectStorages.get(id)
+	if !ok {
+		return nil, nil, notFound("object storage", id)
+	}
+
+	return clone(storage), response(http.StatusOK), nil
+}
+
+// Update changes the label of an object storage subscription
+func (s *ObjectStorageService) Update(_ context.Context, id, label string) error {
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	storage, ok := f.objectStorages.get(id)
+	if !ok {
+		return notFound("object storage", id)
+	}
+
+	storage.Label = label
+	f.record(objectStorageService, "Update", id)
+
+	return nil
+}
+
+// Delete removes an object storage subscription
+func (s *ObjectStorageService) Delete(_ context.Context, id string) error {
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if !f.objectStorages.remove(id) {
+		return notFound("object storage", id)
+	}
+	f.record(objectStorageService, "Delete", id)
+
+	return nil
+}
+
+// List returns object storage subscriptions, filtered by the label of options
+func (s *ObjectStorageService) List(_ context.Context, options *govultr.ListOptions) ([]govultr.ObjectStorage, *govultr.Meta, *http.Response, error) { //nolint:lll
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	storages, meta := f.objectStorages.list(options, func(storage *govultr.ObjectStorage) bool {
+		return options == nil || options.Label == "" || storage.Label == options.Label
+	})
+
+	return storages, meta, response(http.StatusOK), nil
+}
+
+// ListCluster returns the clusters added with Fake.AddObjectStorageCluster
+func (s *ObjectStorageService) ListCluster(_ context.Context, options *govultr.ListOptions) ([]govultr.ObjectStorageCluster, *govultr.Meta, *http.Response, error) { //nolint:lll
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	clusters, meta := f.objectStorageClusters.list(options, nil)
+
+	return clusters, meta, response(http.StatusOK), nil
+}
+
+// ClusterForRegion returns the cluster in a region, preferring one that
+// accepts new subscriptions
+func (s *ObjectStorageService) ClusterForRegion(_ context.Context, region string) (*govultr.ObjectStorageCluster, error) {
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var found *govultr.ObjectStorageCluster
+	for _, id := range f.objectStorageClusters.ids {
+		cluster, _ := f.objectStorageClusters.get(id)
+		if !strings.EqualFold(cluster.Region, region) {
+			continue
+		}
+		if cluster.Deploy == "yes" {
+			return clone(cluster), nil
+		}
+		if found == nil {
+			found = clone(cluster)
+		}
+	}
+
+	if found == nil {
+		return nil, fmt.Errorf("no object storage cluster in region %s", region)
+	}
+
+	return found, nil
+}
+
+// RegenerateKeys replaces the S3 keys of an object storage subscription
+func (s *ObjectStorageService) RegenerateKeys(_ context.Context, id string) (*govultr.S3Keys, *http.Response, error) {
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	storage, ok := f.objectStorages.get(id)
+	if !ok {
+		return nil, nil, notFound("object storage", id)
+	}
+
+	storage.S3Keys = f.newS3Keys(storage.S3Hostname)
+	f.record(objectStorageService, "RegenerateKeys", id)
+
+	keys := storage.S3Keys
+	return &keys, response(http.StatusCreated), nil
+}
+
+// newS3Keys returns keys that differ every time they are made. Callers must
+// hold f.mu.
+func (f *Fake) newS3Keys(hostname string) govultr.S3Keys {
+	key := f.newID("s3key")
+	return govultr.S3Keys{
+		S3Hostname:  hostname,
+		S3AccessKey: strings.ToUpper(strings.ReplaceAll(key, "-", "")),
+		S3SecretKey: "secret-" + key,
+	}
+}
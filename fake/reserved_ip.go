@@ -0,0 +1,213 @@
+package fake
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/vultr/govultr/v3"
+)
+
+const reservedIPService = "ReservedIP"
+
+// ReservedIPService is an in-memory govultr.ReservedIPService. Create, Get,
+// Update, Delete, DeleteGuarded, List, Convert, Attach and Detach are kept in
+// memory, other methods fall through to the embedded service and fail.
+type ReservedIPService struct {
+	govultr.ReservedIPService
+	fake *Fake
+}
+
+// Create reserves an address from a documentation range, a /32 for "v4" and a
+// /64 for "v6"
+func (s *ReservedIPService) Create(_ context.Context, ripCreate *govultr.ReservedIPReq) (*govultr.ReservedIP, *http.Response, error) { //nolint:lll
+	if ripCreate == nil || ripCreate.Region == "" || (ripCreate.IPType != "v4" && ripCreate.IPType != "v6") {
+		return nil, nil, apiError(http.StatusBadRequest, "region and an ip_type of v4 or v6 are required")
+	}
+
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if ripCreate.InstanceID != "" {
+		if _, ok := f.instances.get(ripCreate.InstanceID); !ok {
+			return nil, nil, notFound("instance", ripCreate.InstanceID)
+		}
+	}
+
+	id := f.newID("reservedip")
+	rip := &govultr.ReservedIP{
+		ID:         id,
+		Region:     ripCreate.Region,
+		IPType:     ripCreate.IPType,
+		Label:      ripCreate.Label,
+		InstanceID: ripCreate.InstanceID,
+	}
+	if ripCreate.IPType == "v4" {
+		rip.Subnet, rip.SubnetSize = fmt.Sprintf("203.0.113.%d", f.counters["reservedip"]%254+1), 32
+	} else {
+		rip.Subnet, rip.SubnetSize = fmt.Sprintf("2001:db8:ffff:%x::", f.counters["reservedip"]), 64
+	}
+	f.reservedIPs.add(id, rip)
+	f.record(reservedIPService, "Create", id)
+
+	return clone(rip), response(http.StatusCreated), nil
+}
+
+// Update changes the label of a reserved IP
+func (s *ReservedIPService) Update(_ context.Context, id string, ripUpdate *govultr.ReservedIPUpdateReq) (*govultr.ReservedIP, *http.Response, error) { //nolint:lll
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	rip, ok := f.reservedIPs.get(id)
+	if !ok {
+		return nil, nil, notFound("reserved ip", id)
+	}
+
+	if ripUpdate != nil && ripUpdate.Label != nil {
+		rip.Label = *ripUpdate.Label
+	}
+	f.record(reservedIPService, "Update", id)
+
+	return clone(rip), response(http.StatusAccepted), nil
+}
+
+// Get returns a reserved IP
+func (s *ReservedIPService) Get(_ context.Context, id string) (*govultr.ReservedIP, *http.Response, error) {
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	rip, ok := f.reservedIPs.get(id)
+	if !ok {
+		return nil, nil, notFound("reserved ip", id)
+	}
+
+	return clone(rip), response(http.StatusOK), nil
+}
+
+// Delete removes a reserved IP, attached or not
+func (s *ReservedIPService) Delete(_ context.Context, id string) error {
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if !f.reservedIPs.remove(id) {
+		return notFound("reserved ip", id)
+	}
+	f.record(reservedIPService, "Delete", id)
+
+	return nil
+}
+
+// DeleteGuarded removes a reserved IP, returning a *govultr.ReservedIPAttachedError
+// when it is attached and Force is not set
+func (s *ReservedIPService) DeleteGuarded(ctx context.Context, id string, options *govultr.ReservedIPDeleteOptions) error {
+	if options == nil || !options.Force {
+		rip, _, err := s.Get(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if rip.InstanceID != "" {
+			return &govultr.ReservedIPAttachedError{ID: rip.ID, Subnet: rip.Subnet, InstanceID: rip.InstanceID}
+		}
+	}
+
+	return s.Delete(ctx, id)
+}
+
+// List returns reserved IPs
+func (s *ReservedIPService) List(_ context.Context, options *govultr.ListOptions) ([]govultr.ReservedIP, *govultr.Meta, *http.Response, error) { //nolint:lll
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	rips, meta := f.reservedIPs.list(options, nil)
+
+	return rips, meta, response(http.StatusOK), nil
+}
+
+// Convert reserves the main IP of an instance, leaving it attached
+func (s *ReservedIPService) Convert(_ context.Context, ripConvert *govultr.ReservedIPConvertReq) (*govultr.ReservedIP, *http.Response, error) { //nolint:lll
+	if ripConvert == nil || ripConvert.IPAddress == "" {
+		return nil, nil, apiError(http.StatusBadRequest, "ip_address is required")
+	}
+
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var instance *govultr.Instance
+	for _, id := range f.instances.ids {
+		if candidate, _ := f.instances.get(id); candidate.MainIP == ripConvert.IPAddress {
+			instance = candidate
+			break
+		}
+	}
+	if instance == nil {
+		return nil, nil, apiError(http.StatusNotFound, "no instance has the address %s", ripConvert.IPAddress)
+	}
+
+	id := f.newID("reservedip")
+	rip := &govultr.ReservedIP{
+		ID:         id,
+		Region:     instance.Region,
+		IPType:     "v4",
+		Subnet:     instance.MainIP,
+		SubnetSize: 32,
+		Label:      ripConvert.Label,
+		InstanceID: instance.ID,
+	}
+	f.reservedIPs.add(id, rip)
+	f.record(reservedIPService, "Convert", id)
+
+	return clone(rip), response(http.StatusCreated), nil
+}
+
+// Attach attaches a reserved IP to an existing instance
+func (s *ReservedIPService) Attach(_ context.Context, id, instance string) error {
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	rip, ok := f.reservedIPs.get(id)
+	if !ok {
+		return notFound("reserved ip", id)
+	}
+
+	if _, ok = f.instances.get(instance); !ok {
+		return notFound("instance", instance)
+	}
+
+	if rip.InstanceID != "" {
+		return apiError(http.StatusBadRequest, "reserved ip %s is already attached to %s", id, rip.InstanceID)
+	}
+
+	rip.InstanceID = instance
+	f.record(reservedIPService, "Attach", id)
+
+	return nil
+}
+
+// Detach detaches a reserved IP from its instance
+func (s *ReservedIPService) Detach(_ context.Context, id string) error {
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	rip, ok := f.reservedIPs.get(id)
+	if !ok {
+		return notFound("reserved ip", id)
+	}
+
+	if rip.InstanceID == "" {
+		return apiError(http.StatusBadRequest, "reserved ip %s is not attached", id)
+	}
+
+	rip.InstanceID = ""
+	f.record(reservedIPService, "Detach", id)
+
+	return nil
+}
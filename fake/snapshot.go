@@ -0,0 +1,162 @@
+package fake
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/vultr/govultr/v3"
+)
+
+const snapshotService = "Snapshot"
+
+// SnapshotService is an in-memory govultr.SnapshotService. Create,
+// CreateFromURL, CreateWithMetadata, Get, Delete, List and WaitForStatus are
+// kept in memory, other methods fall through to the embedded service and fail.
+type SnapshotService struct {
+	govultr.SnapshotService
+	fake *Fake
+}
+
+// Create adds a complete snapshot of an existing instance
+func (s *SnapshotService) Create(_ context.Context, snapshotReq *govultr.SnapshotReq) (*govultr.Snapshot, *http.Response, error) { //nolint:lll
+	if snapshotReq == nil || snapshotReq.InstanceID == "" {
+		return nil, nil, apiError(http.StatusBadRequest, "instance_id is required")
+	}
+
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	instance, ok := f.instances.get(snapshotReq.InstanceID)
+	if !ok {
+		return nil, nil, notFound("instance", snapshotReq.InstanceID)
+	}
+
+	snapshot := s.add(snapshotReq.Description, instance.OsID, instance.AppID)
+	f.record(snapshotService, "Create", snapshot.ID)
+
+	return clone(snapshot), response(http.StatusCreated), nil
+}
+
+// CreateFromURL adds a complete snapshot of a raw image
+func (s *SnapshotService) CreateFromURL(_ context.Context, snapshotURLReq *govultr.SnapshotURLReq) (*govultr.Snapshot, *http.Response, error) { //nolint:lll
+	if snapshotURLReq == nil || snapshotURLReq.URL == "" {
+		return nil, nil, apiError(http.StatusBadRequest, "url is required")
+	}
+
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	snapshot := s.add(snapshotURLReq.Description, 0, 0)
+	f.record(snapshotService, "CreateFromURL", snapshot.ID)
+
+	return clone(snapshot), response(http.StatusCreated), nil
+}
+
+// CreateWithMetadata snapshots an instance with metadata as its description,
+// returning the existing snapshot with the same content hash if there is one
+func (s *SnapshotService) CreateWithMetadata(ctx context.Context, instanceID string, metadata *govultr.SnapshotMetadata) (*govultr.Snapshot, *http.Response, error) { //nolint:lll
+	if metadata.ContentHash != "" {
+		if snapshot := s.findContentHash(metadata.ContentHash); snapshot != nil {
+			return snapshot, nil, nil
+		}
+	}
+
+	description, err := json.Marshal(metadata)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return s.Create(ctx, &govultr.SnapshotReq{InstanceID: instanceID, Description: string(description)})
+}
+
+// Get returns a snapshot
+func (s *SnapshotService) Get(_ context.Context, snapshotID string) (*govultr.Snapshot, *http.Response, error) {
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	snapshot, ok := f.snapshots.get(snapshotID)
+	if !ok {
+		return nil, nil, notFound("snapshot", snapshotID)
+	}
+
+	return clone(snapshot), response(http.StatusOK), nil
+}
+
+// WaitForStatus returns a snapshot if its status already is status
+func (s *SnapshotService) WaitForStatus(ctx context.Context, snapshotID, status string, _ *govultr.WaitOptions) (*govultr.Snapshot, *http.Response, error) { //nolint:lll
+	snapshot, resp, err := s.Get(ctx, snapshotID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err = reached("snapshot", snapshotID, status, snapshot.Status); err != nil {
+		return nil, nil, err
+	}
+
+	return snapshot, resp, nil
+}
+
+// Delete removes a snapshot
+func (s *SnapshotService) Delete(_ context.Context, snapshotID string) error {
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if !f.snapshots.remove(snapshotID) {
+		return notFound("snapshot", snapshotID)
+	}
+	f.record(snapshotService, "Delete", snapshotID)
+
+	return nil
+}
+
+// List returns snapshots, filtered by the description of options like the API does
+func (s *SnapshotService) List(_ context.Context, options *govultr.ListOptions) ([]govultr.Snapshot, *govultr.Meta, *http.Response, error) { //nolint:lll
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	snapshots, meta := f.snapshots.list(options, func(snapshot *govultr.Snapshot) bool {
+		return options == nil || options.Description == "" || snapshot.Description == options.Description
+	})
+
+	return snapshots, meta, response(http.StatusOK), nil
+}
+
+// add stores a new complete snapshot. Callers must hold f.mu.
+func (s *SnapshotService) add(description string, osID, appID int) *govultr.Snapshot {
+	f := s.fake
+	id := f.newID("snapshot")
+	snapshot := &govultr.Snapshot{
+		ID:             id,
+		DateCreated:    f.now(),
+		Description:    description,
+		Size:           25 << 30,
+		CompressedSize: 5 << 30,
+		Status:         "complete",
+		OsID:           osID,
+		AppID:          appID,
+	}
+	f.snapshots.add(id, snapshot)
+
+	return snapshot
+}
+
+func (s *SnapshotService) findContentHash(contentHash string) *govultr.Snapshot {
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for _, id := range f.snapshots.ids {
+		snapshot, _ := f.snapshots.get(id)
+		if metadata, ok := snapshot.Metadata(); ok && metadata.ContentHash == contentHash {
+			return clone(snapshot)
+		}
+	}
+
+	return nil
+}
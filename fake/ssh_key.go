@@ -0,0 +1,97 @@
+package fake
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/vultr/govultr/v3"
+)
+
+const sshKeyService = "SSHKey"
+
+// SSHKeyService is an in-memory govultr.SSHKeyService
+type SSHKeyService struct {
+	govultr.SSHKeyService
+	fake *Fake
+}
+
+// Create adds an SSH key
+func (s *SSHKeyService) Create(_ context.Context, sshKeyReq *govultr.SSHKeyReq) (*govultr.SSHKey, *http.Response, error) {
+	if sshKeyReq == nil || sshKeyReq.Name == "" || sshKeyReq.SSHKey == "" {
+		return nil, nil, apiError(http.StatusBadRequest, "name and ssh_key are required")
+	}
+
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	id := f.newID("sshkey")
+	key := &govultr.SSHKey{ID: id, Name: sshKeyReq.Name, SSHKey: sshKeyReq.SSHKey, DateCreated: f.now()}
+	f.sshKeys.add(id, key)
+	f.record(sshKeyService, "Create", id)
+
+	return clone(key), response(http.StatusCreated), nil
+}
+
+// Get returns an SSH key
+func (s *SSHKeyService) Get(_ context.Context, sshKeyID string) (*govultr.SSHKey, *http.Response, error) {
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	key, ok := f.sshKeys.get(sshKeyID)
+	if !ok {
+		return nil, nil, notFound("ssh key", sshKeyID)
+	}
+
+	return clone(key), response(http.StatusOK), nil
+}
+
+// Update changes the name and key of an SSH key
+func (s *SSHKeyService) Update(_ context.Context, sshKeyID string, sshKeyReq *govultr.SSHKeyReq) error {
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	key, ok := f.sshKeys.get(sshKeyID)
+	if !ok {
+		return notFound("ssh key", sshKeyID)
+	}
+
+	if sshKeyReq != nil {
+		if sshKeyReq.Name != "" {
+			key.Name = sshKeyReq.Name
+		}
+		if sshKeyReq.SSHKey != "" {
+			key.SSHKey = sshKeyReq.SSHKey
+		}
+	}
+	f.record(sshKeyService, "Update", sshKeyID)
+
+	return nil
+}
+
+// Delete removes an SSH key
+func (s *SSHKeyService) Delete(_ context.Context, sshKeyID string) error {
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if !f.sshKeys.remove(sshKeyID) {
+		return notFound("ssh key", sshKeyID)
+	}
+	f.record(sshKeyService, "Delete", sshKeyID)
+
+	return nil
+}
+
+// List returns SSH keys
+func (s *SSHKeyService) List(_ context.Context, options *govultr.ListOptions) ([]govultr.SSHKey, *govultr.Meta, *http.Response, error) {
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	keys, meta := f.sshKeys.list(options, nil)
+
+	return keys, meta, response(http.StatusOK), nil
+}
@@ -0,0 +1,113 @@
+package fake
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/vultr/govultr/v3"
+)
+
+const startupScriptService = "StartupScript"
+
+// StartupScriptService is an in-memory govultr.StartupScriptService. Create,
+// Get, Update, Delete and List are kept in memory, other methods fall through
+// to the embedded service and fail.
+type StartupScriptService struct {
+	govultr.StartupScriptService
+	fake *Fake
+}
+
+// Create adds a startup script, a "boot" script unless req sets the type
+func (s *StartupScriptService) Create(_ context.Context, req *govultr.StartupScriptReq) (*govultr.StartupScript, *http.Response, error) { //nolint:lll
+	if req == nil || req.Name == "" || req.Script == "" {
+		return nil, nil, apiError(http.StatusBadRequest, "name and script are required")
+	}
+
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	id := f.newID("script")
+	script := &govultr.StartupScript{
+		ID:           id,
+		DateCreated:  f.now(),
+		DateModified: f.now(),
+		Name:         req.Name,
+		Type:         req.Type,
+		Script:       req.Script,
+	}
+	if script.Type == "" {
+		script.Type = "boot"
+	}
+	f.scripts.add(id, script)
+	f.record(startupScriptService, "Create", id)
+
+	return clone(script), response(http.StatusCreated), nil
+}
+
+// Get returns a startup script
+func (s *StartupScriptService) Get(_ context.Context, scriptID string) (*govultr.StartupScript, *http.Response, error) {
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	script, ok := f.scripts.get(scriptID)
+	if !ok {
+		return nil, nil, notFound("startup script", scriptID)
+	}
+
+	return clone(script), response(http.StatusOK), nil
+}
+
+// Update changes the name, type and contents of a startup script
+func (s *StartupScriptService) Update(_ context.Context, scriptID string, scriptReq *govultr.StartupScriptReq) error {
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	script, ok := f.scripts.get(scriptID)
+	if !ok {
+		return notFound("startup script", scriptID)
+	}
+
+	if scriptReq != nil {
+		if scriptReq.Name != "" {
+			script.Name = scriptReq.Name
+		}
+		if scriptReq.Type != "" {
+			script.Type = scriptReq.Type
+		}
+		if scriptReq.Script != "" {
+			script.Script = scriptReq.Script
+		}
+	}
+	script.DateModified = f.now()
+	f.record(startupScriptService, "Update", scriptID)
+
+	return nil
+}
+
+// Delete removes a startup script
+func (s *StartupScriptService) Delete(_ context.Context, scriptID string) error {
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if !f.scripts.remove(scriptID) {
+		return notFound("startup script", scriptID)
+	}
+	f.record(startupScriptService, "Delete", scriptID)
+
+	return nil
+}
+
+// List returns startup scripts
+func (s *StartupScriptService) List(_ context.Context, options *govultr.ListOptions) ([]govultr.StartupScript, *govultr.Meta, *http.Response, error) { //nolint:lll
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	scripts, meta := f.scripts.list(options, nil)
+
+	return scripts, meta, response(http.StatusOK), nil
+}
@@ -0,0 +1,149 @@
+package fake
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/vultr/govultr/v3"
+)
+
+const userService = "User"
+
+// UserService is an in-memory govultr.UserService. Create, Get, Update,
+// Delete, List and ListAPIEnabled are kept in memory, other methods fall
+// through to the embedded service and fail.
+type UserService struct {
+	govultr.UserService
+	fake *Fake
+}
+
+// Create adds a user with API access unless userCreate disables it. Emails
+// must be unique.
+func (s *UserService) Create(_ context.Context, userCreate *govultr.UserReq) (*govultr.User, *http.Response, error) {
+	if userCreate == nil || userCreate.Email == "" || userCreate.Name == "" || userCreate.Password == "" {
+		return nil, nil, apiError(http.StatusBadRequest, "email, name and password are required")
+	}
+
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for _, id := range f.users.ids {
+		if user, _ := f.users.get(id); strings.EqualFold(user.Email, userCreate.Email) {
+			return nil, nil, apiError(http.StatusBadRequest, "email %s is already in use", userCreate.Email)
+		}
+	}
+
+	id := f.newID("user")
+	apiEnabled := userCreate.APIEnabled == nil || *userCreate.APIEnabled
+	user := &govultr.User{
+		ID:         id,
+		Name:       userCreate.Name,
+		Email:      userCreate.Email,
+		APIEnabled: &apiEnabled,
+		ACL:        slices.Clone(userCreate.ACL),
+	}
+	if apiEnabled {
+		user.APIKey = fmt.Sprintf("FAKE%s", strings.ToUpper(strings.ReplaceAll(id, "-", "")))
+	}
+	f.users.add(id, user)
+	f.record(userService, "Create", id)
+
+	return clone(user), response(http.StatusCreated), nil
+}
+
+// Get returns a user without its API key, like the API
+func (s *UserService) Get(_ context.Context, userID string) (*govultr.User, *http.Response, error) {
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	user, ok := f.users.get(userID)
+	if !ok {
+		return nil, nil, notFound("user", userID)
+	}
+
+	user = clone(user)
+	user.APIKey = ""
+
+	return user, response(http.StatusOK), nil
+}
+
+// Update changes the email, name, API access and ACLs of a user. ACLs are left
+// alone when nil.
+func (s *UserService) Update(_ context.Context, userID string, userReq *govultr.UserReq) error {
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	user, ok := f.users.get(userID)
+	if !ok {
+		return notFound("user", userID)
+	}
+
+	if userReq != nil {
+		if userReq.Email != "" {
+			user.Email = userReq.Email
+		}
+		if userReq.Name != "" {
+			user.Name = userReq.Name
+		}
+		if userReq.APIEnabled != nil {
+			apiEnabled := *userReq.APIEnabled
+			user.APIEnabled = &apiEnabled
+		}
+		if userReq.ACL != nil {
+			user.ACL = slices.Clone(userReq.ACL)
+		}
+	}
+	f.record(userService, "Update", userID)
+
+	return nil
+}
+
+// Delete removes a user
+func (s *UserService) Delete(_ context.Context, userID string) error {
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if !f.users.remove(userID) {
+		return notFound("user", userID)
+	}
+	f.record(userService, "Delete", userID)
+
+	return nil
+}
+
+// List returns users without their API keys
+func (s *UserService) List(_ context.Context, options *govultr.ListOptions) ([]govultr.User, *govultr.Meta, *http.Response, error) {
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	users, meta := f.users.list(options, nil)
+	for i := range users {
+		users[i].APIKey = ""
+	}
+
+	return users, meta, response(http.StatusOK), nil
+}
+
+// ListAPIEnabled returns every user with API access
+func (s *UserService) ListAPIEnabled(_ context.Context) ([]govultr.User, error) {
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	users, _ := f.users.list(nil, func(user *govultr.User) bool {
+		return user.APIEnabled != nil && *user.APIEnabled
+	})
+	for i := range users {
+		users[i].APIKey = ""
+	}
+
+	return users, nil
+}
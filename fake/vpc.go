@@ -0,0 +1,223 @@
+package fake
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/vultr/govultr/v3"
+)
+
+const (
+	vpcService     = "VPC"
+	networkService = "Network"
+)
+
+// VPCService is an in-memory govultr.VPCService. Create, CreateAndWait, Get,
+// Update, UpdateAndGet, Delete and List are kept in memory, other methods fall
+// through to the embedded service and fail.
+type VPCService struct {
+	govultr.VPCService
+	fake *Fake
+}
+
+// Create adds a VPC, choosing a /24 subnet when createReq has none
+func (s *VPCService) Create(_ context.Context, createReq *govultr.VPCReq) (*govultr.VPC, *http.Response, error) {
+	if createReq == nil || createReq.Region == "" {
+		return nil, nil, apiError(http.StatusBadRequest, "region is required")
+	}
+
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	vpc := f.addVPC(createReq)
+	f.record(vpcService, "Create", vpc.ID)
+
+	return clone(vpc), response(http.StatusCreated), nil
+}
+
+// CreateAndWait adds a VPC, which has its subnet immediately
+func (s *VPCService) CreateAndWait(ctx context.Context, createReq *govultr.VPCReq, _ *govultr.WaitOptions) (*govultr.VPC, *http.Response, error) { //nolint:lll
+	return s.Create(ctx, createReq)
+}
+
+// Get returns a VPC
+func (s *VPCService) Get(_ context.Context, vpcID string) (*govultr.VPC, *http.Response, error) {
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	vpc, ok := f.vpcs.get(vpcID)
+	if !ok {
+		return nil, nil, notFound("vpc", vpcID)
+	}
+
+	return clone(vpc), response(http.StatusOK), nil
+}
+
+// Update changes the description of a VPC
+func (s *VPCService) Update(_ context.Context, vpcID, description string) error {
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.describeVPC(vpcService, vpcID, description)
+}
+
+// UpdateAndGet changes the description of a VPC and returns it
+func (s *VPCService) UpdateAndGet(ctx context.Context, vpcID string, updateReq *govultr.VPCUpdateReq) (*govultr.VPC, *http.Response, error) { //nolint:lll
+	description := ""
+	if updateReq != nil {
+		description = updateReq.Description
+	}
+
+	if err := s.Update(ctx, vpcID, description); err != nil {
+		return nil, nil, err
+	}
+
+	return s.Get(ctx, vpcID)
+}
+
+// Delete removes a VPC
+func (s *VPCService) Delete(_ context.Context, vpcID string) error {
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if !f.vpcs.remove(vpcID) {
+		return notFound("vpc", vpcID)
+	}
+	f.record(vpcService, "Delete", vpcID)
+
+	return nil
+}
+
+// List returns VPCs
+func (s *VPCService) List(_ context.Context, options *govultr.ListOptions) ([]govultr.VPC, *govultr.Meta, *http.Response, error) {
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	vpcs, meta := f.vpcs.list(options, nil)
+
+	return vpcs, meta, response(http.StatusOK), nil
+}
+
+// NetworkService is an in-memory govultr.NetworkService. Networks are the VPCs
+// of the fake, as they are for the API.
+type NetworkService struct {
+	govultr.NetworkService
+	fake *Fake
+}
+
+// Create adds a VPC
+func (s *NetworkService) Create(_ context.Context, createReq *govultr.NetworkReq) (*govultr.Network, *http.Response, error) { //nolint:lll
+	if createReq == nil || createReq.Region == "" {
+		return nil, nil, apiError(http.StatusBadRequest, "region is required")
+	}
+
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	vpc := f.addVPC((*govultr.VPCReq)(createReq))
+	f.record(networkService, "Create", vpc.ID)
+
+	return toNetwork(vpc), response(http.StatusCreated), nil
+}
+
+// Get returns a VPC as a network
+func (s *NetworkService) Get(_ context.Context, networkID string) (*govultr.Network, *http.Response, error) {
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	vpc, ok := f.vpcs.get(networkID)
+	if !ok {
+		return nil, nil, notFound("network", networkID)
+	}
+
+	return toNetwork(vpc), response(http.StatusOK), nil
+}
+
+// Update changes the description of a VPC
+func (s *NetworkService) Update(_ context.Context, networkID, description string) error {
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.describeVPC(networkService, networkID, description)
+}
+
+// Delete removes a VPC
+func (s *NetworkService) Delete(_ context.Context, networkID string) error {
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if !f.vpcs.remove(networkID) {
+		return notFound("network", networkID)
+	}
+	f.record(networkService, "Delete", networkID)
+
+	return nil
+}
+
+// List returns VPCs as networks
+func (s *NetworkService) List(_ context.Context, options *govultr.ListOptions) ([]govultr.Network, *govultr.Meta, *http.Response, error) { //nolint:lll
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	vpcs, meta := f.vpcs.list(options, nil)
+	networks := make([]govultr.Network, 0, len(vpcs))
+	for i := range vpcs {
+		networks = append(networks, *toNetwork(&vpcs[i]))
+	}
+
+	return networks, meta, response(http.StatusOK), nil
+}
+
+// addVPC stores a new VPC. Callers must hold f.mu.
+func (f *Fake) addVPC(createReq *govultr.VPCReq) *govultr.VPC {
+	id := f.newID("vpc")
+	vpc := &govultr.VPC{
+		ID:           id,
+		Region:       createReq.Region,
+		Description:  createReq.Description,
+		V4Subnet:     createReq.V4Subnet,
+		V4SubnetMask: createReq.V4SubnetMask,
+		DateCreated:  f.now(),
+	}
+	if vpc.V4Subnet == "" {
+		vpc.V4Subnet, vpc.V4SubnetMask = fmt.Sprintf("10.%d.%d.0", f.counters["vpc"]/256%256, f.counters["vpc"]%256), 24
+	}
+	f.vpcs.add(id, vpc)
+
+	return vpc
+}
+
+// describeVPC changes the description of a VPC. Callers must hold f.mu.
+func (f *Fake) describeVPC(service, vpcID, description string) error {
+	vpc, ok := f.vpcs.get(vpcID)
+	if !ok {
+		return notFound("vpc", vpcID)
+	}
+
+	vpc.Description = description
+	f.record(service, "Update", vpcID)
+
+	return nil
+}
+
+func toNetwork(vpc *govultr.VPC) *govultr.Network {
+	return &govultr.Network{
+		NetworkID:    vpc.ID,
+		Region:       vpc.Region,
+		Description:  vpc.Description,
+		V4Subnet:     vpc.V4Subnet,
+		V4SubnetMask: vpc.V4SubnetMask,
+		DateCreated:  vpc.DateCreated,
+	}
+}
@@ -0,0 +1,199 @@
+package fake
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/netip"
+	"slices"
+
+	"github.com/vultr/govultr/v3"
+)
+
+const vpc2Service = "VPC2"
+
+// VPC2Service is an in-memory govultr.VPC2Service. Nodes are the instances of
+// the fake.
+type VPC2Service struct {
+	govultr.VPC2Service
+	fake *Fake
+}
+
+// Create adds a VPC 2.0 network, choosing a /24 block when createReq has none
+func (s *VPC2Service) Create(_ context.Context, createReq *govultr.VPC2Req) (*govultr.VPC2, *http.Response, error) {
+	if createReq == nil || createReq.Region == "" {
+		return nil, nil, apiError(http.StatusBadRequest, "region is required")
+	}
+
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	id := f.newID("vpc2")
+	vpc := &govultr.VPC2{
+		ID:           id,
+		Region:       createReq.Region,
+		Description:  createReq.Description,
+		IPBlock:      createReq.IPBlock,
+		PrefixLength: createReq.PrefixLength,
+		DateCreated:  f.now(),
+	}
+	if vpc.IPBlock == "" {
+		vpc.IPBlock, vpc.PrefixLength = fmt.Sprintf("10.%d.%d.0", 128+f.counters["vpc2"]/256%128, f.counters["vpc2"]%256), 24
+	}
+	f.vpc2s.add(id, vpc)
+	f.record(vpc2Service, "Create", id)
+
+	return clone(vpc), response(http.StatusCreated), nil
+}
+
+// Get returns a VPC 2.0 network
+func (s *VPC2Service) Get(_ context.Context, vpcID string) (*govultr.VPC2, *http.Response, error) {
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	vpc, ok := f.vpc2s.get(vpcID)
+	if !ok {
+		return nil, nil, notFound("vpc 2.0", vpcID)
+	}
+
+	return clone(vpc), response(http.StatusOK), nil
+}
+
+// Update changes the description of a VPC 2.0 network
+func (s *VPC2Service) Update(_ context.Context, vpcID, description string) error {
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	vpc, ok := f.vpc2s.get(vpcID)
+	if !ok {
+		return notFound("vpc 2.0", vpcID)
+	}
+
+	vpc.Description = description
+	f.record(vpc2Service, "Update", vpcID)
+
+	return nil
+}
+
+// Delete removes a VPC 2.0 network that has no nodes
+func (s *VPC2Service) Delete(_ context.Context, vpcID string) error {
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if _, ok := f.vpc2s.get(vpcID); !ok {
+		return notFound("vpc 2.0", vpcID)
+	}
+
+	if len(f.vpc2Nodes[vpcID]) > 0 {
+		return apiError(http.StatusBadRequest, "vpc 2.0 %s still has nodes attached", vpcID)
+	}
+
+	f.vpc2s.remove(vpcID)
+	delete(f.vpc2Nodes, vpcID)
+	f.record(vpc2Service, "Delete", vpcID)
+
+	return nil
+}
+
+// List returns VPC 2.0 networks
+func (s *VPC2Service) List(_ context.Context, options *govultr.ListOptions) ([]govultr.VPC2, *govultr.Meta, *http.Response, error) {
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	vpcs, meta := f.vpc2s.list(options, nil)
+
+	return vpcs, meta, response(http.StatusOK), nil
+}
+
+// ListNodes returns the instances attached to a VPC 2.0 network with
+// addresses given out in the order they were attached
+func (s *VPC2Service) ListNodes(_ context.Context, vpc2ID string, options *govultr.ListOptions) ([]govultr.VPC2Node, *govultr.Meta, *http.Response, error) { //nolint:lll
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	vpc, ok := f.vpc2s.get(vpc2ID)
+	if !ok {
+		return nil, nil, nil, notFound("vpc 2.0", vpc2ID)
+	}
+
+	address, _ := netip.ParseAddr(vpc.IPBlock)
+	var nodes []govultr.VPC2Node
+	for _, id := range f.vpc2Nodes[vpc2ID] {
+		address = address.Next()
+		instance, ok := f.instances.get(id)
+		if !ok {
+			continue
+		}
+
+		nodes = append(nodes, govultr.VPC2Node{
+			ID:          id,
+			IPAddress:   address.String(),
+			Description: instance.Label,
+			Type:        "vps",
+			NodeStatus:  "active",
+		})
+	}
+
+	nodes, meta := paginate(nodes, options)
+
+	return nodes, meta, response(http.StatusOK), nil
+}
+
+// Attach attaches existing instances to a VPC 2.0 network, attaching none of
+// them when one does not exist
+func (s *VPC2Service) Attach(_ context.Context, vpcID string, attachReq *govultr.VPC2AttachDetachReq) error {
+	if attachReq == nil || len(attachReq.Nodes) == 0 {
+		return apiError(http.StatusBadRequest, "nodes are required")
+	}
+
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if _, ok := f.vpc2s.get(vpcID); !ok {
+		return notFound("vpc 2.0", vpcID)
+	}
+
+	for _, id := range attachReq.Nodes {
+		if _, ok := f.instances.get(id); !ok {
+			return notFound("instance", id)
+		}
+	}
+
+	for _, id := range attachReq.Nodes {
+		if !slices.Contains(f.vpc2Nodes[vpcID], id) {
+			f.vpc2Nodes[vpcID] = append(f.vpc2Nodes[vpcID], id)
+		}
+	}
+	f.record(vpc2Service, "Attach", vpcID)
+
+	return nil
+}
+
+// Detach detaches instances from a VPC 2.0 network
+func (s *VPC2Service) Detach(_ context.Context, vpcID string, detachReq *govultr.VPC2AttachDetachReq) error {
+	if detachReq == nil || len(detachReq.Nodes) == 0 {
+		return apiError(http.StatusBadRequest, "nodes are required")
+	}
+
+	f := s.fake
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if _, ok := f.vpc2s.get(vpcID); !ok {
+		return notFound("vpc 2.0", vpcID)
+	}
+
+	f.vpc2Nodes[vpcID] = slices.DeleteFunc(f.vpc2Nodes[vpcID], func(id string) bool {
+		return slices.Contains(detachReq.Nodes, id)
+	})
+	f.record(vpc2Service, "Detach", vpcID)
+
+	return nil
+}
@@ -16,6 +16,9 @@ type FirewallGroupService interface { //nolint:dupl
 	Update(ctx context.Context, fwGroupID string, fwGroupReq *FirewallGroupReq) error
 	Delete(ctx context.Context, fwGroupID string) error
 	List(ctx context.Context, options *ListOptions) ([]FirewallGroup, *Meta, *http.Response, error)
+
+	CloneGroup(ctx context.Context, srcGroupID, description string) (*FirewallGroup, error)
+	PropagateRules(ctx context.Context, templateGroupID string, targetGroupIDs []string, options *FirewallSyncOptions) ([]FirewallPropagationResult, error) //nolint:lll
 }
 
 // FireWallGroupServiceHandler handles interaction with the firewall group methods for the Vultr API
@@ -34,6 +37,14 @@ type FirewallGroup struct {
 	MaxRuleCount  int    `json:"max_rule_count"`
 }
 
+// FirewallPropagationResult represents the outcome of syncing the rules of a
+// template group to one target group with PropagateRules
+type FirewallPropagationResult struct {
+	GroupID string
+	Sync    *FirewallSyncResult
+	Error   error
+}
+
 // FirewallGroupReq struct is used to create and update a Firewall Group.
 type FirewallGroupReq struct {
 	Description string `json:"description"`
@@ -133,3 +144,41 @@ func (f *FireWallGroupServiceHandler) List(ctx context.Context, options *ListOpt
 
 	return firewalls.FirewallGroups, firewalls.Meta, resp, nil
 }
+
+// CloneGroup creates a firewall group with the given description and a copy of
+// every rule in the source group
+func (f *FireWallGroupServiceHandler) CloneGroup(ctx context.Context, srcGroupID, description string) (*FirewallGroup, error) {
+	doc, err := f.client.FirewallRule.ExportRuleSet(ctx, srcGroupID)
+	if err != nil {
+		return nil, err
+	}
+
+	group, _, err := f.Create(ctx, &FirewallGroupReq{Description: description})
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err = f.client.FirewallRule.ImportRuleSet(ctx, group.ID, doc, nil); err != nil {
+		return group, err
+	}
+
+	return group, nil
+}
+
+// PropagateRules syncs the rules of a template group to each target group
+// using ImportRuleSet with the given options. A failure on one target is
+// reported in its result and does not stop the others.
+func (f *FireWallGroupServiceHandler) PropagateRules(ctx context.Context, templateGroupID string, targetGroupIDs []string, options *FirewallSyncOptions) ([]FirewallPropagationResult, error) { //nolint:lll
+	doc, err := f.client.FirewallRule.ExportRuleSet(ctx, templateGroupID)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]FirewallPropagationResult, 0, len(targetGroupIDs))
+	for _, groupID := range targetGroupIDs {
+		sync, err := f.client.FirewallRule.ImportRuleSet(ctx, groupID, doc, options)
+		results = append(results, FirewallPropagationResult{GroupID: groupID, Sync: sync, Error: err})
+	}
+
+	return results, nil
+}
@@ -135,3 +135,84 @@ func TestFireWallGroupServiceHandler_List(t *testing.T) {
 		t.Errorf("FirewallGroup.List meta returned %+v, expected %+v", meta, expectedMeta)
 	}
 }
+
+func TestFireWallGroupServiceHandler_PropagateRules(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v2/firewalls/tpl/rules", func(writer http.ResponseWriter, request *http.Request) {
+		response := `{"firewall_rules": [
+			{"id": 1, "ip_type": "v4", "protocol": "tcp", "subnet": "0.0.0.0", "subnet_size": 0, "port": "443"},
+			{"id": 2, "ip_type": "v4", "protocol": "tcp", "subnet": "0.0.0.0", "subnet_size": 0, "port": "80"}
+		], "meta": {"total": 2, "links": {"next": "", "prev": ""}}}`
+		fmt.Fprint(writer, response)
+	})
+
+	created := 0
+	mux.HandleFunc("/v2/firewalls/a/rules", func(writer http.ResponseWriter, request *http.Request) {
+		if request.Method == http.MethodPost {
+			created++
+			fmt.Fprint(writer, `{"firewall_rule": {"id": 10}}`)
+			return
+		}
+		fmt.Fprint(writer, `{"firewall_rules": [{"id": 5, "ip_type": "v4", "protocol": "tcp", "subnet": "0.0.0.0", "subnet_size": 0, "port": "443"}], "meta": {"total": 1, "links": {"next": "", "prev": ""}}}`)
+	})
+
+	mux.HandleFunc("/v2/firewalls/b/rules", func(writer http.ResponseWriter, request *http.Request) {
+		http.Error(writer, `{"error": "firewall group not found"}`, http.StatusNotFound)
+	})
+
+	results, err := client.FirewallGroup.PropagateRules(ctx, "tpl", []string{"a", "b"}, nil)
+	if err != nil {
+		t.Errorf("FirewallGroup.PropagateRules returned %+v", err)
+	}
+
+	if len(results) != 2 {
+		t.Fatalf("FirewallGroup.PropagateRules returned %d results, expected 2", len(results))
+	}
+
+	expected := []FirewallRuleReq{{IPType: "v4", Protocol: "tcp", Subnet: "0.0.0.0", Port: "80"}}
+	if results[0].Error != nil || !reflect.DeepEqual(results[0].Sync.Created, expected) {
+		t.Errorf("FirewallGroup.PropagateRules returned %+v, expected %+v created", results[0], expected)
+	}
+
+	if created != 1 {
+		t.Errorf("FirewallGroup.PropagateRules created %d rules, expected 1", created)
+	}
+
+	if results[1].GroupID != "b" || results[1].Error == nil {
+		t.Errorf("FirewallGroup.PropagateRules returned %+v, expected an error for group b", results[1])
+	}
+}
+
+func TestFireWallGroupServiceHandler_CloneGroup(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v2/firewalls/tpl/rules", func(writer http.ResponseWriter, request *http.Request) {
+		fmt.Fprint(writer, `{"firewall_rules": [{"id": 1, "ip_type": "v4", "protocol": "tcp", "subnet": "0.0.0.0", "subnet_size": 0, "port": "22"}], "meta": {"total": 1, "links": {"next": "", "prev": ""}}}`)
+	})
+
+	mux.HandleFunc("/v2/firewalls", func(writer http.ResponseWriter, request *http.Request) {
+		fmt.Fprint(writer, `{"firewall_group": {"id": "new", "description": "staging"}}`)
+	})
+
+	created := 0
+	mux.HandleFunc("/v2/firewalls/new/rules", func(writer http.ResponseWriter, request *http.Request) {
+		if request.Method == http.MethodPost {
+			created++
+			fmt.Fprint(writer, `{"firewall_rule": {"id": 10}}`)
+			return
+		}
+		fmt.Fprint(writer, `{"firewall_rules": [], "meta": {"total": 0, "links": {"next": "", "prev": ""}}}`)
+	})
+
+	group, err := client.FirewallGroup.CloneGroup(ctx, "tpl", "staging")
+	if err != nil {
+		t.Errorf("FirewallGroup.CloneGroup returned %+v", err)
+	}
+
+	if group.ID != "new" || created != 1 {
+		t.Errorf("FirewallGroup.CloneGroup returned %+v with %d rules created, expected group new with 1", group, created)
+	}
+}
@@ -2,12 +2,21 @@ package govultr
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/netip"
+	"sort"
+	"strconv"
+	"strings"
 
 	"github.com/google/go-querystring/query"
 )
 
+// FirewallRuleSetVersion is the schema version written by ExportRuleSet and
+// the only one accepted by ImportRuleSet
+const FirewallRuleSetVersion = 1
+
 // FireWallRuleService is the interface to interact with the firewall rule endpoints on the Vultr API
 // Link : https://www.vultr.com/api/#tag/firewall
 type FireWallRuleService interface {
@@ -15,6 +24,9 @@ type FireWallRuleService interface {
 	Get(ctx context.Context, fwGroupID string, fwRuleID int) (*FirewallRule, *http.Response, error)
 	Delete(ctx context.Context, fwGroupID string, fwRuleID int) error
 	List(ctx context.Context, fwGroupID string, options *ListOptions) ([]FirewallRule, *Meta, *http.Response, error)
+
+	ExportRuleSet(ctx context.Context, fwGroupID string) ([]byte, error)
+	ImportRuleSet(ctx context.Context, fwGroupID string, doc []byte, options *FirewallSyncOptions) (*FirewallSyncResult, error)
 }
 
 // FireWallRuleServiceHandler handles interaction with the firewall rule methods for the Vultr API
@@ -48,6 +60,34 @@ type FirewallRuleReq struct {
 	Notes      string `json:"notes,omitempty"`
 }
 
+// FirewallRuleSet is a portable document describing every rule in a firewall
+// group. Rules are sorted so exports of the same policy are identical.
+type FirewallRuleSet struct {
+	Version int               `json:"version"`
+	Rules   []FirewallRuleReq `json:"rules"`
+}
+
+// FirewallSyncOptions controls how ImportRuleSet applies a rule set
+type FirewallSyncOptions struct {
+	// Prune deletes rules in the group that are not in the rule set
+	Prune bool
+	// DryRun reports the changes without making them
+	DryRun bool
+}
+
+// FirewallSyncResult represents the changes made, or that would be made, by ImportRuleSet
+type FirewallSyncResult struct {
+	Created []FirewallRuleReq
+	Deleted []FirewallRule
+}
+
+// FirewallPacket describes a hypothetical inbound connection for EvaluateRuleSet
+type FirewallPacket struct {
+	Protocol string
+	Port     int
+	SrcIP    string
+}
+
 type firewallRulesBase struct {
 	FirewallRules []FirewallRule `json:"firewall_rules"`
 	Meta          *Meta          `json:"meta"`
@@ -129,3 +169,177 @@ func (f *FireWallRuleServiceHandler) List(ctx context.Context, fwGroupID string,
 
 	return firewallRule.FirewallRules, firewallRule.Meta, resp, nil
 }
+
+// ExportRuleSet returns the rules of a firewall group as a FirewallRuleSet JSON
+// document. Only JSON is supported, YAML is not.
+func (f *FireWallRuleServiceHandler) ExportRuleSet(ctx context.Context, fwGroupID string) ([]byte, error) {
+	rules, err := f.listAll(ctx, fwGroupID)
+	if err != nil {
+		return nil, err
+	}
+
+	ruleSet := FirewallRuleSet{
+		Version: FirewallRuleSetVersion,
+		Rules:   make([]FirewallRuleReq, 0, len(rules)),
+	}
+	for i := range rules {
+		ruleSet.Rules = append(ruleSet.Rules, rules[i].toReq())
+	}
+
+	sort.Slice(ruleSet.Rules, func(i, j int) bool {
+		return ruleSet.Rules[i].key() < ruleSet.Rules[j].key()
+	})
+
+	return json.MarshalIndent(ruleSet, "", "  ")
+}
+
+// ImportRuleSet creates the rules in a FirewallRuleSet JSON document that are
+// missing from a firewall group, and with Prune set deletes the rules that are
+// not in the document. Rules are matched on everything but their notes. Only
+// JSON documents are supported, YAML is not. If a change fails, the changes
+// already made are returned along with the error.
+func (f *FireWallRuleServiceHandler) ImportRuleSet(ctx context.Context, fwGroupID string, doc []byte, options *FirewallSyncOptions) (*FirewallSyncResult, error) { //nolint:lll
+	var ruleSet FirewallRuleSet
+	if err := json.Unmarshal(doc, &ruleSet); err != nil {
+		return nil, err
+	}
+
+	if ruleSet.Version != FirewallRuleSetVersion {
+		return nil, fmt.Errorf("unsupported firewall rule set version %d", ruleSet.Version)
+	}
+
+	if options == nil {
+		options = &FirewallSyncOptions{}
+	}
+
+	existing, err := f.listAll(ctx, fwGroupID)
+	if err != nil {
+		return nil, err
+	}
+
+	current := make(map[string]bool, len(existing))
+	for i := range existing {
+		rule := existing[i].toReq()
+		current[rule.key()] = true
+	}
+
+	result := &FirewallSyncResult{}
+	wanted := make(map[string]bool, len(ruleSet.Rules))
+	for i := range ruleSet.Rules {
+		key := ruleSet.Rules[i].key()
+		if !current[key] && !wanted[key] {
+			result.Created = append(result.Created, ruleSet.Rules[i])
+		}
+		wanted[key] = true
+	}
+
+	if options.Prune {
+		for i := range existing {
+			rule := existing[i].toReq()
+			if !wanted[rule.key()] {
+				result.Deleted = append(result.Deleted, existing[i])
+			}
+		}
+	}
+
+	if options.DryRun {
+		return result, nil
+	}
+
+	applied := &FirewallSyncResult{}
+	for i := range result.Created {
+		if _, _, err := f.Create(ctx, fwGroupID, &result.Created[i]); err != nil {
+			return applied, err
+		}
+		applied.Created = append(applied.Created, result.Created[i])
+	}
+
+	for i := range result.Deleted {
+		if err := f.Delete(ctx, fwGroupID, result.Deleted[i].ID); err != nil {
+			return applied, err
+		}
+		applied.Deleted = append(applied.Deleted, result.Deleted[i])
+	}
+
+	return applied, nil
+}
+
+// EvaluateRuleSet reports whether a firewall group with the given rules would
+// accept a connection, along with the first rule that accepts it. Firewall
+// groups drop anything no rule accepts. Rules with a Source, such as
+// "cloudflare" or a load balancer, depend on addresses only known to Vultr and
+// never match.
+func EvaluateRuleSet(rules []FirewallRuleReq, packet FirewallPacket) (bool, *FirewallRuleReq) {
+	src, err := netip.ParseAddr(packet.SrcIP)
+	if err != nil {
+		return false, nil
+	}
+	src = src.Unmap()
+
+	for i := range rules {
+		if rules[i].matches(packet, src) {
+			return true, &rules[i]
+		}
+	}
+
+	return false, nil
+}
+
+func (f *FirewallRuleReq) matches(packet FirewallPacket, src netip.Addr) bool {
+	if f.Source != "" || !strings.EqualFold(f.Protocol, packet.Protocol) {
+		return false
+	}
+
+	if (f.IPType == "v4") != src.Is4() {
+		return false
+	}
+
+	subnet, err := netip.ParseAddr(f.Subnet)
+	if err != nil {
+		return false
+	}
+
+	prefix, err := subnet.Prefix(f.SubnetSize)
+	if err != nil || !prefix.Contains(src) {
+		return false
+	}
+
+	if f.Port == "" {
+		return true
+	}
+
+	low, high, _ := strings.Cut(f.Port, ":")
+	if high == "" {
+		high = low
+	}
+
+	from, errLow := strconv.Atoi(low)
+	to, errHigh := strconv.Atoi(high)
+	if errLow != nil || errHigh != nil {
+		return false
+	}
+
+	return packet.Port >= from && packet.Port <= to
+}
+
+func (f *FireWallRuleServiceHandler) listAll(ctx context.Context, fwGroupID string) ([]FirewallRule, error) {
+	return collectPages(ctx, func(ctx context.Context, options *ListOptions) ([]FirewallRule, *Meta, *http.Response, error) {
+		return f.List(ctx, fwGroupID, options)
+	})
+}
+
+func (f *FirewallRule) toReq() FirewallRuleReq {
+	return FirewallRuleReq{
+		IPType:     f.IPType,
+		Protocol:   f.Protocol,
+		Subnet:     f.Subnet,
+		SubnetSize: f.SubnetSize,
+		Port:       f.Port,
+		Source:     f.Source,
+		Notes:      f.Notes,
+	}
+}
+
+func (f *FirewallRuleReq) key() string {
+	return fmt.Sprintf("%s|%s|%s/%d|%s|%s", f.IPType, f.Protocol, f.Subnet, f.SubnetSize, f.Port, f.Source)
+}
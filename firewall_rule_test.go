@@ -1,6 +1,7 @@
 package govultr
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"reflect"
@@ -137,3 +138,153 @@ func TestFireWallRuleServiceHandler_Get(t *testing.T) {
 		t.Errorf("FirewallRule.Get returned %+v, expected %+v", firewallRule, expectedRule)
 	}
 }
+
+func TestFireWallRuleServiceHandler_ExportRuleSet(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v2/firewalls/abc123/rules", func(writer http.ResponseWriter, request *http.Request) {
+		response := `{"firewall_rules": [
+			{"id": 2, "ip_type": "v4", "protocol": "tcp", "subnet": "0.0.0.0", "subnet_size": 0, "port": "443", "notes": "https"},
+			{"id": 1, "ip_type": "v4", "protocol": "tcp", "subnet": "0.0.0.0", "subnet_size": 0, "port": "22"}
+		], "meta": {"total": 2, "links": {"next": "", "prev": ""}}}`
+		fmt.Fprint(writer, response)
+	})
+
+	doc, err := client.FirewallRule.ExportRuleSet(ctx, "abc123")
+	if err != nil {
+		t.Errorf("FirewallRule.ExportRuleSet returned error: %v", err)
+	}
+
+	var ruleSet FirewallRuleSet
+	if err := json.Unmarshal(doc, &ruleSet); err != nil {
+		t.Errorf("FirewallRule.ExportRuleSet returned invalid JSON: %v", err)
+	}
+
+	expected := FirewallRuleSet{
+		Version: 1,
+		Rules: []FirewallRuleReq{
+			{IPType: "v4", Protocol: "tcp", Subnet: "0.0.0.0", Port: "22"},
+			{IPType: "v4", Protocol: "tcp", Subnet: "0.0.0.0", Port: "443", Notes: "https"},
+		},
+	}
+
+	if !reflect.DeepEqual(ruleSet, expected) {
+		t.Errorf("FirewallRule.ExportRuleSet returned %+v, expected %+v", ruleSet, expected)
+	}
+}
+
+func TestFireWallRuleServiceHandler_ImportRuleSet(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var created []FirewallRuleReq
+	mux.HandleFunc("/v2/firewalls/abc123/rules", func(writer http.ResponseWriter, request *http.Request) {
+		if request.Method == http.MethodPost {
+			var rule FirewallRuleReq
+			_ = json.NewDecoder(request.Body).Decode(&rule)
+			created = append(created, rule)
+			fmt.Fprint(writer, `{"firewall_rule": {"id": 3}}`)
+			return
+		}
+
+		response := `{"firewall_rules": [
+			{"id": 1, "ip_type": "v4", "protocol": "tcp", "subnet": "0.0.0.0", "subnet_size": 0, "port": "22"},
+			{"id": 2, "ip_type": "v4", "protocol": "tcp", "subnet": "0.0.0.0", "subnet_size": 0, "port": "3306"}
+		], "meta": {"total": 2, "links": {"next": "", "prev": ""}}}`
+		fmt.Fprint(writer, response)
+	})
+
+	var deleted []string
+	mux.HandleFunc("/v2/firewalls/abc123/rules/2", func(writer http.ResponseWriter, request *http.Request) {
+		deleted = append(deleted, request.Method)
+	})
+
+	doc := []byte(`{"version": 1, "rules": [
+		{"ip_type": "v4", "protocol": "tcp", "subnet": "0.0.0.0", "subnet_size": 0, "port": "22"},
+		{"ip_type": "v4", "protocol": "tcp", "subnet": "0.0.0.0", "subnet_size": 0, "port": "443"}
+	]}`)
+
+	result, err := client.FirewallRule.ImportRuleSet(ctx, "abc123", doc, &FirewallSyncOptions{Prune: true})
+	if err != nil {
+		t.Errorf("FirewallRule.ImportRuleSet returned error: %v", err)
+	}
+
+	expectedCreated := []FirewallRuleReq{{IPType: "v4", Protocol: "tcp", Subnet: "0.0.0.0", Port: "443"}}
+	if !reflect.DeepEqual(result.Created, expectedCreated) || !reflect.DeepEqual(created, expectedCreated) {
+		t.Errorf("FirewallRule.ImportRuleSet created %+v, expected %+v", created, expectedCreated)
+	}
+
+	if len(result.Deleted) != 1 || result.Deleted[0].ID != 2 || !reflect.DeepEqual(deleted, []string{http.MethodDelete}) {
+		t.Errorf("FirewallRule.ImportRuleSet deleted %+v, expected rule 2", result.Deleted)
+	}
+
+	if _, err = client.FirewallRule.ImportRuleSet(ctx, "abc123", []byte(`{"version": 2}`), nil); err == nil {
+		t.Error("FirewallRule.ImportRuleSet expected an error for an unsupported version")
+	}
+}
+
+func TestFireWallRuleServiceHandler_ImportRuleSetPartialFailure(t *testing.T) {
+	setup()
+	defer teardown()
+
+	posts := 0
+	mux.HandleFunc("/v2/firewalls/abc123/rules", func(writer http.ResponseWriter, request *http.Request) {
+		if request.Method == http.MethodPost {
+			posts++
+			if posts > 1 {
+				http.Error(writer, `{"error": "rule limit reached"}`, http.StatusBadRequest)
+				return
+			}
+			fmt.Fprint(writer, `{"firewall_rule": {"id": 3}}`)
+			return
+		}
+
+		fmt.Fprint(writer, `{"firewall_rules": [], "meta": {"total": 0, "links": {"next": "", "prev": ""}}}`)
+	})
+
+	doc := []byte(`{"version": 1, "rules": [
+		{"ip_type": "v4", "protocol": "tcp", "subnet": "0.0.0.0", "subnet_size": 0, "port": "22"},
+		{"ip_type": "v4", "protocol": "tcp", "subnet": "0.0.0.0", "subnet_size": 0, "port": "443"}
+	]}`)
+
+	result, err := client.FirewallRule.ImportRuleSet(ctx, "abc123", doc, nil)
+	if err == nil {
+		t.Fatal("FirewallRule.ImportRuleSet expected an error when a create fails")
+	}
+
+	expected := &FirewallSyncResult{Created: []FirewallRuleReq{{IPType: "v4", Protocol: "tcp", Subnet: "0.0.0.0", Port: "22"}}}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("FirewallRule.ImportRuleSet returned %+v, expected %+v", result, expected)
+	}
+}
+
+func TestEvaluateRuleSet(t *testing.T) {
+	rules := []FirewallRuleReq{
+		{IPType: "v4", Protocol: "tcp", Subnet: "0.0.0.0", SubnetSize: 0, Port: "443"},
+		{IPType: "v4", Protocol: "tcp", Subnet: "10.0.0.0", SubnetSize: 8, Port: "8000:9000"},
+		{IPType: "v6", Protocol: "udp", Subnet: "2001:db8::", SubnetSize: 32},
+		{IPType: "v4", Protocol: "tcp", Subnet: "0.0.0.0", SubnetSize: 0, Port: "80", Source: "cloudflare"},
+	}
+
+	tests := []struct {
+		packet   FirewallPacket
+		accepted bool
+		rule     *FirewallRuleReq
+	}{
+		{FirewallPacket{Protocol: "tcp", Port: 443, SrcIP: "198.51.100.7"}, true, &rules[0]},
+		{FirewallPacket{Protocol: "TCP", Port: 8080, SrcIP: "10.1.2.3"}, true, &rules[1]},
+		{FirewallPacket{Protocol: "tcp", Port: 8080, SrcIP: "192.168.1.1"}, false, nil},
+		{FirewallPacket{Protocol: "udp", Port: 53, SrcIP: "2001:db8::1"}, true, &rules[2]},
+		{FirewallPacket{Protocol: "udp", Port: 53, SrcIP: "2001:db9::1"}, false, nil},
+		{FirewallPacket{Protocol: "tcp", Port: 80, SrcIP: "198.51.100.7"}, false, nil},
+		{FirewallPacket{Protocol: "tcp", Port: 443, SrcIP: "not-an-ip"}, false, nil},
+	}
+
+	for _, tt := range tests {
+		accepted, rule := EvaluateRuleSet(rules, tt.packet)
+		if accepted != tt.accepted || rule != tt.rule {
+			t.Errorf("EvaluateRuleSet(%+v) returned %t, %+v, expected %t, %+v", tt.packet, accepted, rule, tt.accepted, tt.rule)
+		}
+	}
+}
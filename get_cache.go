@@ -0,0 +1,136 @@
+package govultr
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+// getCachePaths match the Get-by-ID paths whose responses SetGetCache caches
+var getCachePaths = []*regexp.Regexp{
+	regexp.MustCompile(`^/v2/instances/[^/]+$`),
+	regexp.MustCompile(`^/v2/kubernetes/clusters/[^/]+$`),
+	regexp.MustCompile(`^` + vcrPath + `/[^/]+$`),
+}
+
+// maxGetCacheEntries is the number of entries after which expired ones are swept
+const maxGetCacheEntries = 1024
+
+type getCacheEntry struct {
+	path    string
+	status  int
+	header  http.Header
+	body    []byte
+	expires time.Time
+}
+
+type getCache struct {
+	ttl     time.Duration
+	mu      sync.Mutex
+	entries map[string]*getCacheEntry
+}
+
+// SetGetCache caches successful responses of Get calls for instances, VKE
+// clusters and container registries for ttl, keyed by resource ID. Any other
+// request made through the client to the same resource, or to the collection
+// it belongs to, invalidates the cached response once it completes. Keep ttl
+// shorter than the interval of any wait loop polling those resources, a ttl
+// of 0 disables the cache.
+func (c *Client) SetGetCache(ttl time.Duration) {
+	if ttl <= 0 {
+		c.getCache = nil
+		return
+	}
+	c.getCache = &getCache{ttl: ttl, entries: make(map[string]*getCacheEntry)}
+}
+
+func cacheable(r *http.Request) bool {
+	return r.Method == http.MethodGet && r.URL.RawQuery == "" && cachedPath(r.URL.Path)
+}
+
+func cachedPath(path string) bool {
+	for _, pattern := range getCachePaths {
+		if pattern.MatchString(path) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// invalidatedPath returns the path a mutation invalidates cached responses
+// under. A POST to a path shaped like a resource is a bulk action, such as
+// /v2/instances/reboot, and invalidates the whole collection.
+func invalidatedPath(r *http.Request) string {
+	path := strings.TrimSuffix(r.URL.Path, "/")
+	if r.Method == http.MethodPost && cachedPath(path) {
+		return path[:strings.LastIndex(path, "/")]
+	}
+
+	return path
+}
+
+// lookup returns a copy of the cached response to r, if there is one
+func (g *getCache) lookup(r *http.Request) (*http.Response, bool) {
+	key := r.URL.String()
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	entry, ok := g.entries[key]
+	if !ok {
+		return nil, false
+	}
+
+	if time.Now().After(entry.expires) {
+		delete(g.entries, key)
+		return nil, false
+	}
+
+	return &http.Response{
+		Status:     http.StatusText(entry.status),
+		StatusCode: entry.status,
+		Header:     entry.header.Clone(),
+		Body:       io.NopCloser(bytes.NewReader(entry.body)),
+		Request:    r,
+	}, true
+}
+
+func (g *getCache) store(r *http.Request, res *http.Response, body []byte) {
+	now := time.Now()
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if len(g.entries) >= maxGetCacheEntries {
+		for key, entry := range g.entries {
+			if now.After(entry.expires) {
+				delete(g.entries, key)
+			}
+		}
+	}
+
+	g.entries[r.URL.String()] = &getCacheEntry{
+		path:    r.URL.Path,
+		status:  res.StatusCode,
+		header:  res.Header.Clone(),
+		body:    body,
+		expires: now.Add(g.ttl),
+	}
+}
+
+// invalidate drops cached responses for resources at, under or above path
+func (g *getCache) invalidate(path string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	for key, entry := range g.entries {
+		if entry.path == path || strings.HasPrefix(entry.path, path+"/") || strings.HasPrefix(path, entry.path+"/") {
+			delete(g.entries, key)
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package govultr
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestClient_SetGetCache(t *testing.T) {
+	setup()
+	defer teardown()
+
+	gets := 0
+	mux.HandleFunc("/v2/instances/abc", func(writer http.ResponseWriter, request *http.Request) {
+		if request.Method == http.MethodGet {
+			gets++
+			fmt.Fprintf(writer, `{"instance": {"id": "abc", "label": "label-%d"}}`, gets)
+		}
+	})
+
+	mux.HandleFunc("/v2/instances/abc/reboot", func(writer http.ResponseWriter, request *http.Request) {
+		writer.WriteHeader(http.StatusNoContent)
+	})
+
+	mux.HandleFunc("/v2/instances/reboot", func(writer http.ResponseWriter, request *http.Request) {
+		writer.WriteHeader(http.StatusNoContent)
+	})
+
+	client.SetGetCache(time.Minute)
+
+	for i := 0; i < 3; i++ {
+		instance, _, err := client.Instance.Get(ctx, "abc")
+		if err != nil {
+			t.Fatalf("Instance.Get returned %+v", err)
+		}
+		if instance.Label != "label-1" {
+			t.Errorf("Instance.Get returned label %s, expected label-1", instance.Label)
+		}
+	}
+
+	if gets != 1 {
+		t.Errorf("Instance.Get sent %d requests, expected 1", gets)
+	}
+
+	if err := client.Instance.Reboot(ctx, "abc"); err != nil {
+		t.Fatalf("Instance.Reboot returned %+v", err)
+	}
+
+	instance, _, err := client.Instance.Get(ctx, "abc")
+	if err != nil {
+		t.Fatalf("Instance.Get returned %+v", err)
+	}
+
+	if instance.Label != "label-2" || gets != 2 {
+		t.Errorf("Instance.Get returned label %s after %d requests, expected label-2 after 2", instance.Label, gets)
+	}
+
+	if err = client.Instance.MassReboot(ctx, []string{"abc"}); err != nil {
+		t.Fatalf("Instance.MassReboot returned %+v", err)
+	}
+
+	if _, _, err = client.Instance.Get(ctx, "abc"); err != nil {
+		t.Fatalf("Instance.Get returned %+v", err)
+	}
+
+	if gets != 3 {
+		t.Errorf("Instance.Get sent %d requests after Instance.MassReboot, expected 3", gets)
+	}
+
+	client.SetGetCache(0)
+	if _, _, err = client.Instance.Get(ctx, "abc"); err != nil {
+		t.Fatalf("Instance.Get returned %+v", err)
+	}
+
+	if gets != 4 {
+		t.Errorf("Instance.Get sent %d requests with the cache disabled, expected 4", gets)
+	}
+}
+
+func TestGetCache_Expiry(t *testing.T) {
+	cache := &getCache{ttl: time.Millisecond, entries: make(map[string]*getCacheEntry)}
+
+	req, _ := http.NewRequest(http.MethodGet, "https://api.vultr.com/v2/kubernetes/clusters/vke", nil)
+	cache.store(req, &http.Response{StatusCode: http.StatusOK, Header: http.Header{}}, []byte(`{}`))
+
+	if _, ok := cache.lookup(req); !ok {
+		t.Error("getCache.lookup missed a fresh entry")
+	}
+
+	time.Sleep(5 * time.Millisecond)
+	if _, ok := cache.lookup(req); ok {
+		t.Error("getCache.lookup returned an expired entry")
+	}
+
+	cache.ttl = time.Minute
+	cache.store(req, &http.Response{StatusCode: http.StatusOK, Header: http.Header{}}, []byte(`{}`))
+	cache.invalidate("/v2/kubernetes/clusters/vke/node-pools/np")
+	if _, ok := cache.lookup(req); ok {
+		t.Error("getCache.lookup returned an entry invalidated by a node pool change")
+	}
+}
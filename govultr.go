@@ -4,15 +4,18 @@ package govultr
 
 import (
 	"bytes"
+	"compress/gzip"
 	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"io"
 	"net"
 	"net/http"
+	"net/http/httptrace"
 	"net/url"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/hashicorp/go-retryablehttp"
@@ -24,12 +27,18 @@ const (
 	userAgent   = "govultr/" + version
 	rateLimit   = 500 * time.Millisecond
 	retryLimit  = 3
+
+	// maxDrainBytes caps how much of an unread response body is discarded
+	// to allow the connection to be reused
+	maxDrainBytes = 4 << 10
 )
 
 // RequestBody is used to create JSON bodies for one off calls
 type RequestBody map[string]interface{}
 
-// Client manages interaction with the Vultr API
+// Client manages interaction with the Vultr API. Its Set*, Add* and
+// OnRequestCompleted configuration methods are not safe to call concurrently
+// with requests and should be called before the client is in use.
 type Client struct {
 	// Http Client used to interact with the Vultr API
 	client *retryablehttp.Client
@@ -37,6 +46,9 @@ type Client struct {
 	// BASE URL for APIs
 	BaseURL *url.URL
 
+	// BASE URLs overriding BaseURL for requests to paths with a given prefix
+	pathBaseURLs map[string]*url.URL
+
 	// User Agent for the client
 	UserAgent string
 
@@ -76,8 +88,75 @@ type Client struct {
 
 	// Optional function called after every successful request made to the Vultr API
 	onRequestCompleted RequestCompletionCallback
+
+	// Hooks run before every request is sent and after its response is decoded
+	requestHooks  []RequestHook
+	responseHooks []ResponseHook
+
+	// Optional store recording every mutating request made to the Vultr API
+	journal    JournalStore
+	journalMu  sync.Mutex
+	journalSeq uint64
+
+	// Optional policy consulted before every delete request
+	deletePolicy DeletePolicy
+
+	// Minimum VKE node pool size, whether the last node pool of a cluster may
+	// be deleted and optional policy consulted before node pool changes
+	nodePoolMinNodes    int
+	nodePoolProtectLast bool
+	nodePoolPolicy      NodePoolPolicy
+
+	// Optional tracer a span is started with for every request
+	tracer Tracer
+
+	// Optional maximum monthly cost of all VKE nodes, 0 disables the guard
+	nodeBudget float32
+
+	// Optional limiter every request waits on before being sent
+	rateLimiter RateLimiter
+
+	// Optional limit on the number of requests in flight
+	concurrencyLimiter *AdaptiveLimiter
+
+	// Number of times a 429 is retried, -1 leaves 429s to the retry limit
+	rateLimitRetries int
+
+	// Optional source of the API key, refreshed once when a request is unauthorized
+	credentials            CredentialsProvider
+	onCredentialsRefreshed CredentialsRefreshCallback
+
+	// Collect unknown fields and type mismatches instead of failing on them
+	decodeWarnings bool
+
+	// Delay after which a second copy of a GET request is sent, 0 disables hedging
+	hedgeDelay time.Duration
+
+	// Optional cache of Get-by-ID responses
+	getCache *getCache
+
+	// Optional resolver for public DNS lookups, nil uses net.DefaultResolver,
+	// and optional lookup for queries sent to a specific DNS server
+	dnsResolver  DNSResolver
+	recordLookup RecordLookup
+
+	// Request bodies of at least this many bytes are gzip compressed, 0 disables compression
+	compressThreshold int
+
+	// Counters of connections reused from the pool and newly dialed
+	connsReused atomic.Uint64
+	connsNew    atomic.Uint64
+}
+
+// ConnectionStats represents how many requests reused a pooled connection
+// and how many needed a new one
+type ConnectionStats struct {
+	Reused uint64
+	New    uint64
 }
 
+type baseURLContextKey struct{}
+
 // RequestCompletionCallback defines the type of the request callback function
 type RequestCompletionCallback func(*http.Request, *http.Response)
 
@@ -108,11 +187,15 @@ func NewClient(httpClient *http.Client) *Client {
 		client:    retryablehttp.NewClient(),
 		BaseURL:   baseURL,
 		UserAgent: userAgent,
+
+		rateLimitRetries: -1,
 	}
 
 	client.client.HTTPClient = httpClient
 	client.client.Logger = nil
 	client.client.ErrorHandler = client.vultrErrorHandler
+	client.client.CheckRetry = client.retryPolicy
+	client.client.Backoff = rateLimitBackoff
 	client.SetRetryLimit(retryLimit)
 	client.SetRateLimit(rateLimit)
 
@@ -153,7 +236,7 @@ func NewClient(httpClient *http.Client) *Client {
 
 // NewRequest creates an API Request
 func (c *Client) NewRequest(ctx context.Context, method, uri string, body interface{}) (*http.Request, error) {
-	resolvedURL, err := c.BaseURL.Parse(uri)
+	resolvedURL, err := c.baseURLFor(ctx, uri).Parse(uri)
 	if err != nil {
 		return nil, err
 	}
@@ -165,6 +248,19 @@ func (c *Client) NewRequest(ctx context.Context, method, uri string, body interf
 		}
 	}
 
+	compressed := c.compressThreshold > 0 && buf.Len() >= c.compressThreshold
+	if compressed {
+		zbuf := new(bytes.Buffer)
+		zw := gzip.NewWriter(zbuf)
+		if _, err2 := zw.Write(buf.Bytes()); err2 != nil {
+			return nil, err2
+		}
+		if err2 := zw.Close(); err2 != nil {
+			return nil, err2
+		}
+		buf = zbuf
+	}
+
 	req, err := http.NewRequestWithContext(ctx, method, resolvedURL.String(), buf)
 	if err != nil {
 		return nil, err
@@ -173,6 +269,9 @@ func (c *Client) NewRequest(ctx context.Context, method, uri string, body interf
 	req.Header.Add("User-Agent", c.UserAgent)
 	req.Header.Add("Accept", "application/json")
 	req.Header.Add("Content-Type", "application/json")
+	if compressed {
+		req.Header.Add("Content-Encoding", "gzip")
+	}
 
 	return req, nil
 }
@@ -181,28 +280,77 @@ func (c *Client) NewRequest(ctx context.Context, method, uri string, body interf
 // a successful call. A successful call is then checked to see if we need to unmarshal since some resources
 // have their own implements of unmarshal.
 func (c *Client) DoWithContext(ctx context.Context, r *http.Request, data interface{}) (*http.Response, error) {
+	if r.Method == http.MethodDelete && c.guardsDelete(ctx) {
+		if err := c.checkDeletePolicy(ctx, r); err != nil {
+			return nil, err
+		}
+	}
+
+	if c.journal != nil && isMutation(r.Method) {
+		return c.doJournaled(ctx, r, data)
+	}
+
+	return c.do(ctx, r, data)
+}
+
+func (c *Client) doRequest(ctx context.Context, r *http.Request, data interface{}) (*http.Response, error) {
+	if cache := c.getCache; cache != nil {
+		if cacheable(r) {
+			if res, ok := cache.lookup(r); ok {
+				body, _ := io.ReadAll(res.Body)
+				res.Body = io.NopCloser(bytes.NewBuffer(body))
+				return res, c.decode(res, body, data)
+			}
+		} else if r.Method != http.MethodGet {
+			defer cache.invalidate(invalidatedPath(r))
+		}
+	}
+
+	if c.rateLimiter != nil {
+		if err := c.rateLimiter.Wait(ctx); err != nil {
+			return nil, err
+		}
+	}
+
 	rreq, err := retryablehttp.FromRequest(r)
 	if err != nil {
 		return nil, err
 	}
 
-	rreq = rreq.WithContext(ctx)
+	rreq = rreq.WithContext(httptrace.WithClientTrace(ctx, &httptrace.ClientTrace{
+		GotConn: func(info httptrace.GotConnInfo) {
+			if info.Reused {
+				c.connsReused.Add(1)
+			} else {
+				c.connsNew.Add(1)
+			}
+		},
+	}))
+
+	if c.credentials != nil {
+		apiKey, errKey := c.credentials.APIKey(ctx)
+		if errKey != nil {
+			return nil, errKey
+		}
+		rreq.Header.Set("Authorization", "Bearer "+apiKey)
+	}
 
-	res, errDo := c.client.Do(rreq)
+	res, errDo := c.sendRateLimited(ctx, rreq)
 
 	if c.onRequestCompleted != nil {
 		c.onRequestCompleted(r, res)
 	}
 
+	if errDo == nil && res.StatusCode == http.StatusUnauthorized && c.credentials != nil {
+		drainAndClose(res.Body)
+		res, errDo = c.retryWithRefreshedCredentials(ctx, r, rreq)
+	}
+
 	if errDo != nil {
 		return nil, errDo
 	}
 
-	defer func() {
-		if rerr := res.Body.Close(); err == nil {
-			err = rerr
-		}
-	}()
+	defer drainAndClose(res.Body)
 
 	body, err := io.ReadAll(res.Body)
 	if err != nil {
@@ -212,15 +360,79 @@ func (c *Client) DoWithContext(ctx context.Context, r *http.Request, data interf
 	res.Body = io.NopCloser(bytes.NewBuffer(body))
 
 	if res.StatusCode >= http.StatusOK && res.StatusCode <= http.StatusNoContent {
-		if data != nil {
-			if err := json.Unmarshal(body, data); err != nil {
-				return nil, err
-			}
+		if err := c.decode(res, body, data); err != nil {
+			return nil, err
+		}
+		if c.getCache != nil && cacheable(r) {
+			c.getCache.store(r, res, body)
 		}
 		return res, nil
 	}
 
-	return res, errors.New(string(body))
+	return res, newErrorResponse(res, body)
+}
+
+// decode unmarshals the body of a successful response into data
+func (c *Client) decode(res *http.Response, body []byte, data interface{}) error {
+	if data == nil || len(body) == 0 {
+		return nil
+	}
+
+	if !c.decodeWarnings {
+		return json.Unmarshal(body, data)
+	}
+
+	warnings, err := decodeTolerant(body, data)
+	if err != nil {
+		return err
+	}
+	if warnings != nil {
+		attachDecodeWarnings(res, warnings)
+	}
+
+	return nil
+}
+
+// ConnectionStats returns how many requests have reused a pooled connection and
+// how many have dialed a new one since the client was created
+func (c *Client) ConnectionStats() ConnectionStats {
+	return ConnectionStats{
+		Reused: c.connsReused.Load(),
+		New:    c.connsNew.Load(),
+	}
+}
+
+// drainAndClose discards whatever is left of a response body so the underlying
+// connection can be reused, then closes it
+func drainAndClose(body io.ReadCloser) {
+	_, _ = io.Copy(io.Discard, io.LimitReader(body, maxDrainBytes))
+	_ = body.Close()
+}
+
+// Raw sends a request with the given method, path and JSON body to any API
+// endpoint, decoding a successful response into out when it is not nil. The
+// request gets the same handling as every other call, including retries, rate
+// limiting, the journal and completion callbacks.
+func (c *Client) Raw(ctx context.Context, method, path string, body, out interface{}) (*http.Response, error) {
+	req, err := c.NewRequest(ctx, method, path, body)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.DoWithContext(ctx, req, out)
+}
+
+// Do sends an API Request made with NewRequest and decodes a successful response
+// into a new T. It can be used to call endpoints that do not have first class
+// support yet. A response without a body leaves T at its zero value.
+func Do[T any](ctx context.Context, c *Client, req *http.Request) (*T, *http.Response, error) {
+	out := new(T)
+	resp, err := c.DoWithContext(ctx, req, out)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return out, resp, nil
 }
 
 // SetBaseURL Overrides the default BaseUrl
@@ -235,6 +447,44 @@ func (c *Client) SetBaseURL(baseURL string) error {
 	return nil
 }
 
+// SetPathBaseURL overrides the BaseURL for requests to paths starting with
+// prefix, e.g. "/v2/registry" to route container registry calls through a
+// proxy. When several prefixes match the longest one is used.
+func (c *Client) SetPathBaseURL(prefix, baseURL string) error {
+	updatedURL, err := url.Parse(baseURL)
+	if err != nil {
+		return err
+	}
+
+	if c.pathBaseURLs == nil {
+		c.pathBaseURLs = make(map[string]*url.URL)
+	}
+
+	c.pathBaseURLs[prefix] = updatedURL
+	return nil
+}
+
+// ContextWithBaseURL returns a copy of ctx that overrides the client BaseURL
+// for any request created with it. This takes precedence over SetPathBaseURL.
+func ContextWithBaseURL(ctx context.Context, baseURL *url.URL) context.Context {
+	return context.WithValue(ctx, baseURLContextKey{}, baseURL)
+}
+
+func (c *Client) baseURLFor(ctx context.Context, uri string) *url.URL {
+	if baseURL, ok := ctx.Value(baseURLContextKey{}).(*url.URL); ok && baseURL != nil {
+		return baseURL
+	}
+
+	baseURL, matched := c.BaseURL, ""
+	for prefix, override := range c.pathBaseURLs {
+		if strings.HasPrefix(uri, prefix) && len(prefix) > len(matched) {
+			baseURL, matched = override, prefix
+		}
+	}
+
+	return baseURL
+}
+
 // SetRateLimit Overrides the default rateLimit. For performance, exponential
 // backoff is used with the minimum wait being 2/3rds the time provided.
 func (c *Client) SetRateLimit(t time.Duration) {
@@ -242,6 +492,14 @@ func (c *Client) SetRateLimit(t time.Duration) {
 	c.client.RetryWaitMax = t
 }
 
+// SetRequestCompression gzip compresses request bodies of at least threshold
+// bytes, such as large startup scripts. Compression is off by default and
+// should only be enabled for endpoints that accept compressed bodies. A
+// threshold of 0 disables it.
+func (c *Client) SetRequestCompression(threshold int) {
+	c.compressThreshold = threshold
+}
+
 // SetUserAgent Overrides the default UserAgent
 func (c *Client) SetUserAgent(ua string) {
 	c.UserAgent = ua
@@ -260,16 +518,18 @@ func (c *Client) SetRetryLimit(n int) {
 func (c *Client) vultrErrorHandler(resp *http.Response, err error, numTries int) (*http.Response, error) {
 	if resp == nil {
 		if err != nil {
-			return nil, fmt.Errorf("gave up after %d attempts, last error : %s", numTries, err.Error())
+			return nil, fmt.Errorf("gave up after %d attempts, last error : %w", numTries, err)
 		}
 		return nil, fmt.Errorf("gave up after %d attempts, last error unavailable (resp == nil)", numTries)
 	}
 
+	defer drainAndClose(resp.Body)
+
 	buf, err := io.ReadAll(resp.Body)
 	if err != nil {
 		return nil, fmt.Errorf("gave up after %d attempts, last error unavailable (error reading response body: %v)", numTries, err)
 	}
-	return nil, fmt.Errorf("gave up after %d attempts, last error: %#v", numTries, strings.TrimSpace(string(buf)))
+	return nil, &retryError{attempts: numTries, last: newErrorResponse(resp, buf)}
 }
 
 // BoolToBoolPtr helper function that returns a pointer from your bool value
@@ -1,7 +1,9 @@
 package govultr
 
 import (
+	"compress/gzip"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -317,3 +319,155 @@ func TestRequest_InvalidResponseBody(t *testing.T) {
 		t.Error("Expected response body to be invalid")
 	}
 }
+
+func TestClient_ConnectionStats(t *testing.T) {
+	setup()
+	defer teardown()
+
+	client = NewClient(&http.Client{Transport: &http.Transport{}})
+	client.BaseURL, _ = url.Parse(server.URL)
+
+	mux.HandleFunc("/", func(writer http.ResponseWriter, request *http.Request) {
+		writer.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(writer, `{"error": "bad request"}`)
+	})
+
+	client.SetRetryLimit(0)
+	for i := 0; i < 3; i++ {
+		req, _ := client.NewRequest(ctx, http.MethodGet, "/", nil)
+		if _, err := client.DoWithContext(ctx, req, nil); err == nil {
+			t.Fatal("DoWithContext(): expected an error")
+		}
+	}
+
+	expected := ConnectionStats{Reused: 2, New: 1}
+	if stats := client.ConnectionStats(); stats != expected {
+		t.Errorf("ConnectionStats() = %+v, expected %+v", stats, expected)
+	}
+}
+
+func TestClient_BaseURLOverrides(t *testing.T) {
+	setup()
+	defer teardown()
+
+	if err := client.SetPathBaseURL("/v2/registry", "https://proxy.example.com"); err != nil {
+		t.Fatalf("SetPathBaseURL(): %v", err)
+	}
+
+	req, _ := client.NewRequest(ctx, http.MethodGet, "/v2/registry/1", nil)
+	if expected := "https://proxy.example.com/v2/registry/1"; req.URL.String() != expected {
+		t.Errorf("NewRequest() URL = %v, expected %v", req.URL, expected)
+	}
+
+	req, _ = client.NewRequest(ctx, http.MethodGet, "/v2/instances", nil)
+	if expected := server.URL + "/v2/instances"; req.URL.String() != expected {
+		t.Errorf("NewRequest() URL = %v, expected %v", req.URL, expected)
+	}
+
+	override, _ := url.Parse("https://egress.example.com")
+	req, _ = client.NewRequest(ContextWithBaseURL(ctx, override), http.MethodGet, "/v2/registry/1", nil)
+	if expected := "https://egress.example.com/v2/registry/1"; req.URL.String() != expected {
+		t.Errorf("NewRequest() URL = %v, expected %v", req.URL, expected)
+	}
+}
+
+func TestDo(t *testing.T) {
+	setup()
+	defer teardown()
+
+	type widget struct {
+		Widget struct {
+			ID string `json:"id"`
+		} `json:"widget"`
+	}
+
+	mux.HandleFunc("/v2/widgets/1", func(writer http.ResponseWriter, request *http.Request) {
+		fmt.Fprint(writer, `{"widget": {"id": "1"}}`)
+	})
+
+	mux.HandleFunc("/v2/widgets/2", func(writer http.ResponseWriter, request *http.Request) {
+		writer.WriteHeader(http.StatusNoContent)
+	})
+
+	req, _ := client.NewRequest(ctx, http.MethodGet, "/v2/widgets/1", nil)
+	out, _, err := Do[widget](ctx, client, req)
+	if err != nil {
+		t.Fatalf("Do(): %v", err)
+	}
+
+	if out.Widget.ID != "1" {
+		t.Errorf("Do() decoded ID %q, expected %q", out.Widget.ID, "1")
+	}
+
+	req, _ = client.NewRequest(ctx, http.MethodDelete, "/v2/widgets/2", nil)
+	if _, resp, err := Do[widget](ctx, client, req); err != nil || resp.StatusCode != http.StatusNoContent {
+		t.Errorf("Do() returned %v, %v, expected a %d response", resp, err, http.StatusNoContent)
+	}
+}
+
+func TestClient_Raw(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v2/widgets", func(writer http.ResponseWriter, request *http.Request) {
+		if request.Method != http.MethodPost {
+			t.Errorf("Request method = %v, expecting %v", request.Method, http.MethodPost)
+		}
+
+		if size := request.URL.Query().Get("size"); size != "large" {
+			t.Errorf("Request query size = %q, expecting %q", size, "large")
+		}
+
+		body, _ := io.ReadAll(request.Body)
+		fmt.Fprintf(writer, `{"echo": %s}`, body)
+	})
+
+	out := struct {
+		Echo map[string]string `json:"echo"`
+	}{}
+
+	if _, err := client.Raw(ctx, http.MethodPost, "/v2/widgets?size=large", RequestBody{"label": "a"}, &out); err != nil {
+		t.Fatalf("Raw(): %v", err)
+	}
+
+	if out.Echo["label"] != "a" {
+		t.Errorf("Raw() decoded %+v, expected label %q", out, "a")
+	}
+}
+
+func TestClient_SetRequestCompression(t *testing.T) {
+	setup()
+	defer teardown()
+
+	client.SetRequestCompression(64)
+
+	script := strings.Repeat("echo hello\n", 20)
+	mux.HandleFunc("/v2/startup-scripts", func(writer http.ResponseWriter, request *http.Request) {
+		if encoding := request.Header.Get("Content-Encoding"); encoding != "gzip" {
+			t.Errorf("Content-Encoding = %q, expected gzip", encoding)
+		}
+
+		zr, err := gzip.NewReader(request.Body)
+		if err != nil {
+			t.Fatalf("gzip.NewReader returned %+v", err)
+		}
+
+		var body map[string]string
+		if err = json.NewDecoder(zr).Decode(&body); err != nil {
+			t.Errorf("decoding compressed body returned %+v", err)
+		}
+
+		if body["script"] != script {
+			t.Errorf("compressed body script = %q, expected %q", body["script"], script)
+		}
+	})
+
+	if _, err := client.Raw(ctx, http.MethodPost, "/v2/startup-scripts", RequestBody{"script": script}, nil); err != nil {
+		t.Errorf("Client.Raw returned %+v", err)
+	}
+
+	req, _ := client.NewRequest(ctx, http.MethodPost, "/v2/startup-scripts", RequestBody{"name": "small"})
+	if encoding := req.Header.Get("Content-Encoding"); encoding != "" {
+		t.Errorf("Content-Encoding = %q for a body under the threshold, expected none", encoding)
+	}
+}
@@ -0,0 +1,132 @@
+package govultr
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/go-retryablehttp"
+)
+
+// SetRequestHedging sends a second copy of a GET request when the first has
+// not completed after delay. The first successful response is used and the
+// other request is cancelled. Hedging trades extra API calls for lower tail
+// latency. The second copy waits for the rate limiter and the concurrency
+// limiter like any other request, a delay of 0 disables it.
+func (c *Client) SetRequestHedging(delay time.Duration) {
+	c.hedgeDelay = delay
+}
+
+type hedgeResult struct {
+	res   *http.Response
+	err   error
+	index int
+}
+
+// cancelOnClose cancels the context of a hedged request once its body is closed
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (c *cancelOnClose) Close() error {
+	err := c.ReadCloser.Close()
+	c.cancel()
+	return err
+}
+
+// send sends a request through the concurrency limiter, hedging GET requests
+// when enabled
+func (c *Client) send(ctx context.Context, rreq *retryablehttp.Request) (*http.Response, error) {
+	if c.hedgeDelay <= 0 || rreq.Method != http.MethodGet {
+		return c.sendLimited(ctx, func() (*http.Response, error) {
+			return c.client.Do(rreq)
+		})
+	}
+
+	results := make(chan hedgeResult, 2)
+	var cancels []context.CancelFunc
+	start := func() {
+		attemptCtx, cancel := context.WithCancel(rreq.Context())
+		cancels = append(cancels, cancel)
+		index := len(cancels) - 1
+		attempt := cloneRequest(attemptCtx, rreq)
+		go func() {
+			// The first attempt already waited for the rate limiter in doRequest
+			if index > 0 && c.rateLimiter != nil {
+				if err := c.rateLimiter.Wait(attemptCtx); err != nil {
+					results <- hedgeResult{err: err, index: index}
+					return
+				}
+			}
+
+			res, err := c.sendLimited(attemptCtx, func() (*http.Response, error) {
+				return c.client.Do(attempt)
+			})
+			results <- hedgeResult{res: res, err: err, index: index}
+		}()
+	}
+
+	start()
+	timer := time.NewTimer(c.hedgeDelay)
+	defer timer.Stop()
+
+	var failed *hedgeResult
+	for received := 0; received < len(cancels); {
+		select {
+		case <-timer.C:
+			start()
+		case result := <-results:
+			received++
+			if result.err == nil && result.res.StatusCode < http.StatusInternalServerError {
+				for i, cancel := range cancels {
+					if i != result.index {
+						cancel()
+					}
+				}
+				go discardHedged(results, len(cancels)-received)
+
+				result.res.Body = &cancelOnClose{ReadCloser: result.res.Body, cancel: cancels[result.index]}
+				return result.res, nil
+			}
+
+			if failed != nil {
+				releaseHedged(result)
+				cancels[result.index]()
+				continue
+			}
+			failed = &result
+		}
+	}
+
+	if failed.res == nil {
+		cancels[failed.index]()
+		return nil, failed.err
+	}
+
+	failed.res.Body = &cancelOnClose{ReadCloser: failed.res.Body, cancel: cancels[failed.index]}
+	return failed.res, failed.err
+}
+
+// cloneRequest returns a copy of rreq using ctx that shares no headers or other
+// mutable state with it, so concurrent attempts can be sent safely
+func cloneRequest(ctx context.Context, rreq *retryablehttp.Request) *retryablehttp.Request {
+	clone := rreq.WithContext(ctx)
+	clone.Request = rreq.Request.Clone(ctx)
+	return clone
+}
+
+// discardHedged waits for the remaining hedged requests and releases them
+func discardHedged(results <-chan hedgeResult, pending int) {
+	for ; pending > 0; pending-- {
+		releaseHedged(<-results)
+	}
+}
+
+// releaseHedged closes the body of a hedged response that will not be used
+func releaseHedged(result hedgeResult) {
+	if result.res != nil {
+		drainAndClose(result.res.Body)
+	}
+}
@@ -0,0 +1,102 @@
+package govultr
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestClient_SetRequestHedging(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var requests atomic.Int32
+	mux.HandleFunc("/v2/ssh-keys/1", func(writer http.ResponseWriter, request *http.Request) {
+		if requests.Add(1) == 1 {
+			select {
+			case <-request.Context().Done():
+				return
+			case <-time.After(5 * time.Second):
+			}
+		}
+		fmt.Fprint(writer, `{"ssh_key": {"id": "1"}}`)
+	})
+
+	client.SetRequestHedging(20 * time.Millisecond)
+
+	start := time.Now()
+	key, _, err := client.SSHKey.Get(ctx, "1")
+	if err != nil {
+		t.Errorf("SSHKey.Get returned %+v", err)
+	}
+
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("SSHKey.Get took %s, expected the hedged request to answer first", elapsed)
+	}
+
+	if key.ID != "1" {
+		t.Errorf("SSHKey.Get returned %+v, expected ID 1", key)
+	}
+
+	if n := requests.Load(); n != 2 {
+		t.Errorf("SSHKey.Get sent %d requests, expected 2", n)
+	}
+}
+
+func TestClient_SetRequestHedgingMutation(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var requests atomic.Int32
+	mux.HandleFunc("/v2/ssh-keys/1", func(writer http.ResponseWriter, request *http.Request) {
+		requests.Add(1)
+		time.Sleep(50 * time.Millisecond)
+	})
+
+	client.SetRequestHedging(time.Millisecond)
+	if err := client.SSHKey.Delete(ctx, "1"); err != nil {
+		t.Errorf("SSHKey.Delete returned %+v", err)
+	}
+
+	if n := requests.Load(); n != 1 {
+		t.Errorf("SSHKey.Delete sent %d requests, expected 1", n)
+	}
+}
+
+type countingRateLimiter struct {
+	waits atomic.Int32
+}
+
+func (l *countingRateLimiter) Wait(ctx context.Context) error {
+	l.waits.Add(1)
+	return nil
+}
+
+func TestClient_SetRequestHedgingLimits(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var requests atomic.Int32
+	mux.HandleFunc("/v2/ssh-keys/1", func(writer http.ResponseWriter, request *http.Request) {
+		if requests.Add(1) == 1 {
+			<-request.Context().Done()
+			return
+		}
+		fmt.Fprint(writer, `{"ssh_key": {"id": "1"}}`)
+	})
+
+	limiter := &countingRateLimiter{}
+	client.SetRateLimiter(limiter)
+	client.SetRequestHedging(10 * time.Millisecond)
+
+	if _, _, err := client.SSHKey.Get(ctx, "1"); err != nil {
+		t.Errorf("SSHKey.Get returned %+v", err)
+	}
+
+	if n := limiter.waits.Load(); n != 2 {
+		t.Errorf("RateLimiter.Wait called %d times, expected once for each of the 2 attempts", n)
+	}
+}
@@ -0,0 +1,87 @@
+package govultr
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"time"
+)
+
+// RequestHook defines the type of the function run before a request is sent.
+// It may change the request, such as adding headers. Returning an error stops
+// the request and the error is returned to the caller.
+type RequestHook func(ctx context.Context, req *http.Request) error
+
+// ResponseHook defines the type of the function run after a request completes
+type ResponseHook func(ctx context.Context, info *ResponseInfo)
+
+// ResponseInfo describes a completed request for response hooks. Response is
+// nil when no response was received, Data is the value the response body was
+// decoded into and is only set for successful requests.
+type ResponseInfo struct {
+	Request  *http.Request
+	Response *http.Response
+	Body     []byte
+	Data     interface{}
+	Err      error
+	Start    time.Time
+	Duration time.Duration
+}
+
+// AddRequestHook adds a hook run before every request made to the Vultr API,
+// after those added before it. Hooks run before rate limiting and retries, so
+// once per call.
+func (c *Client) AddRequestHook(hook RequestHook) {
+	c.requestHooks = append(c.requestHooks, hook)
+}
+
+// AddResponseHook adds a hook run after every request made to the Vultr API
+// completes, after those added before it. Duration covers rate limiting and
+// retries.
+func (c *Client) AddResponseHook(hook ResponseHook) {
+	c.responseHooks = append(c.responseHooks, hook)
+}
+
+// do sends a request, running the request and response hooks around it
+// inside the span of the tracer, if any
+func (c *Client) do(ctx context.Context, r *http.Request, data interface{}) (*http.Response, error) {
+	return c.traced(ctx, r, func(ctx context.Context, r *http.Request) (*http.Response, error) {
+		return c.hooked(ctx, r, data)
+	})
+}
+
+// hooked sends a request, running the request and response hooks around it
+func (c *Client) hooked(ctx context.Context, r *http.Request, data interface{}) (*http.Response, error) {
+	if len(c.requestHooks) == 0 && len(c.responseHooks) == 0 {
+		return c.doRequest(ctx, r, data)
+	}
+
+	for _, hook := range c.requestHooks {
+		if err := hook(ctx, r); err != nil {
+			return nil, err
+		}
+	}
+
+	start := time.Now()
+	res, err := c.doRequest(ctx, r, data)
+
+	info := &ResponseInfo{Request: r, Response: res, Err: err, Start: start, Duration: time.Since(start)}
+	if err == nil {
+		info.Data = data
+	}
+
+	if res != nil && res.Body != nil {
+		body, errRead := io.ReadAll(res.Body)
+		if errRead == nil {
+			info.Body = body
+			res.Body = io.NopCloser(bytes.NewBuffer(body))
+		}
+	}
+
+	for _, hook := range c.responseHooks {
+		hook(ctx, info)
+	}
+
+	return res, err
+}
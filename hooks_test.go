@@ -0,0 +1,80 @@
+package govultr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestClient_Hooks(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v2/ssh-keys/abc", func(writer http.ResponseWriter, request *http.Request) {
+		if trace := request.Header.Get("X-Trace-Id"); trace != "trace-1" {
+			t.Errorf("request hook set X-Trace-Id %q, expected trace-1", trace)
+		}
+		fmt.Fprint(writer, `{"ssh_key": {"id": "abc", "name": "laptop"}}`)
+	})
+
+	var order []string
+	client.AddRequestHook(func(ctx context.Context, req *http.Request) error {
+		order = append(order, "request 1")
+		req.Header.Set("X-Trace-Id", "trace-1")
+		return nil
+	})
+	client.AddRequestHook(func(ctx context.Context, req *http.Request) error {
+		order = append(order, "request 2")
+		return nil
+	})
+
+	var info *ResponseInfo
+	client.AddResponseHook(func(ctx context.Context, i *ResponseInfo) {
+		order = append(order, "response")
+		info = i
+	})
+
+	key, _, err := client.SSHKey.Get(ctx, "abc")
+	if err != nil {
+		t.Fatalf("SSHKey.Get returned %+v", err)
+	}
+
+	expected := []string{"request 1", "request 2", "response"}
+	if !reflect.DeepEqual(order, expected) {
+		t.Errorf("hooks ran %+v, expected %+v", order, expected)
+	}
+
+	if info.Response.StatusCode != http.StatusOK || info.Err != nil || info.Duration <= 0 {
+		t.Errorf("response hook got %+v", info)
+	}
+
+	if string(info.Body) != `{"ssh_key": {"id": "abc", "name": "laptop"}}` {
+		t.Errorf("response hook got body %s", info.Body)
+	}
+
+	data, ok := info.Data.(*sshKeyBase)
+	if !ok || !reflect.DeepEqual(data.SSHKey, key) {
+		t.Errorf("response hook got data %+v, expected %+v", info.Data, key)
+	}
+}
+
+func TestClient_RequestHookError(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v2/ssh-keys/abc", func(writer http.ResponseWriter, request *http.Request) {
+		t.Error("SSHKey.Get sent a request refused by a request hook")
+	})
+
+	refused := errors.New("refused")
+	client.AddRequestHook(func(ctx context.Context, req *http.Request) error {
+		return refused
+	})
+
+	if _, _, err := client.SSHKey.Get(ctx, "abc"); !errors.Is(err, refused) {
+		t.Errorf("SSHKey.Get returned %+v, expected %+v", err, refused)
+	}
+}
@@ -4,17 +4,26 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"sort"
+	"time"
 
 	"github.com/google/go-querystring/query"
 )
 
-const instancePath = "/v2/instances"
+const (
+	instancePath = "/v2/instances"
+
+	instanceStatusActive   = "active"
+	instanceServerStatusOK = "ok"
+)
 
 // InstanceService is the interface to interact with the instance endpoints on the Vultr API
 // Link: https://www.vultr.com/api/#tag/instances
 type InstanceService interface {
 	Create(ctx context.Context, instanceReq *InstanceCreateReq) (*Instance, *http.Response, error)
 	Get(ctx context.Context, instanceID string) (*Instance, *http.Response, error)
+	WaitForStatus(ctx context.Context, instanceID, status string, options *WaitOptions) (*Instance, *http.Response, error)
+	EnableIPv6(ctx context.Context, instanceID string, options *WaitOptions) (*Instance, *http.Response, error)
 	Update(ctx context.Context, instanceID string, instanceReq *InstanceUpdateReq) (*Instance, *http.Response, error)
 	Delete(ctx context.Context, instanceID string) error
 	List(ctx context.Context, options *ListOptions) ([]Instance, *Meta, *http.Response, error)
@@ -23,6 +32,7 @@ type InstanceService interface {
 	Halt(ctx context.Context, instanceID string) error
 	Reboot(ctx context.Context, instanceID string) error
 	Reinstall(ctx context.Context, instanceID string, reinstallReq *ReinstallReq) (*Instance, *http.Response, error)
+	ReinstallAndWait(ctx context.Context, instanceID string, reinstallOptions *ReinstallOptions, options *WaitOptions) (*Instance, *http.Response, error) //nolint:lll
 
 	MassStart(ctx context.Context, instanceList []string) error
 	MassHalt(ctx context.Context, instanceList []string) error
@@ -70,6 +80,8 @@ type InstanceService interface {
 	GetUserData(ctx context.Context, instanceID string) (*UserData, *http.Response, error)
 
 	GetUpgrades(ctx context.Context, instanceID string) (*Upgrades, *http.Response, error)
+
+	AuditImages(ctx context.Context) ([]InstanceImageAudit, error)
 }
 
 // InstanceServiceHandler handles interaction with the server methods for the Vultr API
@@ -112,6 +124,27 @@ type Instance struct {
 	Tags            []string `json:"tags"`
 }
 
+// InstanceImageAudit identifies an instance built from an operating system or
+// application that is no longer offered
+type InstanceImageAudit struct {
+	InstanceID string
+	Label      string
+	Os         string
+	OsID       int
+	AppID      int
+	ImageID    string
+}
+
+// HasFeature reports whether a feature, such as "ddos_protection" or "auto_backups", is enabled on the instance
+func (i *Instance) HasFeature(feature string) bool {
+	for _, f := range i.Features {
+		if f == feature {
+			return true
+		}
+	}
+	return false
+}
+
 type instanceBase struct {
 	Instance *Instance `json:"instance"`
 }
@@ -138,6 +171,43 @@ type Bandwidth struct {
 	} `json:"bandwidth"`
 }
 
+// BandwidthSample represents a single day of bandwidth usage
+type BandwidthSample struct {
+	Date          time.Time
+	IncomingBytes int
+	OutgoingBytes int
+}
+
+// Samples returns the bandwidth usage as a time series sorted by date, limited
+// to the days between start and end inclusive. A zero start or end leaves that
+// side of the period open. Daily network usage is the only monitoring data the
+// API currently exposes for instances and Bare Metal servers.
+func (b *Bandwidth) Samples(start, end time.Time) ([]BandwidthSample, error) {
+	samples := make([]BandwidthSample, 0, len(b.Bandwidth))
+	for day, usage := range b.Bandwidth {
+		date, err := time.Parse(time.DateOnly, day)
+		if err != nil {
+			return nil, err
+		}
+
+		if (!start.IsZero() && date.Before(start)) || (!end.IsZero() && date.After(end)) {
+			continue
+		}
+
+		samples = append(samples, BandwidthSample{
+			Date:          date,
+			IncomingBytes: usage.IncomingBytes,
+			OutgoingBytes: usage.OutgoingBytes,
+		})
+	}
+
+	sort.Slice(samples, func(i, j int) bool {
+		return samples[i].Date.Before(samples[j].Date)
+	})
+
+	return samples, nil
+}
+
 type privateNetworksBase struct {
 	PrivateNetworks []PrivateNetwork `json:"private_networks"`
 	Meta            *Meta            `json:"meta"`
@@ -251,6 +321,41 @@ type Upgrades struct {
 	Plans        []string      `json:"plans,omitempty"`
 }
 
+// HasOS reports whether the operating system is an available upgrade
+func (u *Upgrades) HasOS(osID int) bool {
+	for i := range u.OS {
+		if u.OS[i].ID == osID {
+			return true
+		}
+	}
+	return false
+}
+
+// HasApplication reports whether the application is an available upgrade
+func (u *Upgrades) HasApplication(appID int) bool {
+	for i := range u.Applications {
+		if u.Applications[i].ID == appID {
+			return true
+		}
+	}
+	return false
+}
+
+// HasPlan reports whether the plan is an available upgrade
+func (u *Upgrades) HasPlan(plan string) bool {
+	for i := range u.Plans {
+		if u.Plans[i] == plan {
+			return true
+		}
+	}
+	return false
+}
+
+// HasIPv6 reports whether an IPv6 address has been assigned to the instance
+func (i *Instance) HasIPv6() bool {
+	return i.V6MainIP != ""
+}
+
 // InstanceCreateReq struct used to create an instance.
 type InstanceCreateReq struct {
 	Region string `json:"region,omitempty"`
@@ -321,6 +426,13 @@ type ReinstallReq struct {
 	Hostname string `json:"hostname,omitempty"`
 }
 
+// ReinstallOptions used by ReinstallAndWait. UserData must be base64 encoded
+// and is applied to the instance before the reinstall is started.
+type ReinstallOptions struct {
+	Hostname string
+	UserData string
+}
+
 // Create will create the server with the given parameters
 func (i *InstanceServiceHandler) Create(ctx context.Context, instanceReq *InstanceCreateReq) (*Instance, *http.Response, error) {
 	req, err := i.client.NewRequest(ctx, http.MethodPost, instancePath, instanceReq)
@@ -355,6 +467,52 @@ func (i *InstanceServiceHandler) Get(ctx context.Context, instanceID string) (*I
 	return instance.Instance, resp, nil
 }
 
+// WaitForStatus polls an instance until its status, power status or server
+// status matches status, such as "active", "stopped" or "ok"
+func (i *InstanceServiceHandler) WaitForStatus(ctx context.Context, instanceID, status string, options *WaitOptions) (*Instance, *http.Response, error) { //nolint:lll
+	return waitForStatus(ctx, options, status, func(ctx context.Context) (*Instance, *http.Response, error) {
+		return i.Get(ctx, instanceID)
+	}, func(r *Instance) []string {
+		return []string{r.Status, r.PowerStatus, r.ServerStatus}
+	})
+}
+
+// EnableIPv6 enables IPv6 on an existing instance and polls until its IPv6
+// address has been assigned. Nothing is sent when the instance already has
+// IPv6. Public IPv4 can only be disabled when an instance is created.
+func (i *InstanceServiceHandler) EnableIPv6(ctx context.Context, instanceID string, options *WaitOptions) (*Instance, *http.Response, error) { //nolint:lll
+	instance, resp, err := i.Get(ctx, instanceID)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	if instance.HasIPv6() {
+		return instance, resp, nil
+	}
+
+	if _, resp, err = i.Update(ctx, instanceID, &InstanceUpdateReq{EnableIPv6: BoolToBoolPtr(true)}); err != nil {
+		return nil, resp, err
+	}
+
+	err = waitFor(ctx, options, func(ctx context.Context) (string, bool, error) {
+		var errGet error
+		instance, resp, errGet = i.Get(ctx, instanceID)
+		if errGet != nil {
+			return "", false, errGet
+		}
+
+		if !instance.HasIPv6() {
+			return "ipv6 pending", false, nil
+		}
+		return "ipv6 assigned", true, nil
+	})
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return instance, resp, nil
+}
+
 // Update will update the server with the given parameters
 func (i *InstanceServiceHandler) Update(ctx context.Context, instanceID string, instanceReq *InstanceUpdateReq) (*Instance, *http.Response, error) { //nolint:lll
 	uri := fmt.Sprintf("%s/%s", instancePath, instanceID)
@@ -373,7 +531,7 @@ func (i *InstanceServiceHandler) Update(ctx context.Context, instanceID string,
 	return instance.Instance, resp, nil
 }
 
-// Delete an instance. All data will be permanently lost, and the IP address will be released
+// Delete an instance. All data will be permanently lost, and the IP address will be released.
 func (i *InstanceServiceHandler) Delete(ctx context.Context, instanceID string) error {
 	uri := fmt.Sprintf("%s/%s", instancePath, instanceID)
 
@@ -462,6 +620,65 @@ func (i *InstanceServiceHandler) Reinstall(ctx context.Context, instanceID strin
 	return instance.Instance, resp, nil
 }
 
+// ReinstallAndWait sets the user data if provided, reinstalls the instance and
+// polls until it is active again. Progress, if set, is called with the server
+// status after every poll.
+func (i *InstanceServiceHandler) ReinstallAndWait(ctx context.Context, instanceID string, reinstallOptions *ReinstallOptions, options *WaitOptions) (*Instance, *http.Response, error) { //nolint:lll
+	reinstallReq := &ReinstallReq{}
+	if reinstallOptions != nil {
+		reinstallReq.Hostname = reinstallOptions.Hostname
+
+		if reinstallOptions.UserData != "" {
+			if _, resp, err := i.Update(ctx, instanceID, &InstanceUpdateReq{UserData: reinstallOptions.UserData}); err != nil {
+				return nil, resp, err
+			}
+		}
+	}
+
+	instance, resp, err := i.Reinstall(ctx, instanceID, reinstallReq)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	started := !instanceReady(instance)
+	return waitForRebuild(ctx, options, started, func(ctx context.Context) (*Instance, *http.Response, error) {
+		return i.Get(ctx, instanceID)
+	})
+}
+
+// instanceReady reports whether an instance is active with a server status of ok
+func instanceReady(instance *Instance) bool {
+	return instance.Status == instanceStatusActive && instance.ServerStatus == instanceServerStatusOK
+}
+
+// waitForRebuild polls an instance being reinstalled or restored until it is
+// ready again. The instance must first be seen not ready, unless started is
+// set, so the wait cannot end before the rebuild has begun.
+func waitForRebuild(ctx context.Context, options *WaitOptions, started bool, get func(ctx context.Context) (*Instance, *http.Response, error)) (*Instance, *http.Response, error) { //nolint:lll
+	var instance *Instance
+	var resp *http.Response
+	err := waitFor(ctx, options, func(ctx context.Context) (string, bool, error) {
+		var errGet error
+		instance, resp, errGet = get(ctx)
+		if errGet != nil {
+			return "", false, errGet
+		}
+
+		ready := instanceReady(instance)
+		if !started {
+			started = !ready
+			return instance.ServerStatus + " (waiting for the rebuild to start)", false, nil
+		}
+
+		return instance.ServerStatus, ready, nil
+	})
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return instance, resp, nil
+}
+
 // MassStart will start a list of instances the machine is already running, it will be restarted.
 func (i *InstanceServiceHandler) MassStart(ctx context.Context, instanceList []string) error {
 	uri := fmt.Sprintf("%s/start", instancePath)
@@ -948,3 +1165,64 @@ func (i *InstanceServiceHandler) GetUpgrades(ctx context.Context, instanceID str
 
 	return upgrades.Upgrades, resp, nil
 }
+
+// AuditImages returns the instances on the account whose operating system or
+// application no longer appears in the OS or application catalog. The API
+// does not publish deprecation or end of life dates, so removal from the
+// catalog is the only signal available.
+func (i *InstanceServiceHandler) AuditImages(ctx context.Context) ([]InstanceImageAudit, error) {
+	oses, err := collectPages(ctx, i.client.OS.List)
+	if err != nil {
+		return nil, err
+	}
+
+	apps, err := collectPages(ctx, i.client.Application.List)
+	if err != nil {
+		return nil, err
+	}
+
+	instances, err := collectPages(ctx, i.List)
+	if err != nil {
+		return nil, err
+	}
+
+	osIDs := make(map[int]bool, len(oses))
+	for j := range oses {
+		osIDs[oses[j].ID] = true
+	}
+
+	appIDs := make(map[int]bool, len(apps))
+	imageIDs := make(map[string]bool, len(apps))
+	for j := range apps {
+		appIDs[apps[j].ID] = true
+		if apps[j].ImageID != "" {
+			imageIDs[apps[j].ImageID] = true
+		}
+	}
+
+	var audits []InstanceImageAudit
+	for j := range instances {
+		instance := &instances[j]
+
+		offered := osIDs[instance.OsID]
+		if instance.AppID != 0 && !appIDs[instance.AppID] {
+			offered = false
+		}
+		if instance.ImageID != "" && !imageIDs[instance.ImageID] {
+			offered = false
+		}
+
+		if !offered {
+			audits = append(audits, InstanceImageAudit{
+				InstanceID: instance.ID,
+				Label:      instance.Label,
+				Os:         instance.Os,
+				OsID:       instance.OsID,
+				AppID:      instance.AppID,
+				ImageID:    instance.ImageID,
+			})
+		}
+	}
+
+	return audits, nil
+}
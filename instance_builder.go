@@ -0,0 +1,169 @@
+package govultr
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ipxeOSID is the "Custom" operating system used when booting from iPXE
+const ipxeOSID = 159
+
+// InstanceBuilder builds an InstanceCreateReq, ensuring that the region, plan
+// and exactly one installation source are set. Create one with NewInstanceBuilder.
+type InstanceBuilder struct {
+	req    InstanceCreateReq
+	source string
+	err    error
+}
+
+// NewInstanceBuilder returns an InstanceBuilder for a new instance
+func NewInstanceBuilder() *InstanceBuilder {
+	return &InstanceBuilder{}
+}
+
+// Region sets the region the instance is deployed in
+func (b *InstanceBuilder) Region(region string) *InstanceBuilder {
+	b.req.Region = region
+	return b
+}
+
+// Plan sets the plan of the instance
+func (b *InstanceBuilder) Plan(plan string) *InstanceBuilder {
+	b.req.Plan = plan
+	return b
+}
+
+// Label sets the label of the instance
+func (b *InstanceBuilder) Label(label string) *InstanceBuilder {
+	b.req.Label = label
+	return b
+}
+
+// Hostname sets the hostname of the instance
+func (b *InstanceBuilder) Hostname(hostname string) *InstanceBuilder {
+	b.req.Hostname = hostname
+	return b
+}
+
+// Tags adds tags to the instance
+func (b *InstanceBuilder) Tags(tags ...string) *InstanceBuilder {
+	b.req.Tags = append(b.req.Tags, tags...)
+	return b
+}
+
+// SSHKeys adds SSH keys to the instance
+func (b *InstanceBuilder) SSHKeys(sshKeyIDs ...string) *InstanceBuilder {
+	b.req.SSHKeys = append(b.req.SSHKeys, sshKeyIDs...)
+	return b
+}
+
+// StartupScript sets the startup script run on the instance
+func (b *InstanceBuilder) StartupScript(scriptID string) *InstanceBuilder {
+	b.req.ScriptID = scriptID
+	return b
+}
+
+// UserData sets the base64 encoded user data of the instance
+func (b *InstanceBuilder) UserData(userData string) *InstanceBuilder {
+	b.req.UserData = userData
+	return b
+}
+
+// FirewallGroup sets the firewall group the instance is a member of
+func (b *InstanceBuilder) FirewallGroup(firewallGroupID string) *InstanceBuilder {
+	b.req.FirewallGroupID = firewallGroupID
+	return b
+}
+
+// VPCs attaches the instance to VPCs
+func (b *InstanceBuilder) VPCs(vpcIDs ...string) *InstanceBuilder {
+	b.req.AttachVPC = append(b.req.AttachVPC, vpcIDs...)
+	return b
+}
+
+// EnableIPv6 enables IPv6 on the instance
+func (b *InstanceBuilder) EnableIPv6() *InstanceBuilder {
+	b.req.EnableIPv6 = BoolToBoolPtr(true)
+	return b
+}
+
+// FromOS installs an operating system on the instance
+func (b *InstanceBuilder) FromOS(osID int) *InstanceBuilder {
+	if b.setSource("os") {
+		b.req.OsID = osID
+	}
+	return b
+}
+
+// FromSnapshot restores a snapshot on the instance
+func (b *InstanceBuilder) FromSnapshot(snapshotID string) *InstanceBuilder {
+	if b.setSource("snapshot") {
+		b.req.SnapshotID = snapshotID
+	}
+	return b
+}
+
+// FromISO boots the instance from an ISO
+func (b *InstanceBuilder) FromISO(isoID string) *InstanceBuilder {
+	if b.setSource("iso") {
+		b.req.ISOID = isoID
+	}
+	return b
+}
+
+// FromApp installs a one-click application on the instance
+func (b *InstanceBuilder) FromApp(appID int) *InstanceBuilder {
+	if b.setSource("app") {
+		b.req.AppID = appID
+	}
+	return b
+}
+
+// FromImage installs a marketplace image on the instance
+func (b *InstanceBuilder) FromImage(imageID string) *InstanceBuilder {
+	if b.setSource("image") {
+		b.req.ImageID = imageID
+	}
+	return b
+}
+
+// FromIPXE boots the instance from an iPXE chain URL
+func (b *InstanceBuilder) FromIPXE(chainURL string) *InstanceBuilder {
+	if b.setSource("ipxe") {
+		b.req.OsID = ipxeOSID
+		b.req.IPXEChainURL = chainURL
+	}
+	return b
+}
+
+// Build returns the InstanceCreateReq, or an error if the region, plan or
+// installation source is missing or more than one source was set
+func (b *InstanceBuilder) Build() (*InstanceCreateReq, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+
+	if b.req.Region == "" {
+		return nil, errors.New("instance region is required")
+	}
+
+	if b.req.Plan == "" {
+		return nil, errors.New("instance plan is required")
+	}
+
+	if b.source == "" {
+		return nil, errors.New("instance installation source is required")
+	}
+
+	req := b.req
+	return &req, nil
+}
+
+func (b *InstanceBuilder) setSource(source string) bool {
+	if b.source != "" && b.err == nil {
+		b.err = fmt.Errorf("instance installation source is already %s, cannot also use %s", b.source, source)
+	}
+
+	b.source = source
+	return b.err == nil
+}
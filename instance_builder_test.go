@@ -0,0 +1,59 @@
+package govultr
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestInstanceBuilder_Build(t *testing.T) {
+	req, err := NewInstanceBuilder().
+		Region("ewr").
+		Plan("vc2-1c-1gb").
+		Label("web").
+		Tags("prod", "web").
+		SSHKeys("key-1").
+		EnableIPv6().
+		FromSnapshot("snap-1").
+		Build()
+	if err != nil {
+		t.Errorf("InstanceBuilder.Build returned %+v", err)
+	}
+
+	expected := &InstanceCreateReq{
+		Region:     "ewr",
+		Plan:       "vc2-1c-1gb",
+		Label:      "web",
+		Tags:       []string{"prod", "web"},
+		SSHKeys:    []string{"key-1"},
+		EnableIPv6: BoolToBoolPtr(true),
+		SnapshotID: "snap-1",
+	}
+
+	if !reflect.DeepEqual(req, expected) {
+		t.Errorf("InstanceBuilder.Build returned %+v, expected %+v", req, expected)
+	}
+
+	req, err = NewInstanceBuilder().Region("ewr").Plan("vc2-1c-1gb").FromIPXE("https://example.com/boot.ipxe").Build()
+	if err != nil {
+		t.Errorf("InstanceBuilder.Build returned %+v", err)
+	}
+
+	if req.OsID != ipxeOSID || req.IPXEChainURL != "https://example.com/boot.ipxe" {
+		t.Errorf("InstanceBuilder.Build returned %+v, expected the custom OS and chain URL", req)
+	}
+}
+
+func TestInstanceBuilder_BuildInvalid(t *testing.T) {
+	tests := map[string]*InstanceBuilder{
+		"no region":        NewInstanceBuilder().Plan("vc2-1c-1gb").FromOS(387),
+		"no plan":          NewInstanceBuilder().Region("ewr").FromOS(387),
+		"no source":        NewInstanceBuilder().Region("ewr").Plan("vc2-1c-1gb"),
+		"multiple sources": NewInstanceBuilder().Region("ewr").Plan("vc2-1c-1gb").FromOS(387).FromSnapshot("snap-1"),
+	}
+
+	for name, builder := range tests {
+		if req, err := builder.Build(); err == nil {
+			t.Errorf("InstanceBuilder.Build with %s returned %+v, expected an error", name, req)
+		}
+	}
+}
@@ -1,11 +1,14 @@
 package govultr
 
 import (
+	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"reflect"
+	"strings"
 	"testing"
+	"time"
 )
 
 const (
@@ -1269,3 +1272,198 @@ func TestServerServiceHandler_CreateMarketplace(t *testing.T) {
 		t.Errorf("Instance.Create returned %+v, expected %+v", server, expected)
 	}
 }
+
+func TestBandwidth_Samples(t *testing.T) {
+	bandwidth := &Bandwidth{
+		Bandwidth: map[string]struct {
+			IncomingBytes int `json:"incoming_bytes"`
+			OutgoingBytes int `json:"outgoing_bytes"`
+		}{
+			"2017-04-03": {IncomingBytes: 3, OutgoingBytes: 30},
+			"2017-04-01": {IncomingBytes: 1, OutgoingBytes: 10},
+			"2017-04-02": {IncomingBytes: 2, OutgoingBytes: 20},
+		},
+	}
+
+	samples, err := bandwidth.Samples(time.Date(2017, 4, 2, 0, 0, 0, 0, time.UTC), time.Time{})
+	if err != nil {
+		t.Errorf("Bandwidth.Samples returned %+v", err)
+	}
+
+	expected := []BandwidthSample{
+		{Date: time.Date(2017, 4, 2, 0, 0, 0, 0, time.UTC), IncomingBytes: 2, OutgoingBytes: 20},
+		{Date: time.Date(2017, 4, 3, 0, 0, 0, 0, time.UTC), IncomingBytes: 3, OutgoingBytes: 30},
+	}
+
+	if !reflect.DeepEqual(samples, expected) {
+		t.Errorf("Bandwidth.Samples returned %+v, expected %+v", samples, expected)
+	}
+}
+
+func TestServerServiceHandler_ReinstallAndWait(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var update InstanceUpdateReq
+	var reinstall ReinstallReq
+	gets := 0
+	rebuilding := false
+	mux.HandleFunc("/v2/instances/14b3e7d6-ffb5-4994-8502-57fcd9db3b33", func(writer http.ResponseWriter, request *http.Request) {
+		if request.Method == http.MethodPatch {
+			json.NewDecoder(request.Body).Decode(&update)
+		}
+
+		if request.Method == http.MethodGet {
+			gets++
+			if rebuilding {
+				rebuilding = false
+				fmt.Fprint(writer, strings.Replace(defaultInstanceListResponse, `"server_status": "ok"`, `"server_status": "installingbooting"`, 1))
+				return
+			}
+		}
+		fmt.Fprint(writer, defaultInstanceListResponse)
+	})
+
+	mux.HandleFunc("/v2/instances/14b3e7d6-ffb5-4994-8502-57fcd9db3b33/reinstall", func(writer http.ResponseWriter, request *http.Request) {
+		json.NewDecoder(request.Body).Decode(&reinstall)
+		rebuilding = true
+		fmt.Fprint(writer, defaultInstanceListResponse)
+	})
+
+	reinstallOptions := &ReinstallOptions{Hostname: "web-01", UserData: "I2Nsb3VkLWNvbmZpZw=="}
+	instance, _, err := client.Instance.ReinstallAndWait(ctx, "14b3e7d6-ffb5-4994-8502-57fcd9db3b33", reinstallOptions, &WaitOptions{Interval: time.Millisecond})
+	if err != nil {
+		t.Errorf("Instance.ReinstallAndWait returned %+v", err)
+	}
+
+	if instance.ServerStatus != "ok" {
+		t.Errorf("Instance.ReinstallAndWait returned server status %s, expected ok", instance.ServerStatus)
+	}
+
+	if gets != 2 {
+		t.Errorf("Instance.ReinstallAndWait polled %d times, expected to wait for the reinstall to start and finish", gets)
+	}
+
+	if update.UserData != reinstallOptions.UserData {
+		t.Errorf("Instance.ReinstallAndWait sent user data %s, expected %s", update.UserData, reinstallOptions.UserData)
+	}
+
+	if reinstall.Hostname != reinstallOptions.Hostname {
+		t.Errorf("Instance.ReinstallAndWait sent hostname %s, expected %s", reinstall.Hostname, reinstallOptions.Hostname)
+	}
+}
+
+func TestServerServiceHandler_AuditImages(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v2/os", func(writer http.ResponseWriter, request *http.Request) {
+		fmt.Fprint(writer, `{"os": [{"id": 387, "name": "Ubuntu 20.04 x64"}, {"id": 186, "name": "Application"}], "meta": {"total": 2, "links": {"next": "", "prev": ""}}}`)
+	})
+
+	mux.HandleFunc("/v2/applications", func(writer http.ResponseWriter, request *http.Request) {
+		fmt.Fprint(writer, `{"applications": [{"id": 1, "name": "LEMP"}, {"id": 2, "name": "WordPress", "image_id": "wordpress"}], "meta": {"total": 2, "links": {"next": "", "prev": ""}}}`)
+	})
+
+	mux.HandleFunc("/v2/instances", func(writer http.ResponseWriter, request *http.Request) {
+		fmt.Fprint(writer, `{"instances": [
+			{"id": "a", "label": "current", "os": "Ubuntu 20.04 x64", "os_id": 387},
+			{"id": "b", "label": "retired-os", "os": "CentOS 6 x64", "os_id": 147},
+			{"id": "c", "label": "app", "os": "Application", "os_id": 186, "app_id": 1},
+			{"id": "d", "label": "retired-image", "os": "Application", "os_id": 186, "app_id": 2, "image_id": "joomla"}
+		], "meta": {"total": 4, "links": {"next": "", "prev": ""}}}`)
+	})
+
+	audits, err := client.Instance.AuditImages(ctx)
+	if err != nil {
+		t.Errorf("Instance.AuditImages returned %+v", err)
+	}
+
+	expected := []InstanceImageAudit{
+		{InstanceID: "b", Label: "retired-os", Os: "CentOS 6 x64", OsID: 147},
+		{InstanceID: "d", Label: "retired-image", Os: "Application", OsID: 186, AppID: 2, ImageID: "joomla"},
+	}
+
+	if !reflect.DeepEqual(audits, expected) {
+		t.Errorf("Instance.AuditImages returned %+v, expected %+v", audits, expected)
+	}
+}
+
+func TestServerServiceHandler_WaitForStatus(t *testing.T) {
+	setup()
+	defer teardown()
+
+	gets := 0
+	mux.HandleFunc("/v2/instances/14b3e7d6-ffb5-4994-8502-57fcd9db3b33", func(writer http.ResponseWriter, request *http.Request) {
+		gets++
+		powerStatus := "running"
+		if gets > 2 {
+			powerStatus = "stopped"
+		}
+		fmt.Fprintf(writer, `{"instance": {"id": "14b3e7d6-ffb5-4994-8502-57fcd9db3b33", "status": "active", "power_status": "%s", "server_status": "ok"}}`, powerStatus)
+	})
+
+	var statuses []string
+	options := &WaitOptions{
+		Interval: time.Millisecond,
+		Backoff:  2,
+		Progress: func(status string, elapsed time.Duration) {
+			statuses = append(statuses, status)
+		},
+	}
+
+	instance, _, err := client.Instance.WaitForStatus(ctx, "14b3e7d6-ffb5-4994-8502-57fcd9db3b33", "Stopped", options)
+	if err != nil {
+		t.Fatalf("Instance.WaitForStatus returned %+v", err)
+	}
+
+	if instance.PowerStatus != "stopped" {
+		t.Errorf("Instance.WaitForStatus returned %+v", instance)
+	}
+
+	expected := []string{"active/running/ok", "active/running/ok", "active/stopped/ok"}
+	if !reflect.DeepEqual(statuses, expected) {
+		t.Errorf("Instance.WaitForStatus reported %+v, expected %+v", statuses, expected)
+	}
+}
+
+func TestServerServiceHandler_EnableIPv6(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var patches []map[string]interface{}
+	gets := 0
+	mux.HandleFunc("/v2/instances/abc", func(writer http.ResponseWriter, request *http.Request) {
+		if request.Method == http.MethodPatch {
+			body := map[string]interface{}{}
+			json.NewDecoder(request.Body).Decode(&body)
+			patches = append(patches, body)
+			fmt.Fprint(writer, `{"instance": {"id": "abc"}}`)
+			return
+		}
+
+		gets++
+		v6 := ""
+		if gets > 2 {
+			v6 = "2001:db8::1"
+		}
+		fmt.Fprintf(writer, `{"instance": {"id": "abc", "v6_main_ip": "%s"}}`, v6)
+	})
+
+	instance, _, err := client.Instance.EnableIPv6(ctx, "abc", &WaitOptions{Interval: time.Millisecond})
+	if err != nil {
+		t.Fatalf("Instance.EnableIPv6 returned %+v", err)
+	}
+
+	if !instance.HasIPv6() || instance.V6MainIP != "2001:db8::1" {
+		t.Errorf("Instance.EnableIPv6 returned %+v", instance)
+	}
+
+	if len(patches) != 1 || patches[0]["enable_ipv6"] != true {
+		t.Errorf("Instance.EnableIPv6 sent %+v, expected a single update enabling IPv6", patches)
+	}
+
+	if _, _, err = client.Instance.EnableIPv6(ctx, "abc", nil); err != nil || len(patches) != 1 {
+		t.Errorf("Instance.EnableIPv6 returned %+v and sent %d patches, expected no new patch", err, len(patches))
+	}
+}
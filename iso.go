@@ -4,6 +4,9 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/google/go-querystring/query"
 )
@@ -16,8 +19,17 @@ type ISOService interface {
 	Delete(ctx context.Context, isoID string) error
 	List(ctx context.Context, options *ListOptions) ([]ISO, *Meta, *http.Response, error)
 	ListPublic(ctx context.Context, options *ListOptions) ([]PublicISO, *Meta, *http.Response, error)
+
+	VerifyChecksum(ctx context.Context, isoID, algo, expected string) (bool, error)
+	Usage(ctx context.Context) (*ISOUsage, error)
 }
 
+// Checksum algorithms accepted by VerifyChecksum
+const (
+	ISOChecksumMD5    = "md5"
+	ISOChecksumSHA512 = "sha512"
+)
+
 // ISOServiceHandler handles interaction with the ISO methods for the Vultr API
 type ISOServiceHandler struct {
 	client *Client
@@ -34,6 +46,14 @@ type ISO struct {
 	Status      string `json:"status"`
 }
 
+// ISOUsage represents the private ISO storage used by the account. ISOs are
+// ordered oldest first so cleanup can start from the front.
+type ISOUsage struct {
+	Count     int
+	TotalSize int
+	ISOs      []ISO
+}
+
 // PublicISO represents public ISOs offered in the Vultr ISO library.
 type PublicISO struct {
 	ID          string `json:"id"`
@@ -158,3 +178,78 @@ func (i *ISOServiceHandler) ListPublic(ctx context.Context, options *ListOptions
 
 	return iso.PublicIsos, iso.Meta, resp, nil
 }
+
+// VerifyChecksum reports whether the checksum Vultr computed for an ISO using
+// the given algorithm matches the expected value
+func (i *ISOServiceHandler) VerifyChecksum(ctx context.Context, isoID, algo, expected string) (bool, error) {
+	iso, _, err := i.Get(ctx, isoID)
+	if err != nil {
+		return false, err
+	}
+
+	var sum string
+	switch algo {
+	case ISOChecksumMD5:
+		sum = iso.MD5Sum
+	case ISOChecksumSHA512:
+		sum = iso.SHA512Sum
+	default:
+		return false, fmt.Errorf("unsupported checksum algorithm %q", algo)
+	}
+
+	if sum == "" {
+		return false, fmt.Errorf("ISO %s has no %s checksum, it may still be pending", isoID, algo)
+	}
+
+	return strings.EqualFold(sum, strings.TrimSpace(expected)), nil
+}
+
+// Created returns the time the ISO was created
+func (i *ISO) Created() (time.Time, error) {
+	if created, err := time.Parse(time.RFC3339, i.DateCreated); err == nil {
+		return created, nil
+	}
+	return time.Parse(time.DateTime, i.DateCreated)
+}
+
+// Usage returns the number and total size in bytes of the private ISOs on the
+// account. The API does not publish an ISO storage limit so none is reported.
+func (i *ISOServiceHandler) Usage(ctx context.Context) (*ISOUsage, error) {
+	isos, err := collectPages(ctx, i.List)
+	if err != nil {
+		return nil, err
+	}
+
+	usage := &ISOUsage{Count: len(isos), ISOs: isos}
+	created := make(map[string]time.Time, len(isos))
+	for j := range isos {
+		usage.TotalSize += isos[j].Size
+
+		date, err := isos[j].Created()
+		if err != nil {
+			return nil, fmt.Errorf("ISO %s: %w", isos[j].ID, err)
+		}
+		created[isos[j].ID] = date
+	}
+
+	sort.SliceStable(usage.ISOs, func(a, b int) bool {
+		return created[usage.ISOs[a].ID].Before(created[usage.ISOs[b].ID])
+	})
+
+	return usage, nil
+}
+
+// FilterPublicISOs returns the public ISOs whose name or description contains
+// term, ignoring case, e.g. "debian" to find every Debian release
+func FilterPublicISOs(isos []PublicISO, term string) []PublicISO {
+	term = strings.ToLower(term)
+
+	var filtered []PublicISO
+	for j := range isos {
+		if strings.Contains(strings.ToLower(isos[j].Name), term) || strings.Contains(strings.ToLower(isos[j].Description), term) {
+			filtered = append(filtered, isos[j])
+		}
+	}
+
+	return filtered
+}
@@ -149,3 +149,69 @@ func TestIsoServiceHandler_ListPublic(t *testing.T) {
 		t.Errorf("Iso.ListPublic meta returned %+v, expected %+v", meta, expectedMeta)
 	}
 }
+
+func TestIsoServiceHandler_VerifyChecksum(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v2/iso/24", func(writer http.ResponseWriter, request *http.Request) {
+		fmt.Fprint(writer, `{"iso": {"id": "24", "md5sum": "ABC123", "sha512sum": "def456", "status": "complete"}}`)
+	})
+
+	ok, err := client.ISO.VerifyChecksum(ctx, "24", ISOChecksumMD5, "abc123")
+	if err != nil || !ok {
+		t.Errorf("ISO.VerifyChecksum returned %v, %+v, expected a match", ok, err)
+	}
+
+	ok, err = client.ISO.VerifyChecksum(ctx, "24", ISOChecksumSHA512, "abc123")
+	if err != nil || ok {
+		t.Errorf("ISO.VerifyChecksum returned %v, %+v, expected a mismatch", ok, err)
+	}
+
+	if _, err = client.ISO.VerifyChecksum(ctx, "24", "crc32", "abc123"); err == nil {
+		t.Error("ISO.VerifyChecksum expected an error for an unsupported algorithm")
+	}
+}
+
+func TestFilterPublicISOs(t *testing.T) {
+	isos := []PublicISO{
+		{ID: "1", Name: "Debian 12", Description: "bookworm"},
+		{ID: "2", Name: "Ubuntu 22.04", Description: "jammy"},
+		{ID: "3", Name: "Finnix", Description: "Debian based rescue"},
+	}
+
+	expected := []PublicISO{isos[0], isos[2]}
+	if filtered := FilterPublicISOs(isos, "DEBIAN"); !reflect.DeepEqual(filtered, expected) {
+		t.Errorf("FilterPublicISOs returned %+v, expected %+v", filtered, expected)
+	}
+}
+
+func TestIsoServiceHandler_Usage(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v2/iso", func(writer http.ResponseWriter, request *http.Request) {
+		fmt.Fprint(writer, `{"isos": [
+			{"id": "new", "date_created": "2024-03-01T00:00:00+00:00", "size": 300},
+			{"id": "old", "date_created": "2023-01-01 00:00:00", "size": 700}
+		], "meta": {"total": 2, "links": {"next": "", "prev": ""}}}`)
+	})
+
+	usage, err := client.ISO.Usage(ctx)
+	if err != nil {
+		t.Errorf("ISO.Usage returned %+v", err)
+	}
+
+	expected := &ISOUsage{
+		Count:     2,
+		TotalSize: 1000,
+		ISOs: []ISO{
+			{ID: "old", DateCreated: "2023-01-01 00:00:00", Size: 700},
+			{ID: "new", DateCreated: "2024-03-01T00:00:00+00:00", Size: 300},
+		},
+	}
+
+	if !reflect.DeepEqual(usage, expected) {
+		t.Errorf("ISO.Usage returned %+v, expected %+v", usage, expected)
+	}
+}
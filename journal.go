@@ -0,0 +1,103 @@
+package govultr
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// JournalEntry represents a single mutating request recorded by the journal
+type JournalEntry struct {
+	Sequence   uint64
+	Time       time.Time
+	Method     string
+	Path       string
+	BodyHash   string
+	StatusCode int
+	Error      string
+}
+
+// JournalStore is the interface used to persist journal entries
+type JournalStore interface {
+	Append(ctx context.Context, entry JournalEntry) error
+}
+
+// MemoryJournal is a JournalStore that keeps all entries in memory
+type MemoryJournal struct {
+	mu      sync.Mutex
+	entries []JournalEntry
+}
+
+// Append adds an entry to the journal
+func (m *MemoryJournal) Append(_ context.Context, entry JournalEntry) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.entries = append(m.entries, entry)
+	return nil
+}
+
+// Entries returns a copy of all entries recorded so far
+func (m *MemoryJournal) Entries() []JournalEntry {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	entries := make([]JournalEntry, len(m.entries))
+	copy(entries, m.entries)
+	return entries
+}
+
+// SetJournal records every mutating request (anything other than GET and HEAD)
+// to the given store. While a journal is set mutating requests are sent one at
+// a time so sequence numbers match the order changes were applied.
+func (c *Client) SetJournal(store JournalStore) {
+	c.journal = store
+}
+
+func isMutation(method string) bool {
+	return method != http.MethodGet && method != http.MethodHead
+}
+
+// doJournaled sends a mutating request and records the outcome in the journal
+func (c *Client) doJournaled(ctx context.Context, r *http.Request, data interface{}) (*http.Response, error) {
+	c.journalMu.Lock()
+	defer c.journalMu.Unlock()
+
+	hash := sha256.New()
+	if r.GetBody != nil {
+		body, err := r.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		if _, err = io.Copy(hash, body); err != nil {
+			return nil, err
+		}
+	}
+
+	c.journalSeq++
+	entry := JournalEntry{
+		Sequence: c.journalSeq,
+		Time:     time.Now(),
+		Method:   r.Method,
+		Path:     r.URL.Path,
+		BodyHash: hex.EncodeToString(hash.Sum(nil)),
+	}
+
+	res, err := c.do(ctx, r, data)
+	if res != nil {
+		entry.StatusCode = res.StatusCode
+	}
+	if err != nil {
+		entry.Error = err.Error()
+	}
+
+	if errJournal := c.journal.Append(ctx, entry); errJournal != nil && err == nil {
+		return res, errJournal
+	}
+
+	return res, err
+}
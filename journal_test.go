@@ -0,0 +1,50 @@
+package govultr
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestClient_SetJournal(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v2/ssh-keys", func(writer http.ResponseWriter, request *http.Request) {
+		fmt.Fprint(writer, `{"ssh_key": {"id": "1"}}`)
+	})
+
+	journal := &MemoryJournal{}
+	client.SetJournal(journal)
+
+	if _, _, _, err := client.SSHKey.List(ctx, nil); err != nil {
+		t.Errorf("SSHKey.List returned %+v", err)
+	}
+
+	if _, _, err := client.SSHKey.Create(ctx, &SSHKeyReq{Name: "a"}); err != nil {
+		t.Errorf("SSHKey.Create returned %+v", err)
+	}
+
+	if _, _, err := client.SSHKey.Create(ctx, &SSHKeyReq{Name: "b"}); err != nil {
+		t.Errorf("SSHKey.Create returned %+v", err)
+	}
+
+	entries := journal.Entries()
+	if len(entries) != 2 {
+		t.Fatalf("Journal recorded %d entries, expected 2", len(entries))
+	}
+
+	for i, entry := range entries {
+		if entry.Sequence != uint64(i+1) {
+			t.Errorf("Journal entry %d has sequence %d, expected %d", i, entry.Sequence, i+1)
+		}
+
+		if entry.Method != http.MethodPost || entry.Path != "/v2/ssh-keys" || entry.StatusCode != http.StatusOK {
+			t.Errorf("Journal entry %d recorded %+v", i, entry)
+		}
+	}
+
+	if entries[0].BodyHash == entries[1].BodyHash {
+		t.Errorf("Journal entries expected different body hashes, got %s", entries[0].BodyHash)
+	}
+}
@@ -1,20 +1,62 @@
 package govultr
 
 import (
+	"bufio"
 	"context"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
 	"fmt"
 	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/google/go-querystring/query"
 )
 
 const vkePath = "/v2/kubernetes/clusters"
 
+// Node statuses reported by the Node Status field
+const (
+	NodeStatusPending = "pending"
+	NodeStatusActive  = "active"
+)
+
+// Cluster difference types reported by DiffCluster
+const (
+	ClusterDiffLabel            = "label"
+	ClusterDiffVersion          = "version"
+	ClusterDiffNodePoolMissing  = "node_pool_missing"
+	ClusterDiffNodePoolExtra    = "node_pool_extra"
+	ClusterDiffNodePoolPlan     = "node_pool_plan"
+	ClusterDiffNodePoolQuantity = "node_pool_quantity"
+	ClusterDiffNodePoolScaling  = "node_pool_scaling"
+	ClusterDiffNodePoolLabels   = "node_pool_labels"
+)
+
+// Cluster event types emitted by WatchCluster
+const (
+	ClusterEventStatus          = "status"
+	ClusterEventVersion         = "version"
+	ClusterEventNodePoolAdded   = "node_pool_added"
+	ClusterEventNodePoolRemoved = "node_pool_removed"
+	ClusterEventNodePoolScaled  = "node_pool_scaled"
+	ClusterEventNodePoolStatus  = "node_pool_status"
+	ClusterEventNodeAdded       = "node_added"
+	ClusterEventNodeRemoved     = "node_removed"
+	ClusterEventNodeStatus      = "node_status"
+)
+
 // KubernetesService is the interface to interact with kubernetes endpoint on the Vultr API
 // Link : https://www.vultr.com/api/#tag/kubernetes
 type KubernetesService interface {
 	CreateCluster(ctx context.Context, createReq *ClusterReq) (*Cluster, *http.Response, error)
 	GetCluster(ctx context.Context, id string) (*Cluster, *http.Response, error)
+	NodeCost(ctx context.Context) (*NodeCostReport, error)
+	WaitForClusterStatus(ctx context.Context, vkeID, status string, options *WaitOptions) (*Cluster, *http.Response, error)
 	ListClusters(ctx context.Context, options *ListOptions) ([]Cluster, *Meta, *http.Response, error)
 	UpdateCluster(ctx context.Context, vkeID string, updateReq *ClusterReqUpdate) error
 	DeleteCluster(ctx context.Context, id string) error
@@ -26,16 +68,36 @@ type KubernetesService interface {
 	UpdateNodePool(ctx context.Context, vkeID, nodePoolID string, updateReq *NodePoolReqUpdate) (*NodePool, *http.Response, error)
 	DeleteNodePool(ctx context.Context, vkeID, nodePoolID string) error
 
+	GetNode(ctx context.Context, vkeID, nodePoolID, nodeID string) (*NodeDetails, *http.Response, error)
 	DeleteNodePoolInstance(ctx context.Context, vkeID, nodePoolID, nodeID string) error
 	RecycleNodePoolInstance(ctx context.Context, vkeID, nodePoolID, nodeID string) error
 
 	GetKubeConfig(ctx context.Context, vkeID string) (*KubeConfig, *http.Response, error)
+	GetEndpoints(ctx context.Context, vkeID string) (*ClusterEndpoints, *http.Response, error)
+	GetClusterCA(ctx context.Context, vkeID string) (*ClusterCA, *http.Response, error)
 	GetVersions(ctx context.Context) (*Versions, *http.Response, error)
+	DiffCluster(ctx context.Context, vkeID string, desired *ClusterSpec) ([]ClusterDiff, *http.Response, error)
+	WatchCluster(ctx context.Context, vkeID string, interval time.Duration, handler ClusterEventHandler) error
 
 	GetUpgrades(ctx context.Context, vkeID string) ([]string, *http.Response, error)
 	Upgrade(ctx context.Context, vkeID string, body *ClusterUpgradeReq) error
 }
 
+// ClusterEventHandler defines the type of the function called by WatchCluster
+// for every change seen between polls
+type ClusterEventHandler func(event *ClusterEvent)
+
+// ClusterEvent represents a change to a cluster seen by WatchCluster.
+// NodePoolID and NodeID are set for node pool and node events.
+type ClusterEvent struct {
+	Type       string
+	ClusterID  string
+	NodePoolID string
+	NodeID     string
+	Previous   string
+	Current    string
+}
+
 // KubernetesHandler handles interaction with the kubernetes methods for the Vultr API
 type KubernetesHandler struct {
 	client *Client
@@ -83,11 +145,47 @@ type Node struct {
 	Status      string `json:"status"`
 }
 
+// Created returns the time the node was created
+func (n *Node) Created() (time.Time, error) {
+	return time.Parse(time.RFC3339, n.DateCreated)
+}
+
+// NodeDetails represents a node along with the plan and addresses of the
+// instance backing it
+type NodeDetails struct {
+	Node
+	NodePoolID string
+	Region     string
+	Plan       string
+	MainIP     string
+	V6MainIP   string
+	InternalIP string
+}
+
 // KubeConfig will contain the kubeconfig b64 encoded
 type KubeConfig struct {
 	KubeConfig string `json:"kube_config"`
 }
 
+// ClusterCA represents the certificate authority of a VKE cluster as found in
+// its kubeconfig. Certificate is PEM encoded.
+type ClusterCA struct {
+	Certificate string
+	Subject     string
+	NotBefore   time.Time
+	NotAfter    time.Time
+}
+
+// ClusterEndpoints represents the network endpoints of a VKE cluster. The API
+// does not currently expose an OIDC issuer URL for VKE clusters.
+type ClusterEndpoints struct {
+	Endpoint      string
+	IP            string
+	APIServerURL  string
+	ClusterSubnet string
+	ServiceSubnet string
+}
+
 // ClusterReq struct used to create a cluster
 type ClusterReq struct {
 	Label           string        `json:"label"`
@@ -98,6 +196,23 @@ type ClusterReq struct {
 	NodePools       []NodePoolReq `json:"node_pools"`
 }
 
+// ClusterSpec describes the desired state of a cluster for DiffCluster. Node
+// pools are matched to the live cluster by label. Empty Label and Version
+// fields are not compared.
+type ClusterSpec struct {
+	Label     string
+	Version   string
+	NodePools []NodePoolReq
+}
+
+// ClusterDiff represents a single difference between a ClusterSpec and a live cluster
+type ClusterDiff struct {
+	Type     string
+	NodePool string
+	Current  string
+	Desired  string
+}
+
 // ClusterReqUpdate struct used to update update a cluster
 type ClusterReqUpdate struct {
 	Label string `json:"label"`
@@ -160,6 +275,12 @@ type ClusterUpgradeReq struct {
 
 // CreateCluster will create a Kubernetes cluster.
 func (k *KubernetesHandler) CreateCluster(ctx context.Context, createReq *ClusterReq) (*Cluster, *http.Response, error) {
+	if createReq != nil {
+		if err := k.client.checkNodeBudgetCreate(ctx, "", createReq.NodePools); err != nil {
+			return nil, nil, err
+		}
+	}
+
 	req, err := k.client.NewRequest(ctx, http.MethodPost, vkePath, createReq)
 	if err != nil {
 		return nil, nil, err
@@ -190,6 +311,16 @@ func (k *KubernetesHandler) GetCluster(ctx context.Context, id string) (*Cluster
 	return k8.VKECluster, resp, nil
 }
 
+// WaitForClusterStatus polls a VKE cluster until its status matches status,
+// such as "active". Node pools are not checked.
+func (k *KubernetesHandler) WaitForClusterStatus(ctx context.Context, vkeID, status string, options *WaitOptions) (*Cluster, *http.Response, error) { //nolint:lll
+	return waitForStatus(ctx, options, status, func(ctx context.Context) (*Cluster, *http.Response, error) {
+		return k.GetCluster(ctx, vkeID)
+	}, func(r *Cluster) []string {
+		return []string{r.Status}
+	})
+}
+
 // ListClusters will return all kubernetes clusters.
 func (k *KubernetesHandler) ListClusters(ctx context.Context, options *ListOptions) ([]Cluster, *Meta, *http.Response, error) { //nolint:dupl,lll
 	req, err := k.client.NewRequest(ctx, http.MethodGet, vkePath, nil)
@@ -246,6 +377,12 @@ func (k *KubernetesHandler) DeleteClusterWithResources(ctx context.Context, id s
 
 // CreateNodePool creates a nodepool on a VKE cluster
 func (k *KubernetesHandler) CreateNodePool(ctx context.Context, vkeID string, nodePoolReq *NodePoolReq) (*NodePool, *http.Response, error) {
+	if nodePoolReq != nil {
+		if err := k.client.checkNodeBudgetCreate(ctx, vkeID, []NodePoolReq{*nodePoolReq}); err != nil {
+			return nil, nil, err
+		}
+	}
+
 	req, err := k.client.NewRequest(ctx, http.MethodPost, fmt.Sprintf("%s/%s/node-pools", vkePath, vkeID), nodePoolReq)
 	if err != nil {
 		return nil, nil, err
@@ -299,8 +436,50 @@ func (k *KubernetesHandler) GetNodePool(ctx context.Context, vkeID, nodePoolID s
 	return n.NodePool, resp, nil
 }
 
+// GetNode returns a node in a node pool along with the plan and addresses of
+// the instance backing it, which shares the node's ID
+func (k *KubernetesHandler) GetNode(ctx context.Context, vkeID, nodePoolID, nodeID string) (*NodeDetails, *http.Response, error) {
+	pool, resp, err := k.GetNodePool(ctx, vkeID, nodePoolID)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	var details *NodeDetails
+	for i := range pool.Nodes {
+		if pool.Nodes[i].ID == nodeID {
+			details = &NodeDetails{Node: pool.Nodes[i], NodePoolID: pool.ID, Plan: pool.Plan}
+			break
+		}
+	}
+
+	if details == nil {
+		return nil, resp, fmt.Errorf("node %s not found in node pool %s", nodeID, nodePoolID)
+	}
+
+	instance, resp, err := k.client.Instance.Get(ctx, nodeID)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	details.Region = instance.Region
+	details.MainIP = instance.MainIP
+	details.V6MainIP = instance.V6MainIP
+	details.InternalIP = instance.InternalIP
+
+	return details, resp, nil
+}
+
 // UpdateNodePool will allow you change the quantity of nodes within a nodepool
 func (k *KubernetesHandler) UpdateNodePool(ctx context.Context, vkeID, nodePoolID string, updateReq *NodePoolReqUpdate) (*NodePool, *http.Response, error) { //nolint:lll
+	if updateReq != nil {
+		if err := k.client.checkNodePool(ctx, vkeID, nodePoolID, updateReq); err != nil {
+			return nil, nil, err
+		}
+		if err := k.client.checkNodeBudgetUpdate(ctx, vkeID, nodePoolID, updateReq); err != nil {
+			return nil, nil, err
+		}
+	}
+
 	req, err := k.client.NewRequest(ctx, http.MethodPatch, fmt.Sprintf("%s/%s/node-pools/%s", vkePath, vkeID, nodePoolID), updateReq)
 	if err != nil {
 		return nil, nil, err
@@ -317,6 +496,10 @@ func (k *KubernetesHandler) UpdateNodePool(ctx context.Context, vkeID, nodePoolI
 
 // DeleteNodePool will remove a nodepool from a VKE cluster
 func (k *KubernetesHandler) DeleteNodePool(ctx context.Context, vkeID, nodePoolID string) error {
+	if err := k.client.checkNodePool(ctx, vkeID, nodePoolID, nil); err != nil {
+		return err
+	}
+
 	req, err := k.client.NewRequest(ctx, http.MethodDelete, fmt.Sprintf("%s/%s/node-pools/%s", vkePath, vkeID, nodePoolID), nil)
 	if err != nil {
 		return err
@@ -374,6 +557,300 @@ func (k *KubernetesHandler) GetKubeConfig(ctx context.Context, vkeID string) (*K
 	return kc, resp, nil
 }
 
+// GetEndpoints returns the endpoint and subnets of a cluster along with the
+// API server URL taken from its kubeconfig
+func (k *KubernetesHandler) GetEndpoints(ctx context.Context, vkeID string) (*ClusterEndpoints, *http.Response, error) {
+	cluster, _, err := k.GetCluster(ctx, vkeID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	kc, resp, err := k.GetKubeConfig(ctx, vkeID)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	config, err := base64.StdEncoding.DecodeString(kc.KubeConfig)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	endpoints := &ClusterEndpoints{
+		Endpoint:      cluster.Endpoint,
+		IP:            cluster.IP,
+		ClusterSubnet: cluster.ClusterSubnet,
+		ServiceSubnet: cluster.ServiceSubnet,
+	}
+
+	scanner := bufio.NewScanner(strings.NewReader(string(config)))
+	for scanner.Scan() {
+		if server, ok := strings.CutPrefix(strings.TrimSpace(scanner.Text()), "server:"); ok {
+			endpoints.APIServerURL = strings.TrimSpace(server)
+			break
+		}
+	}
+
+	return endpoints, resp, nil
+}
+
+// GetClusterCA returns the certificate authority of a cluster taken from its
+// kubeconfig. The API does not currently support rotating cluster credentials.
+func (k *KubernetesHandler) GetClusterCA(ctx context.Context, vkeID string) (*ClusterCA, *http.Response, error) {
+	kc, resp, err := k.GetKubeConfig(ctx, vkeID)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	config, err := base64.StdEncoding.DecodeString(kc.KubeConfig)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	var data string
+	scanner := bufio.NewScanner(strings.NewReader(string(config)))
+	for scanner.Scan() {
+		if value, ok := strings.CutPrefix(strings.TrimSpace(scanner.Text()), "certificate-authority-data:"); ok {
+			data = strings.TrimSpace(value)
+			break
+		}
+	}
+
+	if data == "" {
+		return nil, resp, errors.New("kubeconfig does not contain certificate-authority-data")
+	}
+
+	certificate, err := base64.StdEncoding.DecodeString(data)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	block, _ := pem.Decode(certificate)
+	if block == nil {
+		return nil, resp, errors.New("certificate-authority-data is not PEM encoded")
+	}
+
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	ca := &ClusterCA{
+		Certificate: string(certificate),
+		Subject:     cert.Subject.String(),
+		NotBefore:   cert.NotBefore,
+		NotAfter:    cert.NotAfter,
+	}
+
+	return ca, resp, nil
+}
+
+// DiffCluster compares a live cluster against a desired spec and returns the
+// differences between them. A nil slice means the cluster matches the spec.
+func (k *KubernetesHandler) DiffCluster(ctx context.Context, vkeID string, desired *ClusterSpec) ([]ClusterDiff, *http.Response, error) { //nolint:lll
+	if desired == nil {
+		return nil, nil, errors.New("diff cluster requires a desired spec")
+	}
+
+	cluster, resp, err := k.GetCluster(ctx, vkeID)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	var diffs []ClusterDiff
+	if desired.Label != "" && desired.Label != cluster.Label {
+		diffs = append(diffs, ClusterDiff{Type: ClusterDiffLabel, Current: cluster.Label, Desired: desired.Label})
+	}
+
+	if desired.Version != "" && desired.Version != cluster.Version {
+		diffs = append(diffs, ClusterDiff{Type: ClusterDiffVersion, Current: cluster.Version, Desired: desired.Version})
+	}
+
+	live := make(map[string]*NodePool, len(cluster.NodePools))
+	for i := range cluster.NodePools {
+		live[cluster.NodePools[i].Label] = &cluster.NodePools[i]
+	}
+
+	wanted := make(map[string]bool, len(desired.NodePools))
+	for i := range desired.NodePools {
+		spec := &desired.NodePools[i]
+		wanted[spec.Label] = true
+
+		pool, ok := live[spec.Label]
+		if !ok {
+			diffs = append(diffs, ClusterDiff{Type: ClusterDiffNodePoolMissing, NodePool: spec.Label, Desired: spec.Plan})
+			continue
+		}
+
+		diffs = append(diffs, diffNodePool(pool, spec)...)
+	}
+
+	for i := range cluster.NodePools {
+		if !wanted[cluster.NodePools[i].Label] {
+			diffs = append(diffs, ClusterDiff{
+				Type:     ClusterDiffNodePoolExtra,
+				NodePool: cluster.NodePools[i].Label,
+				Current:  cluster.NodePools[i].Plan,
+			})
+		}
+	}
+
+	return diffs, resp, nil
+}
+
+// WatchCluster polls a cluster every interval and calls handler with an event
+// for each change in its status, version, node pools and nodes since the
+// previous poll. The first poll sets the baseline and emits no events. This
+// blocks until ctx is done or a request fails, returning the error that
+// stopped it.
+func (k *KubernetesHandler) WatchCluster(ctx context.Context, vkeID string, interval time.Duration, handler ClusterEventHandler) error { //nolint:lll
+	if interval <= 0 {
+		return fmt.Errorf("watch interval must be positive, got %s", interval)
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var previous *Cluster
+	for {
+		cluster, _, err := k.GetCluster(ctx, vkeID)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return err
+		}
+
+		if previous != nil {
+			events := clusterEvents(previous, cluster)
+			for i := range events {
+				handler(&events[i])
+			}
+		}
+		previous = cluster
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// clusterEvents returns the changes between two polls of a cluster
+func clusterEvents(previous, current *Cluster) []ClusterEvent {
+	var events []ClusterEvent
+	event := func(eventType, nodePoolID, nodeID, was, now string) {
+		events = append(events, ClusterEvent{
+			Type:       eventType,
+			ClusterID:  current.ID,
+			NodePoolID: nodePoolID,
+			NodeID:     nodeID,
+			Previous:   was,
+			Current:    now,
+		})
+	}
+
+	if previous.Status != current.Status {
+		event(ClusterEventStatus, "", "", previous.Status, current.Status)
+	}
+
+	if previous.Version != current.Version {
+		event(ClusterEventVersion, "", "", previous.Version, current.Version)
+	}
+
+	pools := make(map[string]*NodePool, len(previous.NodePools))
+	for i := range previous.NodePools {
+		pools[previous.NodePools[i].ID] = &previous.NodePools[i]
+	}
+
+	for i := range current.NodePools {
+		pool := &current.NodePools[i]
+		old, ok := pools[pool.ID]
+		if !ok {
+			event(ClusterEventNodePoolAdded, pool.ID, "", "", strconv.Itoa(pool.NodeQuantity))
+			continue
+		}
+		delete(pools, pool.ID)
+
+		if old.NodeQuantity != pool.NodeQuantity {
+			event(ClusterEventNodePoolScaled, pool.ID, "", strconv.Itoa(old.NodeQuantity), strconv.Itoa(pool.NodeQuantity))
+		}
+
+		if old.Status != pool.Status {
+			event(ClusterEventNodePoolStatus, pool.ID, "", old.Status, pool.Status)
+		}
+
+		nodes := make(map[string]string, len(old.Nodes))
+		for _, node := range old.Nodes {
+			nodes[node.ID] = node.Status
+		}
+
+		for _, node := range pool.Nodes {
+			status, ok := nodes[node.ID]
+			switch {
+			case !ok:
+				event(ClusterEventNodeAdded, pool.ID, node.ID, "", node.Status)
+			case status != node.Status:
+				event(ClusterEventNodeStatus, pool.ID, node.ID, status, node.Status)
+			}
+			delete(nodes, node.ID)
+		}
+
+		for _, node := range old.Nodes {
+			if status, ok := nodes[node.ID]; ok {
+				event(ClusterEventNodeRemoved, pool.ID, node.ID, status, "")
+			}
+		}
+	}
+
+	for i := range previous.NodePools {
+		if pool, ok := pools[previous.NodePools[i].ID]; ok {
+			event(ClusterEventNodePoolRemoved, pool.ID, "", strconv.Itoa(pool.NodeQuantity), "")
+		}
+	}
+
+	return events
+}
+
+func diffNodePool(pool *NodePool, spec *NodePoolReq) []ClusterDiff {
+	var diffs []ClusterDiff
+	if spec.Plan != "" && spec.Plan != pool.Plan {
+		diffs = append(diffs, ClusterDiff{Type: ClusterDiffNodePoolPlan, NodePool: pool.Label, Current: pool.Plan, Desired: spec.Plan})
+	}
+
+	// The node quantity of an autoscaled pool changes on its own, so only its bounds are compared
+	autoScaler := spec.AutoScaler != nil && *spec.AutoScaler
+	if autoScaler || pool.AutoScaler {
+		current := fmt.Sprintf("%t %d-%d", pool.AutoScaler, pool.MinNodes, pool.MaxNodes)
+		desired := fmt.Sprintf("%t %d-%d", autoScaler, spec.MinNodes, spec.MaxNodes)
+		if current != desired {
+			diffs = append(diffs, ClusterDiff{Type: ClusterDiffNodePoolScaling, NodePool: pool.Label, Current: current, Desired: desired})
+		}
+	} else if spec.NodeQuantity != pool.NodeQuantity {
+		diffs = append(diffs, ClusterDiff{
+			Type:     ClusterDiffNodePoolQuantity,
+			NodePool: pool.Label,
+			Current:  fmt.Sprint(pool.NodeQuantity),
+			Desired:  fmt.Sprint(spec.NodeQuantity),
+		})
+	}
+
+	if current, desired := formatLabels(pool.Labels), formatLabels(spec.Labels); current != desired {
+		diffs = append(diffs, ClusterDiff{Type: ClusterDiffNodePoolLabels, NodePool: pool.Label, Current: current, Desired: desired})
+	}
+
+	return diffs
+}
+
+func formatLabels(labels map[string]string) string {
+	pairs := make([]string, 0, len(labels))
+	for k, v := range labels {
+		pairs = append(pairs, k+"="+v)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
+
 // GetVersions returns the supported kubernetes versions
 func (k *KubernetesHandler) GetVersions(ctx context.Context) (*Versions, *http.Response, error) {
 	uri := "/v2/kubernetes/versions"
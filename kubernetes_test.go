@@ -2,7 +2,16 @@ package govultr
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
 	"fmt"
+	"math/big"
 	"net/http"
 	"reflect"
 	"testing"
@@ -810,3 +819,244 @@ func TestKubernetesHandler_Upgrade(t *testing.T) {
 		t.Errorf("Kubernetes.StartUpgrade returned %+v", err)
 	}
 }
+
+func TestKubernetesHandler_GetClusterCA(t *testing.T) {
+	setup()
+	defer teardown()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	notBefore := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	notAfter := notBefore.AddDate(10, 0, 0)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "kubernetes"},
+		NotBefore:             notBefore,
+		NotAfter:              notAfter,
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	certificate := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+	config := base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf(
+		"apiVersion: v1\nclusters:\n- cluster:\n    certificate-authority-data: %s\n    server: https://1.vultr-k8s.com:6443\n  name: vke\n",
+		base64.StdEncoding.EncodeToString([]byte(certificate)),
+	)))
+	mux.HandleFunc(fmt.Sprintf("%s/%s/config", vkePath, "1"), func(writer http.ResponseWriter, request *http.Request) {
+		fmt.Fprintf(writer, `{"kube_config": %q}`, config)
+	})
+
+	ca, _, err := client.Kubernetes.GetClusterCA(ctx, "1")
+	if err != nil {
+		t.Errorf("Kubernetes.GetClusterCA returned %+v", err)
+	}
+
+	expected := &ClusterCA{
+		Certificate: certificate,
+		Subject:     "CN=kubernetes",
+		NotBefore:   notBefore,
+		NotAfter:    notAfter,
+	}
+
+	if !reflect.DeepEqual(ca, expected) {
+		t.Errorf("Kubernetes.GetClusterCA returned %+v, expected %+v", ca, expected)
+	}
+}
+
+func TestKubernetesHandler_GetEndpoints(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc(fmt.Sprintf("%s/%s", vkePath, "1"), func(writer http.ResponseWriter, request *http.Request) {
+		response := `{"vke_cluster": {"id": "1", "cluster_subnet": "10.244.0.0/16", "service_subnet": "10.96.0.0/12", "ip": "192.0.2.10", "endpoint": "1.vultr-k8s.com"}}`
+		fmt.Fprint(writer, response)
+	})
+
+	config := base64.StdEncoding.EncodeToString([]byte("apiVersion: v1\nclusters:\n- cluster:\n    server: https://1.vultr-k8s.com:6443\n  name: vke\n"))
+	mux.HandleFunc(fmt.Sprintf("%s/%s/config", vkePath, "1"), func(writer http.ResponseWriter, request *http.Request) {
+		fmt.Fprintf(writer, `{"kube_config": %q}`, config)
+	})
+
+	endpoints, _, err := client.Kubernetes.GetEndpoints(ctx, "1")
+	if err != nil {
+		t.Errorf("Kubernetes.GetEndpoints returned %+v", err)
+	}
+
+	expected := &ClusterEndpoints{
+		Endpoint:      "1.vultr-k8s.com",
+		IP:            "192.0.2.10",
+		APIServerURL:  "https://1.vultr-k8s.com:6443",
+		ClusterSubnet: "10.244.0.0/16",
+		ServiceSubnet: "10.96.0.0/12",
+	}
+
+	if !reflect.DeepEqual(endpoints, expected) {
+		t.Errorf("Kubernetes.GetEndpoints returned %+v, expected %+v", endpoints, expected)
+	}
+}
+
+func TestKubernetesHandler_DiffCluster(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc(fmt.Sprintf("%s/%s", vkePath, "1"), func(writer http.ResponseWriter, request *http.Request) {
+		response := `{"vke_cluster": {"id": "1", "label": "prod", "version": "v1.28.2+1", "node_pools": [
+			{"id": "a", "label": "workers", "plan": "vc2-2c-4gb", "node_quantity": 3, "labels": {"tier": "app"}},
+			{"id": "b", "label": "batch", "plan": "vc2-4c-8gb", "auto_scaler": true, "min_nodes": 1, "max_nodes": 5},
+			{"id": "c", "label": "legacy", "plan": "vc2-1c-2gb", "node_quantity": 1}
+		]}}`
+		fmt.Fprint(writer, response)
+	})
+
+	autoScaler := true
+	desired := &ClusterSpec{
+		Label:   "prod",
+		Version: "v1.29.1+1",
+		NodePools: []NodePoolReq{
+			{Label: "workers", Plan: "vc2-4c-8gb", NodeQuantity: 3, Labels: map[string]string{"tier": "web"}},
+			{Label: "batch", Plan: "vc2-4c-8gb", NodeQuantity: 1, AutoScaler: &autoScaler, MinNodes: 1, MaxNodes: 10},
+			{Label: "gpu", Plan: "vcg-a16-2c-16g", NodeQuantity: 1},
+		},
+	}
+
+	diffs, _, err := client.Kubernetes.DiffCluster(ctx, "1", desired)
+	if err != nil {
+		t.Errorf("Kubernetes.DiffCluster returned %+v", err)
+	}
+
+	expected := []ClusterDiff{
+		{Type: ClusterDiffVersion, Current: "v1.28.2+1", Desired: "v1.29.1+1"},
+		{Type: ClusterDiffNodePoolPlan, NodePool: "workers", Current: "vc2-2c-4gb", Desired: "vc2-4c-8gb"},
+		{Type: ClusterDiffNodePoolLabels, NodePool: "workers", Current: "tier=app", Desired: "tier=web"},
+		{Type: ClusterDiffNodePoolScaling, NodePool: "batch", Current: "true 1-5", Desired: "true 1-10"},
+		{Type: ClusterDiffNodePoolMissing, NodePool: "gpu", Desired: "vcg-a16-2c-16g"},
+		{Type: ClusterDiffNodePoolExtra, NodePool: "legacy", Current: "vc2-1c-2gb"},
+	}
+
+	if !reflect.DeepEqual(diffs, expected) {
+		t.Errorf("Kubernetes.DiffCluster returned %+v, expected %+v", diffs, expected)
+	}
+}
+
+func TestKubernetesHandler_DiffClusterNilSpec(t *testing.T) {
+	setup()
+	defer teardown()
+
+	requests := 0
+	mux.HandleFunc("/v2/kubernetes/clusters/", func(writer http.ResponseWriter, request *http.Request) {
+		requests++
+	})
+
+	if _, _, err := client.Kubernetes.DiffCluster(ctx, "014da059-21bd-47c5-8b1e-3a8a8f7e1b8a", nil); err == nil {
+		t.Error("Kubernetes.DiffCluster expected an error for a nil spec")
+	}
+
+	if requests != 0 {
+		t.Errorf("Kubernetes.DiffCluster made %d requests, expected none", requests)
+	}
+}
+
+func TestKubernetesHandler_GetNode(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc(fmt.Sprintf("%s/%s/node-pools/%s", vkePath, "1", "2"), func(writer http.ResponseWriter, request *http.Request) {
+		response := `{"node_pool": {"id": "2", "plan": "vc2-4c-8gb", "nodes": [
+			{"id": "3", "date_created": "2024-05-01T12:00:00+00:00", "label": "nodepool-3", "status": "active"}
+		]}}`
+		fmt.Fprint(writer, response)
+	})
+
+	mux.HandleFunc("/v2/instances/3", func(writer http.ResponseWriter, request *http.Request) {
+		response := `{"instance": {"id": "3", "region": "ewr", "main_ip": "192.0.2.3", "v6_main_ip": "2001:db8::3", "internal_ip": "10.1.96.3"}}`
+		fmt.Fprint(writer, response)
+	})
+
+	node, _, err := client.Kubernetes.GetNode(ctx, "1", "2", "3")
+	if err != nil {
+		t.Errorf("Kubernetes.GetNode returned %+v", err)
+	}
+
+	expected := &NodeDetails{
+		Node:       Node{ID: "3", DateCreated: "2024-05-01T12:00:00+00:00", Label: "nodepool-3", Status: NodeStatusActive},
+		NodePoolID: "2",
+		Region:     "ewr",
+		Plan:       "vc2-4c-8gb",
+		MainIP:     "192.0.2.3",
+		V6MainIP:   "2001:db8::3",
+		InternalIP: "10.1.96.3",
+	}
+
+	if !reflect.DeepEqual(node, expected) {
+		t.Errorf("Kubernetes.GetNode returned %+v, expected %+v", node, expected)
+	}
+
+	created, err := node.Created()
+	if err != nil || !created.Equal(time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)) {
+		t.Errorf("Node.Created returned %v, %v", created, err)
+	}
+
+	if _, _, err = client.Kubernetes.GetNode(ctx, "1", "2", "4"); err == nil {
+		t.Error("Kubernetes.GetNode expected an error for a node not in the pool")
+	}
+}
+
+func TestKubernetesHandler_WatchCluster(t *testing.T) {
+	setup()
+	defer teardown()
+
+	watchCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	polls := []string{
+		`{"vke_cluster": {"id": "1", "status": "active", "version": "v1.29.1", "node_pools": [
+			{"id": "p1", "status": "active", "node_quantity": 1, "nodes": [{"id": "n1", "status": "active"}]},
+			{"id": "p2", "status": "active", "node_quantity": 1}
+		]}}`,
+		`{"vke_cluster": {"id": "1", "status": "active", "version": "v1.29.1", "node_pools": [
+			{"id": "p1", "status": "active", "node_quantity": 2, "nodes": [{"id": "n1", "status": "active"}, {"id": "n2", "status": "pending"}]},
+			{"id": "p3", "status": "pending", "node_quantity": 1}
+		]}}`,
+	}
+	poll := 0
+	mux.HandleFunc(fmt.Sprintf("%s/%s", vkePath, "1"), func(writer http.ResponseWriter, request *http.Request) {
+		fmt.Fprint(writer, polls[poll])
+		if poll < len(polls)-1 {
+			poll++
+		}
+	})
+
+	var events []ClusterEvent
+	err := client.Kubernetes.WatchCluster(watchCtx, "1", time.Millisecond, func(event *ClusterEvent) {
+		events = append(events, *event)
+		if len(events) == 4 {
+			cancel()
+		}
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Kubernetes.WatchCluster returned %+v, expected %+v", err, context.Canceled)
+	}
+
+	expected := []ClusterEvent{
+		{Type: ClusterEventNodePoolScaled, ClusterID: "1", NodePoolID: "p1", Previous: "1", Current: "2"},
+		{Type: ClusterEventNodeAdded, ClusterID: "1", NodePoolID: "p1", NodeID: "n2", Current: "pending"},
+		{Type: ClusterEventNodePoolAdded, ClusterID: "1", NodePoolID: "p3", Current: "1"},
+		{Type: ClusterEventNodePoolRemoved, ClusterID: "1", NodePoolID: "p2", Previous: "1"},
+	}
+
+	if !reflect.DeepEqual(events, expected) {
+		t.Errorf("Kubernetes.WatchCluster emitted %+v, expected %+v", events, expected)
+	}
+
+	if err := client.Kubernetes.WatchCluster(ctx, "cluster", 0, func(event *ClusterEvent) {}); err == nil {
+		t.Error("Kubernetes.WatchCluster expected an error for a zero interval")
+	}
+}
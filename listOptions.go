@@ -1,5 +1,10 @@
 package govultr
 
+import (
+	"context"
+	"net/http"
+)
+
 // ListOptions are the available query params
 type ListOptions struct {
 	// These query params are used for all list calls that support pagination
@@ -18,3 +23,28 @@ type ListOptions struct {
 	// https://www.vultr.com/api/#operation/list-snapshots
 	Description string `url:"description,omitempty"`
 }
+
+// CollectPages calls list, typically the List method of a service, with each
+// page cursor in turn and returns the items from every page
+func CollectPages[T any](ctx context.Context, list func(context.Context, *ListOptions) ([]T, *Meta, *http.Response, error)) ([]T, error) { //nolint:lll
+	return collectPages(ctx, list)
+}
+
+// collectPages calls list with each page cursor in turn and returns the items
+// from every page
+func collectPages[T any](ctx context.Context, list func(context.Context, *ListOptions) ([]T, *Meta, *http.Response, error)) ([]T, error) {
+	var items []T
+	options := &ListOptions{PerPage: 100}
+	for {
+		page, meta, _, err := list(ctx, options)
+		if err != nil {
+			return nil, err
+		}
+
+		items = append(items, page...)
+		if meta == nil || meta.Links == nil || meta.Links.Next == "" {
+			return items, nil
+		}
+		options.Cursor = meta.Links.Next
+	}
+}
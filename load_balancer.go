@@ -3,21 +3,36 @@ package govultr
 import (
 	"context"
 	"fmt"
+	"net"
 	"net/http"
+	"net/netip"
+	"strconv"
+	"time"
 
 	"github.com/google/go-querystring/query"
 )
 
-const lbPath = "/v2/load-balancers"
+const (
+	lbPath         = "/v2/load-balancers"
+	lbStatusActive = "active"
+
+	defaultProbeTimeout = 10 * time.Second
+)
 
 // LoadBalancerService is the interface to interact with the server endpoints on the Vultr API
 // Link : https://www.vultr.com/api/#tag/load-balancer
 type LoadBalancerService interface {
 	Create(ctx context.Context, createReq *LoadBalancerReq) (*LoadBalancer, *http.Response, error)
+	CreateAndWait(ctx context.Context, createReq *LoadBalancerReq, options *WaitOptions, probe *LoadBalancerProbe) (*LoadBalancer, *http.Response, error) //nolint:lll
 	Get(ctx context.Context, lbID string) (*LoadBalancer, *http.Response, error)
+	WaitForStatus(ctx context.Context, lbID, status string, options *WaitOptions) (*LoadBalancer, *http.Response, error)
 	Update(ctx context.Context, lbID string, updateReq *LoadBalancerReq) error
 	Delete(ctx context.Context, lbID string) error
+	DrainInstance(ctx context.Context, lbID, instanceID string, options *WaitOptions) error
+	RestoreInstance(ctx context.Context, lbID, instanceID string, options *WaitOptions) error
 	List(ctx context.Context, options *ListOptions) ([]LoadBalancer, *Meta, *http.Response, error)
+	ListByLabel(ctx context.Context, label string) ([]LoadBalancer, error)
+	FindByIP(ctx context.Context, ip string) (*LoadBalancer, error)
 	CreateForwardingRule(ctx context.Context, lbID string, rule *ForwardingRule) (*ForwardingRule, *http.Response, error)
 	GetForwardingRule(ctx context.Context, lbID string, ruleID string) (*ForwardingRule, *http.Response, error)
 	DeleteForwardingRule(ctx context.Context, lbID string, RuleID string) error
@@ -68,6 +83,35 @@ type LoadBalancerReq struct {
 	VPC            *string `json:"vpc,omitempty"`
 }
 
+// DualStack reports whether the load balancer has both an IPv4 and an IPv6
+// frontend address
+func (l *LoadBalancer) DualStack() bool {
+	return l.IPV4 != "" && l.IPV6 != ""
+}
+
+// ValidateFirewallRules checks that every firewall rule has an IP type of v4
+// or v6 and, when its source is a CIDR rather than a named source such as
+// "cloudflare", that the source belongs to that address family
+func (l *LoadBalancerReq) ValidateFirewallRules() error {
+	for i := range l.FirewallRules {
+		rule := &l.FirewallRules[i]
+		if rule.IPType != "v4" && rule.IPType != "v6" {
+			return fmt.Errorf("firewall rule %d: invalid ip_type %q", i, rule.IPType)
+		}
+
+		prefix, err := netip.ParsePrefix(rule.Source)
+		if err != nil {
+			continue
+		}
+
+		if prefix.Addr().Is4() != (rule.IPType == "v4") {
+			return fmt.Errorf("firewall rule %d: source %s is not %s", i, rule.Source, rule.IPType)
+		}
+	}
+
+	return nil
+}
+
 // InstanceList represents instances that are attached to your load balancer
 type InstanceList struct {
 	InstanceList []string
@@ -114,6 +158,17 @@ type ForwardingRule struct {
 	BackendPort      int    `json:"backend_port,omitempty"`
 }
 
+// LoadBalancerProbe is a client-side readiness check made against the public
+// IPv4 address of a load balancer. Rules with an http frontend are probed with
+// a GET of Path, which must not return a 5xx status. All other rules are
+// probed by opening a TCP connection to the frontend port. Each probe gives
+// up after Timeout, or 10 seconds if it is not set.
+type LoadBalancerProbe struct {
+	Rule    ForwardingRule
+	Path    string
+	Timeout time.Duration
+}
+
 // LBFirewallRule represent a single firewall rule
 type LBFirewallRule struct {
 	RuleID string `json:"id,omitempty"`
@@ -172,6 +227,80 @@ func (l *LoadBalancerHandler) Create(ctx context.Context, createReq *LoadBalance
 	return lb.LoadBalancer, resp, nil
 }
 
+// CreateAndWait creates a new load balancer and polls until it is active and
+// has been assigned an IPv4 address. If a probe is given the wait continues
+// until the probe also succeeds, so the load balancer is ready to receive
+// traffic when this returns.
+func (l *LoadBalancerHandler) CreateAndWait(ctx context.Context, createReq *LoadBalancerReq, options *WaitOptions, probe *LoadBalancerProbe) (*LoadBalancer, *http.Response, error) { //nolint:lll
+	lb, resp, err := l.Create(ctx, createReq)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	err = waitFor(ctx, options, func(ctx context.Context) (string, bool, error) {
+		var errGet error
+		lb, resp, errGet = l.Get(ctx, lb.ID)
+		if errGet != nil {
+			return "", false, errGet
+		}
+
+		if lb.Status != lbStatusActive || lb.IPV4 == "" {
+			return lb.Status, false, nil
+		}
+
+		if probe != nil {
+			if errProbe := probe.check(ctx, lb.IPV4); errProbe != nil {
+				return fmt.Sprintf("probe failed: %v", errProbe), false, nil
+			}
+		}
+
+		return lb.Status, true, nil
+	})
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return lb, resp, nil
+}
+
+func (p *LoadBalancerProbe) check(ctx context.Context, ip string) error {
+	timeout := p.Timeout
+	if timeout <= 0 {
+		timeout = defaultProbeTimeout
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	addr := net.JoinHostPort(ip, strconv.Itoa(p.Rule.FrontendPort))
+	if p.Rule.FrontendProtocol != "http" {
+		var dialer net.Dialer
+		conn, err := dialer.DialContext(ctx, "tcp", addr)
+		if err != nil {
+			return err
+		}
+		return conn.Close()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://"+addr+p.Path, nil)
+	if err != nil {
+		return err
+	}
+
+	probeClient := &http.Client{Timeout: timeout}
+	res, err := probeClient.Do(req)
+	if err != nil {
+		return err
+	}
+	drainAndClose(res.Body)
+
+	if res.StatusCode >= http.StatusInternalServerError {
+		return fmt.Errorf("unexpected status %s", res.Status)
+	}
+
+	return nil
+}
+
 // Get a load balancer
 func (l *LoadBalancerHandler) Get(ctx context.Context, lbID string) (*LoadBalancer, *http.Response, error) {
 	uri := fmt.Sprintf("%s/%s", lbPath, lbID)
@@ -189,6 +318,16 @@ func (l *LoadBalancerHandler) Get(ctx context.Context, lbID string) (*LoadBalanc
 	return lb.LoadBalancer, resp, nil
 }
 
+// WaitForStatus polls a load balancer until its status matches status, such
+// as "active"
+func (l *LoadBalancerHandler) WaitForStatus(ctx context.Context, lbID, status string, options *WaitOptions) (*LoadBalancer, *http.Response, error) { //nolint:lll
+	return waitForStatus(ctx, options, status, func(ctx context.Context) (*LoadBalancer, *http.Response, error) {
+		return l.Get(ctx, lbID)
+	}, func(r *LoadBalancer) []string {
+		return []string{r.Status}
+	})
+}
+
 // Update updates your your load balancer
 func (l *LoadBalancerHandler) Update(ctx context.Context, lbID string, updateReq *LoadBalancerReq) error {
 	uri := fmt.Sprintf("%s/%s", lbPath, lbID)
@@ -201,6 +340,83 @@ func (l *LoadBalancerHandler) Update(ctx context.Context, lbID string, updateReq
 	return err
 }
 
+// DrainInstance takes an instance out of rotation on a load balancer and waits
+// until the load balancer is active without it. The API does not support
+// backend weights or connection draining, so in-flight connections to the
+// instance are not waited on. Use RestoreInstance to put it back.
+func (l *LoadBalancerHandler) DrainInstance(ctx context.Context, lbID, instanceID string, options *WaitOptions) error {
+	lb, _, err := l.Get(ctx, lbID)
+	if err != nil {
+		return err
+	}
+
+	instances := make([]string, 0, len(lb.Instances))
+	for _, id := range lb.Instances {
+		if id != instanceID {
+			instances = append(instances, id)
+		}
+	}
+
+	if len(instances) == len(lb.Instances) {
+		return nil
+	}
+
+	if len(instances) == 0 {
+		return fmt.Errorf("instance %s is the only instance on load balancer %s", instanceID, lbID)
+	}
+
+	return l.setInstances(ctx, lbID, instances, instanceID, false, options)
+}
+
+// RestoreInstance puts an instance back into rotation on a load balancer and
+// waits until the load balancer is active with it
+func (l *LoadBalancerHandler) RestoreInstance(ctx context.Context, lbID, instanceID string, options *WaitOptions) error {
+	lb, _, err := l.Get(ctx, lbID)
+	if err != nil {
+		return err
+	}
+
+	for _, id := range lb.Instances {
+		if id == instanceID {
+			return nil
+		}
+	}
+
+	instances := append(lb.Instances, instanceID)
+	return l.setInstances(ctx, lbID, instances, instanceID, true, options)
+}
+
+// setInstances replaces the instances of a load balancer. Only the instances
+// are sent since the update request would otherwise reset the firewall rules.
+func (l *LoadBalancerHandler) setInstances(ctx context.Context, lbID string, instances []string, instanceID string, attached bool, options *WaitOptions) error { //nolint:lll
+	uri := fmt.Sprintf("%s/%s", lbPath, lbID)
+	req, err := l.client.NewRequest(ctx, http.MethodPatch, uri, RequestBody{"instances": instances})
+	if err != nil {
+		return err
+	}
+
+	if _, err = l.client.DoWithContext(ctx, req, nil); err != nil {
+		return err
+	}
+
+	return waitFor(ctx, options, func(ctx context.Context) (string, bool, error) {
+		lb, _, errGet := l.Get(ctx, lbID)
+		if errGet != nil {
+			return "", false, errGet
+		}
+
+		found := false
+		for _, id := range lb.Instances {
+			if id == instanceID {
+				found = true
+				break
+			}
+		}
+
+		return lb.Status, found == attached && lb.Status == lbStatusActive, nil
+	})
+}
+
 // Delete a load balancer subscription.
 func (l *LoadBalancerHandler) Delete(ctx context.Context, lbID string) error {
 	uri := fmt.Sprintf("%s/%s", lbPath, lbID)
@@ -235,6 +451,49 @@ func (l *LoadBalancerHandler) List(ctx context.Context, options *ListOptions) ([
 	return lbs.LoadBalancers, lbs.Meta, resp, nil
 }
 
+// ListByLabel returns every load balancer with the given label. The API does
+// not filter load balancers by label, so all pages are fetched and filtered
+// client-side.
+func (l *LoadBalancerHandler) ListByLabel(ctx context.Context, label string) ([]LoadBalancer, error) {
+	lbs, err := collectPages(ctx, l.List)
+	if err != nil {
+		return nil, err
+	}
+
+	var matched []LoadBalancer
+	for i := range lbs {
+		if lbs[i].Label == label {
+			matched = append(matched, lbs[i])
+		}
+	}
+
+	return matched, nil
+}
+
+// FindByIP returns the load balancer whose IPv4 or IPv6 frontend address is
+// the given IP, including its forwarding and firewall rules
+func (l *LoadBalancerHandler) FindByIP(ctx context.Context, ip string) (*LoadBalancer, error) {
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return nil, fmt.Errorf("invalid IP address %q", ip)
+	}
+
+	lbs, err := collectPages(ctx, l.List)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range lbs {
+		for _, lbIP := range []string{lbs[i].IPV4, lbs[i].IPV6} {
+			if lbAddr, errParse := netip.ParseAddr(lbIP); errParse == nil && lbAddr.Unmap() == addr.Unmap() {
+				return &lbs[i], nil
+			}
+		}
+	}
+
+	return nil, fmt.Errorf("no load balancer with IP %s", ip)
+}
+
 // CreateForwardingRule will create a new forwarding rule for your load balancer subscription.
 // Note the RuleID will be returned in the ForwardingRule struct
 func (l *LoadBalancerHandler) CreateForwardingRule(ctx context.Context, lbID string, rule *ForwardingRule) (*ForwardingRule, *http.Response, error) { //nolint:lll
@@ -1,10 +1,15 @@
 package govultr
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"reflect"
+	"strconv"
 	"testing"
+	"time"
 )
 
 func TestLoadBalancerHandler_List(t *testing.T) {
@@ -666,3 +671,207 @@ func TestLoadBalancerHandler_GetFirewallRule(t *testing.T) {
 		t.Errorf("LoadBalancer.GetFirewallRule returned %+v, expected %+v", rule, expected)
 	}
 }
+
+func TestLoadBalancerHandler_CreateAndWait(t *testing.T) {
+	setup()
+	defer teardown()
+
+	healthy := false
+	backend := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		if !healthy {
+			healthy = true
+			writer.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		writer.WriteHeader(http.StatusOK)
+	}))
+	defer backend.Close()
+
+	backendURL, _ := url.Parse(backend.URL)
+	port, _ := strconv.Atoi(backendURL.Port())
+
+	mux.HandleFunc(lbPath, func(writer http.ResponseWriter, request *http.Request) {
+		fmt.Fprint(writer, `{"load_balancer": {"id": "1317575", "status": "pending"}}`)
+	})
+
+	polls := 0
+	mux.HandleFunc(fmt.Sprintf("%s/%s", lbPath, "1317575"), func(writer http.ResponseWriter, request *http.Request) {
+		polls++
+		if polls == 1 {
+			fmt.Fprint(writer, `{"load_balancer": {"id": "1317575", "status": "pending"}}`)
+			return
+		}
+		fmt.Fprint(writer, `{"load_balancer": {"id": "1317575", "status": "active", "ipv4": "127.0.0.1"}}`)
+	})
+
+	var progress []string
+	options := &WaitOptions{
+		Interval: time.Millisecond,
+		Progress: func(status string, elapsed time.Duration) {
+			progress = append(progress, status)
+		},
+	}
+
+	probe := &LoadBalancerProbe{
+		Rule: ForwardingRule{FrontendProtocol: "http", FrontendPort: port},
+		Path: "/health",
+	}
+
+	lb, _, err := client.LoadBalancer.CreateAndWait(ctx, &LoadBalancerReq{Region: "ewr"}, options, probe)
+	if err != nil {
+		t.Errorf("LoadBalancer.CreateAndWait returned %+v", err)
+	}
+
+	expected := &LoadBalancer{ID: "1317575", Status: "active", IPV4: "127.0.0.1"}
+	if !reflect.DeepEqual(lb, expected) {
+		t.Errorf("LoadBalancer.CreateAndWait returned %+v, expected %+v", lb, expected)
+	}
+
+	expectedProgress := []string{"pending", "probe failed: unexpected status 503 Service Unavailable", "active"}
+	if !reflect.DeepEqual(progress, expectedProgress) {
+		t.Errorf("LoadBalancer.CreateAndWait progress returned %+v, expected %+v", progress, expectedProgress)
+	}
+
+	probe.Rule.FrontendProtocol = "tcp"
+	if err = probe.check(ctx, "127.0.0.1"); err != nil {
+		t.Errorf("LoadBalancerProbe.check returned %+v", err)
+	}
+}
+
+func TestLoadBalancerProbe_Timeout(t *testing.T) {
+	release := make(chan struct{})
+	hung := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		<-release
+	}))
+	defer hung.Close()
+	defer close(release)
+
+	hungURL, _ := url.Parse(hung.URL)
+	port, _ := strconv.Atoi(hungURL.Port())
+
+	probe := &LoadBalancerProbe{
+		Rule:    ForwardingRule{FrontendProtocol: "http", FrontendPort: port},
+		Timeout: 20 * time.Millisecond,
+	}
+
+	start := time.Now()
+	if err := probe.check(ctx, "127.0.0.1"); err == nil {
+		t.Error("LoadBalancerProbe.check expected an error for a backend that never responds")
+	}
+
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("LoadBalancerProbe.check took %s, expected it to give up after its timeout", elapsed)
+	}
+}
+
+func TestLoadBalancerHandler_DrainInstance(t *testing.T) {
+	setup()
+	defer teardown()
+
+	instances := []string{"a", "b"}
+	mux.HandleFunc(fmt.Sprintf("%s/%s", lbPath, "1317575"), func(writer http.ResponseWriter, request *http.Request) {
+		if request.Method == http.MethodPatch {
+			var body map[string]interface{}
+			_ = json.NewDecoder(request.Body).Decode(&body)
+			if _, ok := body["firewall_rules"]; ok || len(body) != 1 {
+				t.Errorf("LoadBalancer update body = %+v, expected only instances", body)
+			}
+
+			instances = instances[:0]
+			for _, id := range body["instances"].([]interface{}) {
+				instances = append(instances, id.(string))
+			}
+			return
+		}
+
+		lb := lbBase{LoadBalancer: &LoadBalancer{ID: "1317575", Status: "active", Instances: instances}}
+		_ = json.NewEncoder(writer).Encode(lb)
+	})
+
+	options := &WaitOptions{Interval: time.Millisecond}
+	if err := client.LoadBalancer.DrainInstance(ctx, "1317575", "a", options); err != nil {
+		t.Errorf("LoadBalancer.DrainInstance returned %+v", err)
+	}
+
+	if !reflect.DeepEqual(instances, []string{"b"}) {
+		t.Errorf("LoadBalancer.DrainInstance left instances %+v, expected [b]", instances)
+	}
+
+	if err := client.LoadBalancer.DrainInstance(ctx, "1317575", "b", options); err == nil {
+		t.Error("LoadBalancer.DrainInstance expected an error when draining the only instance")
+	}
+
+	if err := client.LoadBalancer.RestoreInstance(ctx, "1317575", "a", options); err != nil {
+		t.Errorf("LoadBalancer.RestoreInstance returned %+v", err)
+	}
+
+	if !reflect.DeepEqual(instances, []string{"b", "a"}) {
+		t.Errorf("LoadBalancer.RestoreInstance left instances %+v, expected [b a]", instances)
+	}
+}
+
+func TestLoadBalancerReq_ValidateFirewallRules(t *testing.T) {
+	valid := &LoadBalancerReq{FirewallRules: []LBFirewallRule{
+		{Port: 443, IPType: "v4", Source: "0.0.0.0/0"},
+		{Port: 443, IPType: "v6", Source: "::/0"},
+		{Port: 80, IPType: "v4", Source: "cloudflare"},
+	}}
+	if err := valid.ValidateFirewallRules(); err != nil {
+		t.Errorf("LoadBalancerReq.ValidateFirewallRules returned %+v", err)
+	}
+
+	for _, rule := range []LBFirewallRule{
+		{Port: 443, IPType: "v6", Source: "0.0.0.0/0"},
+		{Port: 443, IPType: "v4", Source: "2001:db8::/32"},
+		{Port: 443, IPType: "ipv6", Source: "::/0"},
+	} {
+		req := &LoadBalancerReq{FirewallRules: []LBFirewallRule{rule}}
+		if err := req.ValidateFirewallRules(); err == nil {
+			t.Errorf("LoadBalancerReq.ValidateFirewallRules accepted %+v", rule)
+		}
+	}
+
+	if lb := (&LoadBalancer{IPV4: "192.0.2.1", IPV6: "2001:db8::1"}); !lb.DualStack() {
+		t.Errorf("LoadBalancer.DualStack returned false for %+v", lb)
+	}
+}
+
+func TestLoadBalancerHandler_ListByLabelAndFindByIP(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc(lbPath, func(writer http.ResponseWriter, request *http.Request) {
+		if request.URL.Query().Get("cursor") == "" {
+			fmt.Fprint(writer, `{"load_balancers": [{"id": "lb-1", "label": "web", "ipv4": "203.0.113.10"}], "meta": {"total": 2, "links": {"next": "page2"}}}`)
+			return
+		}
+		fmt.Fprint(writer, `{"load_balancers": [{"id": "lb-2", "label": "api", "ipv4": "203.0.113.20", "ipv6": "2001:db8::20"}], "meta": {"total": 2}}`)
+	})
+
+	lbs, err := client.LoadBalancer.ListByLabel(ctx, "api")
+	if err != nil {
+		t.Errorf("LoadBalancer.ListByLabel returned %+v", err)
+	}
+
+	expected := []LoadBalancer{{ID: "lb-2", Label: "api", IPV4: "203.0.113.20", IPV6: "2001:db8::20"}}
+	if !reflect.DeepEqual(lbs, expected) {
+		t.Errorf("LoadBalancer.ListByLabel returned %+v, expected %+v", lbs, expected)
+	}
+
+	lb, err := client.LoadBalancer.FindByIP(ctx, "2001:db8:0::20")
+	if err != nil {
+		t.Errorf("LoadBalancer.FindByIP returned %+v", err)
+	}
+
+	if !reflect.DeepEqual(lb, &expected[0]) {
+		t.Errorf("LoadBalancer.FindByIP returned %+v, expected %+v", lb, &expected[0])
+	}
+
+	if _, err = client.LoadBalancer.FindByIP(ctx, "198.51.100.1"); err == nil {
+		t.Error("LoadBalancer.FindByIP expected an error for an unknown IP")
+	}
+
+	if _, err = client.LoadBalancer.FindByIP(ctx, "not-an-ip"); err == nil {
+		t.Error("LoadBalancer.FindByIP expected an error for an invalid IP")
+	}
+}
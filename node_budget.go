@@ -0,0 +1,191 @@
+package govultr
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// NodeBudgetError is returned when a node pool change is refused because it
+// would take the monthly cost of VKE nodes over the budget set with
+// SetNodeBudget
+type NodeBudgetError struct {
+	MaxMonthlyCost float32
+	MonthlyCost    float32
+	ProjectedCost  float32
+}
+
+func (e *NodeBudgetError) Error() string {
+	return fmt.Sprintf("refusing node pool change: monthly node cost would rise from %.2f to %.2f, over the budget of %.2f",
+		e.MonthlyCost, e.ProjectedCost, e.MaxMonthlyCost)
+}
+
+// NodePoolCost represents the monthly cost of the nodes of a node pool.
+// Autoscaled pools are costed at their maximum size.
+type NodePoolCost struct {
+	ClusterID   string
+	NodePoolID  string
+	Plan        string
+	Nodes       int
+	MonthlyCost float32
+}
+
+// NodeCostReport represents the monthly cost of the nodes of every VKE
+// cluster on the account
+type NodeCostReport struct {
+	MonthlyCost float32
+	Pools       []NodePoolCost
+}
+
+// SetNodeBudget turns on a client-side guard that refuses to create clusters
+// and node pools, or scale node pools up, when the monthly cost of all VKE
+// nodes on the account would go over maxMonthlyCost, returning a
+// *NodeBudgetError instead. Costs use the monthly plan prices and the maximum
+// size of autoscaled pools. Use ContextWithForceScale to make such a change
+// on purpose. A maxMonthlyCost of 0 turns the guard off.
+func (c *Client) SetNodeBudget(maxMonthlyCost float32) {
+	c.nodeBudget = maxMonthlyCost
+}
+
+// NodeCost returns the monthly cost of the nodes of every VKE cluster
+func (k *KubernetesHandler) NodeCost(ctx context.Context) (*NodeCostReport, error) {
+	report, _, err := k.client.nodeCosts(ctx)
+	return report, err
+}
+
+// budgetedNodes returns how many nodes a pool is costed at
+func budgetedNodes(quantity, maxNodes int, autoScaler bool) int {
+	if autoScaler && maxNodes > quantity {
+		return maxNodes
+	}
+	return quantity
+}
+
+// nodeCosts returns the current node cost report along with the monthly price
+// of every plan
+func (c *Client) nodeCosts(ctx context.Context) (*NodeCostReport, map[string]float32, error) {
+	plans, err := collectPages(ctx, func(ctx context.Context, options *ListOptions) ([]Plan, *Meta, *http.Response, error) {
+		return c.Plan.List(ctx, "", options)
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	prices := make(map[string]float32, len(plans))
+	for i := range plans {
+		prices[plans[i].ID] = plans[i].MonthlyCost
+	}
+
+	clusters, err := collectPages(ctx, c.Kubernetes.ListClusters)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	report := &NodeCostReport{}
+	for i := range clusters {
+		for _, pool := range clusters[i].NodePools {
+			cost, err := poolCost(prices, clusters[i].ID, pool.ID, pool.Plan, budgetedNodes(pool.NodeQuantity, pool.MaxNodes, pool.AutoScaler))
+			if err != nil {
+				return nil, nil, err
+			}
+			report.Pools = append(report.Pools, cost)
+			report.MonthlyCost += cost.MonthlyCost
+		}
+	}
+
+	return report, prices, nil
+}
+
+func poolCost(prices map[string]float32, clusterID, nodePoolID, plan string, nodes int) (NodePoolCost, error) {
+	price, ok := prices[plan]
+	if !ok {
+		return NodePoolCost{}, fmt.Errorf("no price for node pool plan %s", plan)
+	}
+
+	return NodePoolCost{
+		ClusterID:   clusterID,
+		NodePoolID:  nodePoolID,
+		Plan:        plan,
+		Nodes:       nodes,
+		MonthlyCost: price * float32(nodes),
+	}, nil
+}
+
+// checkNodeBudget refuses a change when the budget is set and the cost of the
+// nodes after it would exceed the budget. change is given the current pool
+// costs and the plan prices and returns the costs after the change.
+func (c *Client) checkNodeBudget(ctx context.Context, change func(pools []NodePoolCost, prices map[string]float32) ([]NodePoolCost, error)) error { //nolint:lll
+	if c.nodeBudget <= 0 || forcedScale(ctx) {
+		return nil
+	}
+
+	report, prices, err := c.nodeCosts(ctx)
+	if err != nil {
+		return err
+	}
+
+	pools, err := change(report.Pools, prices)
+	if err != nil {
+		return err
+	}
+
+	var projected float32
+	for _, pool := range pools {
+		projected += pool.MonthlyCost
+	}
+
+	if projected > c.nodeBudget && projected > report.MonthlyCost {
+		return &NodeBudgetError{MaxMonthlyCost: c.nodeBudget, MonthlyCost: report.MonthlyCost, ProjectedCost: projected}
+	}
+
+	return nil
+}
+
+// checkNodeBudgetCreate checks adding node pools to a cluster against the budget
+func (c *Client) checkNodeBudgetCreate(ctx context.Context, vkeID string, reqs []NodePoolReq) error {
+	return c.checkNodeBudget(ctx, func(pools []NodePoolCost, prices map[string]float32) ([]NodePoolCost, error) {
+		for _, req := range reqs {
+			autoScaler := req.AutoScaler != nil && *req.AutoScaler
+			cost, err := poolCost(prices, vkeID, "", req.Plan, budgetedNodes(req.NodeQuantity, req.MaxNodes, autoScaler))
+			if err != nil {
+				return nil, err
+			}
+			pools = append(pools, cost)
+		}
+		return pools, nil
+	})
+}
+
+// checkNodeBudgetUpdate checks a node pool update against the budget
+func (c *Client) checkNodeBudgetUpdate(ctx context.Context, vkeID, nodePoolID string, update *NodePoolReqUpdate) error {
+	return c.checkNodeBudget(ctx, func(pools []NodePoolCost, prices map[string]float32) ([]NodePoolCost, error) {
+		pool, _, err := c.Kubernetes.GetNodePool(ctx, vkeID, nodePoolID)
+		if err != nil {
+			return nil, err
+		}
+
+		quantity, maxNodes, autoScaler := pool.NodeQuantity, pool.MaxNodes, pool.AutoScaler
+		if update.NodeQuantity > 0 {
+			quantity = update.NodeQuantity
+		}
+		if update.MaxNodes > 0 {
+			maxNodes = update.MaxNodes
+		}
+		if update.AutoScaler != nil {
+			autoScaler = *update.AutoScaler
+		}
+
+		cost, err := poolCost(prices, vkeID, nodePoolID, pool.Plan, budgetedNodes(quantity, maxNodes, autoScaler))
+		if err != nil {
+			return nil, err
+		}
+
+		for i := range pools {
+			if pools[i].ClusterID == vkeID && pools[i].NodePoolID == nodePoolID {
+				pools[i] = cost
+				return pools, nil
+			}
+		}
+		return append(pools, cost), nil
+	})
+}
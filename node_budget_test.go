@@ -0,0 +1,70 @@
+package govultr
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestClient_SetNodeBudget(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v2/plans", func(writer http.ResponseWriter, request *http.Request) {
+		fmt.Fprint(writer, `{"plans": [{"id": "vc2-2c-4gb", "monthly_cost": 20}, {"id": "vc2-4c-8gb", "monthly_cost": 40}], "meta": {"total": 2}}`)
+	})
+
+	mux.HandleFunc("/v2/kubernetes/clusters", func(writer http.ResponseWriter, request *http.Request) {
+		if request.Method == http.MethodPost {
+			t.Error("Kubernetes.CreateCluster sent a request over budget")
+			return
+		}
+		fmt.Fprint(writer, `{"vke_clusters": [
+			{"id": "a", "node_pools": [{"id": "np1", "plan": "vc2-2c-4gb", "node_quantity": 2}]},
+			{"id": "b", "node_pools": [{"id": "np2", "plan": "vc2-4c-8gb", "node_quantity": 1, "auto_scaler": true, "max_nodes": 2}]}
+		], "meta": {"total": 2}}`)
+	})
+
+	mux.HandleFunc("/v2/kubernetes/clusters/a/node-pools/np1", func(writer http.ResponseWriter, request *http.Request) {
+		fmt.Fprint(writer, `{"node_pool": {"id": "np1", "plan": "vc2-2c-4gb", "node_quantity": 2}}`)
+	})
+
+	report, err := client.Kubernetes.NodeCost(ctx)
+	if err != nil {
+		t.Fatalf("Kubernetes.NodeCost returned %+v", err)
+	}
+
+	expected := &NodeCostReport{
+		MonthlyCost: 120,
+		Pools: []NodePoolCost{
+			{ClusterID: "a", NodePoolID: "np1", Plan: "vc2-2c-4gb", Nodes: 2, MonthlyCost: 40},
+			{ClusterID: "b", NodePoolID: "np2", Plan: "vc2-4c-8gb", Nodes: 2, MonthlyCost: 80},
+		},
+	}
+	if !reflect.DeepEqual(report, expected) {
+		t.Errorf("Kubernetes.NodeCost returned %+v, expected %+v", report, expected)
+	}
+
+	client.SetNodeBudget(150)
+
+	var budgetErr *NodeBudgetError
+	_, _, err = client.Kubernetes.UpdateNodePool(ctx, "a", "np1", &NodePoolReqUpdate{NodeQuantity: 4})
+	if !errors.As(err, &budgetErr) || budgetErr.ProjectedCost != 160 {
+		t.Errorf("Kubernetes.UpdateNodePool returned %+v, expected a NodeBudgetError projecting 160", err)
+	}
+
+	if _, _, err = client.Kubernetes.UpdateNodePool(ctx, "a", "np1", &NodePoolReqUpdate{NodeQuantity: 3}); err != nil {
+		t.Errorf("Kubernetes.UpdateNodePool returned %+v within budget", err)
+	}
+
+	createReq := &ClusterReq{NodePools: []NodePoolReq{{Plan: "vc2-2c-4gb", NodeQuantity: 2}}}
+	if _, _, err = client.Kubernetes.CreateCluster(ctx, createReq); !errors.As(err, &budgetErr) {
+		t.Errorf("Kubernetes.CreateCluster returned %+v, expected a NodeBudgetError", err)
+	}
+
+	if _, _, err = client.Kubernetes.UpdateNodePool(ContextWithForceScale(ctx), "a", "np1", &NodePoolReqUpdate{NodeQuantity: 4}); err != nil {
+		t.Errorf("Kubernetes.UpdateNodePool returned %+v with a forced context", err)
+	}
+}
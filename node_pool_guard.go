@@ -0,0 +1,115 @@
+package govultr
+
+import (
+	"context"
+	"fmt"
+)
+
+type forceScaleContextKey struct{}
+
+// NodePoolGuardError is returned when a node pool change is refused by the
+// guard set with SetNodePoolGuard
+type NodePoolGuardError struct {
+	ClusterID  string
+	NodePoolID string
+	Reason     string
+}
+
+func (e *NodePoolGuardError) Error() string {
+	return fmt.Sprintf("node pool %s of cluster %s: %s", e.NodePoolID, e.ClusterID, e.Reason)
+}
+
+// NodePoolPolicy defines the type of the function consulted before a node pool
+// is scaled or deleted. update is nil for deletes. Returning an error refuses
+// the change and the error is returned to the caller.
+type NodePoolPolicy func(cluster *Cluster, pool *NodePool, update *NodePoolReqUpdate) error
+
+// SetNodePoolGuard turns on a client-side guard that refuses to scale a VKE
+// node pool below minNodes, either directly or through its autoscaler minimum,
+// and to delete the last node pool of a cluster, returning a
+// *NodePoolGuardError instead. A minNodes of 0 turns both off, use
+// SetLastNodePoolProtection to control the last pool refusal on its own. Use
+// ContextWithForceScale or ContextWithForceDelete to make such a change on
+// purpose.
+func (c *Client) SetNodePoolGuard(minNodes int) {
+	c.nodePoolMinNodes = minNodes
+	c.nodePoolProtectLast = minNodes > 0
+}
+
+// SetLastNodePoolProtection turns the refusal to delete the last node pool of
+// a cluster on or off independently of the minimum set by SetNodePoolGuard,
+// e.g. to protect the last pool while only a NodePoolPolicy is set
+func (c *Client) SetLastNodePoolProtection(enabled bool) {
+	c.nodePoolProtectLast = enabled
+}
+
+// SetNodePoolPolicy sets a policy consulted before any VKE node pool is scaled
+// or deleted, after the guard set by SetNodePoolGuard. It is bypassed the same
+// way as the guard.
+func (c *Client) SetNodePoolPolicy(policy NodePoolPolicy) {
+	c.nodePoolPolicy = policy
+}
+
+// ContextWithForceScale returns a copy of ctx that bypasses the guards set by
+// SetNodePoolGuard and SetNodePoolPolicy for node pool updates made with it
+func ContextWithForceScale(ctx context.Context) context.Context {
+	return context.WithValue(ctx, forceScaleContextKey{}, true)
+}
+
+func forcedScale(ctx context.Context) bool {
+	force, _ := ctx.Value(forceScaleContextKey{}).(bool)
+	return force
+}
+
+// checkNodePool applies the node pool guard and policy to a node pool update,
+// or a delete when update is nil
+func (c *Client) checkNodePool(ctx context.Context, vkeID, nodePoolID string, update *NodePoolReqUpdate) error {
+	if c.nodePoolMinNodes <= 0 && !c.nodePoolProtectLast && c.nodePoolPolicy == nil {
+		return nil
+	}
+
+	if (update == nil && forcedDelete(ctx)) || (update != nil && forcedScale(ctx)) {
+		return nil
+	}
+
+	cluster, _, err := c.Kubernetes.GetCluster(ctx, vkeID)
+	if err != nil {
+		return err
+	}
+
+	var pool *NodePool
+	for i := range cluster.NodePools {
+		if cluster.NodePools[i].ID == nodePoolID {
+			pool = &cluster.NodePools[i]
+			break
+		}
+	}
+
+	// Leave reporting an unknown node pool to the API
+	if pool == nil {
+		return nil
+	}
+
+	guardErr := &NodePoolGuardError{ClusterID: vkeID, NodePoolID: nodePoolID}
+	if update == nil && c.nodePoolProtectLast && len(cluster.NodePools) == 1 {
+		guardErr.Reason = "refusing to delete the last node pool"
+		return guardErr
+	}
+
+	if update != nil && c.nodePoolMinNodes > 0 {
+		switch {
+		case update.NodeQuantity > 0 && update.NodeQuantity < c.nodePoolMinNodes:
+			guardErr.Reason = fmt.Sprintf("refusing to scale to %d nodes, the minimum is %d", update.NodeQuantity, c.nodePoolMinNodes)
+			return guardErr
+		case update.MinNodes > 0 && update.MinNodes < c.nodePoolMinNodes:
+			guardErr.Reason = fmt.Sprintf("refusing to autoscale down to %d nodes, the minimum is %d", update.MinNodes, c.nodePoolMinNodes)
+			return guardErr
+		}
+	}
+
+	if c.nodePoolPolicy != nil {
+		return c.nodePoolPolicy(cluster, pool, update)
+	}
+
+	return nil
+}
@@ -0,0 +1,126 @@
+package govultr
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestClient_SetNodePoolGuard(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v2/kubernetes/clusters/vke", func(writer http.ResponseWriter, request *http.Request) {
+		fmt.Fprint(writer, `{"vke_cluster": {"id": "vke", "node_pools": [{"id": "np", "node_quantity": 3}]}}`)
+	})
+
+	var sent []string
+	mux.HandleFunc("/v2/kubernetes/clusters/vke/node-pools/np", func(writer http.ResponseWriter, request *http.Request) {
+		sent = append(sent, request.Method)
+		if request.Method == http.MethodPatch {
+			fmt.Fprint(writer, `{"node_pool": {"id": "np"}}`)
+		}
+	})
+
+	client.SetNodePoolGuard(2)
+
+	var guardErr *NodePoolGuardError
+	_, _, err := client.Kubernetes.UpdateNodePool(ctx, "vke", "np", &NodePoolReqUpdate{NodeQuantity: 1})
+	if !errors.As(err, &guardErr) {
+		t.Errorf("Kubernetes.UpdateNodePool returned %+v, expected a NodePoolGuardError", err)
+	}
+
+	_, _, err = client.Kubernetes.UpdateNodePool(ctx, "vke", "np", &NodePoolReqUpdate{MinNodes: 1, MaxNodes: 5})
+	if !errors.As(err, &guardErr) {
+		t.Errorf("Kubernetes.UpdateNodePool returned %+v, expected a NodePoolGuardError", err)
+	}
+
+	if err = client.Kubernetes.DeleteNodePool(ctx, "vke", "np"); !errors.As(err, &guardErr) {
+		t.Errorf("Kubernetes.DeleteNodePool returned %+v, expected a NodePoolGuardError", err)
+	}
+
+	if len(sent) != 0 {
+		t.Errorf("Kubernetes node pool guard sent %+v, expected no requests", sent)
+	}
+
+	if _, _, err = client.Kubernetes.UpdateNodePool(ctx, "vke", "np", &NodePoolReqUpdate{NodeQuantity: 2}); err != nil {
+		t.Errorf("Kubernetes.UpdateNodePool returned %+v", err)
+	}
+
+	if _, _, err = client.Kubernetes.UpdateNodePool(ContextWithForceScale(ctx), "vke", "np", &NodePoolReqUpdate{NodeQuantity: 1}); err != nil {
+		t.Errorf("Kubernetes.UpdateNodePool returned %+v with a forced context", err)
+	}
+
+	if err = client.Kubernetes.DeleteNodePool(ContextWithForceDelete(ctx), "vke", "np"); err != nil {
+		t.Errorf("Kubernetes.DeleteNodePool returned %+v with a forced context", err)
+	}
+
+	expected := []string{http.MethodPatch, http.MethodPatch, http.MethodDelete}
+	if !reflect.DeepEqual(sent, expected) {
+		t.Errorf("Kubernetes node pool guard sent %+v, expected %+v", sent, expected)
+	}
+}
+
+func TestClient_SetNodePoolPolicy(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v2/kubernetes/clusters/vke", func(writer http.ResponseWriter, request *http.Request) {
+		fmt.Fprint(writer, `{"vke_cluster": {"id": "vke", "label": "prod", "node_pools": [{"id": "np", "label": "default"}, {"id": "np2"}]}}`)
+	})
+
+	mux.HandleFunc("/v2/kubernetes/clusters/vke/node-pools/np", func(writer http.ResponseWriter, request *http.Request) {
+		t.Errorf("Kubernetes.DeleteNodePool sent %s, expected the policy to refuse", request.Method)
+	})
+
+	refused := errors.New("default pools of prod clusters are managed by hand")
+	client.SetNodePoolPolicy(func(cluster *Cluster, pool *NodePool, update *NodePoolReqUpdate) error {
+		if cluster.Label == "prod" && pool.Label == "default" && update == nil {
+			return refused
+		}
+		return nil
+	})
+
+	if err := client.Kubernetes.DeleteNodePool(ctx, "vke", "np"); !errors.Is(err, refused) {
+		t.Errorf("Kubernetes.DeleteNodePool returned %+v, expected %+v", err, refused)
+	}
+}
+
+func TestClient_SetLastNodePoolProtection(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v2/kubernetes/clusters/vke", func(writer http.ResponseWriter, request *http.Request) {
+		fmt.Fprint(writer, `{"vke_cluster": {"id": "vke", "node_pools": [{"id": "np", "node_quantity": 3}]}}`)
+	})
+
+	var sent []string
+	mux.HandleFunc("/v2/kubernetes/clusters/vke/node-pools/np", func(writer http.ResponseWriter, request *http.Request) {
+		sent = append(sent, request.Method)
+		fmt.Fprint(writer, `{"node_pool": {"id": "np", "node_quantity": 1}}`)
+	})
+
+	client.SetLastNodePoolProtection(true)
+
+	var guardErr *NodePoolGuardError
+	if err := client.Kubernetes.DeleteNodePool(ctx, "vke", "np"); !errors.As(err, &guardErr) {
+		t.Errorf("Kubernetes.DeleteNodePool returned %+v, expected a NodePoolGuardError", err)
+	}
+
+	if _, _, err := client.Kubernetes.UpdateNodePool(ctx, "vke", "np", &NodePoolReqUpdate{NodeQuantity: 1}); err != nil {
+		t.Errorf("Kubernetes.UpdateNodePool returned %+v, expected no minimum without SetNodePoolGuard", err)
+	}
+
+	client.SetNodePoolGuard(2)
+	client.SetLastNodePoolProtection(false)
+	if err := client.Kubernetes.DeleteNodePool(ctx, "vke", "np"); err != nil {
+		t.Errorf("Kubernetes.DeleteNodePool returned %+v, expected last pool protection to be off", err)
+	}
+
+	expected := []string{http.MethodPatch, http.MethodDelete}
+	if !reflect.DeepEqual(sent, expected) {
+		t.Errorf("Kubernetes node pool guard sent %+v, expected %+v", sent, expected)
+	}
+}
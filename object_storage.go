@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"strings"
 
 	"github.com/google/go-querystring/query"
 )
@@ -18,9 +19,14 @@ type ObjectStorageService interface {
 	List(ctx context.Context, options *ListOptions) ([]ObjectStorage, *Meta, *http.Response, error)
 
 	ListCluster(ctx context.Context, options *ListOptions) ([]ObjectStorageCluster, *Meta, *http.Response, error)
+	ClusterForRegion(ctx context.Context, region string) (*ObjectStorageCluster, error)
 	RegenerateKeys(ctx context.Context, id string) (*S3Keys, *http.Response, error)
+
+	CreateReplicated(ctx context.Context, clusterIDs []int, label string, options *WaitOptions) (*ObjectStorageReplicaSet, error)
 }
 
+const objectStorageStatusActive = "active"
+
 // ObjectStorageServiceHandler handles interaction between the object storage service and the Vultr API.
 type ObjectStorageServiceHandler struct {
 	client *Client
@@ -53,6 +59,37 @@ type ObjectStorageCluster struct {
 	Deploy   string `json:"deploy"`
 }
 
+// NormalizeS3Hostname returns an object storage hostname without a scheme,
+// path or trailing dot, in lower case, e.g. "HTTPS://EWR1.vultrobjects.com/"
+// becomes "ewr1.vultrobjects.com"
+func NormalizeS3Hostname(hostname string) string {
+	hostname = strings.TrimSpace(hostname)
+	if i := strings.Index(hostname, "://"); i >= 0 {
+		hostname = hostname[i+3:]
+	}
+	if i := strings.IndexByte(hostname, '/'); i >= 0 {
+		hostname = hostname[:i]
+	}
+	return strings.ToLower(strings.TrimSuffix(hostname, "."))
+}
+
+// Endpoint returns the HTTPS S3 endpoint of the cluster
+func (o *ObjectStorageCluster) Endpoint() string {
+	return "https://" + NormalizeS3Hostname(o.Hostname)
+}
+
+// Endpoint returns the HTTPS S3 endpoint for the keys
+func (s *S3Keys) Endpoint() string {
+	return "https://" + NormalizeS3Hostname(s.S3Hostname)
+}
+
+// ObjectStorageReplicaSet represents matching object storage subscriptions
+// provisioned in several clusters, e.g. for active/passive bucket replication
+type ObjectStorageReplicaSet struct {
+	Label         string
+	Subscriptions []ObjectStorage
+}
+
 type objectStoragesBase struct {
 	ObjectStorages []ObjectStorage `json:"object_storages"`
 	Meta           *Meta           `json:"meta"`
@@ -195,3 +232,64 @@ func (o *ObjectStorageServiceHandler) RegenerateKeys(ctx context.Context, id str
 
 	return s3Keys.S3Credentials, resp, nil
 }
+
+// ClusterForRegion returns the object storage cluster in a Vultr region. A
+// cluster that accepts new subscriptions is preferred when there are several.
+func (o *ObjectStorageServiceHandler) ClusterForRegion(ctx context.Context, region string) (*ObjectStorageCluster, error) {
+	clusters, err := collectPages(ctx, o.ListCluster)
+	if err != nil {
+		return nil, err
+	}
+
+	var found *ObjectStorageCluster
+	for i := range clusters {
+		if !strings.EqualFold(clusters[i].Region, region) {
+			continue
+		}
+		if clusters[i].Deploy == "yes" {
+			return &clusters[i], nil
+		}
+		if found == nil {
+			found = &clusters[i]
+		}
+	}
+
+	if found == nil {
+		return nil, fmt.Errorf("no object storage cluster in region %s", region)
+	}
+
+	return found, nil
+}
+
+// CreateReplicated creates an object storage subscription with the same label
+// in each of the given clusters and waits for them all to become active so
+// their hostnames and keys are available. If any subscription fails the ones
+// already created are returned along with the error so they can be cleaned up.
+func (o *ObjectStorageServiceHandler) CreateReplicated(ctx context.Context, clusterIDs []int, label string, options *WaitOptions) (*ObjectStorageReplicaSet, error) { //nolint:lll
+	replicaSet := &ObjectStorageReplicaSet{Label: label}
+	for _, clusterID := range clusterIDs {
+		objectStorage, _, err := o.Create(ctx, clusterID, label)
+		if err != nil {
+			return replicaSet, err
+		}
+		replicaSet.Subscriptions = append(replicaSet.Subscriptions, *objectStorage)
+	}
+
+	for i := range replicaSet.Subscriptions {
+		subscription := &replicaSet.Subscriptions[i]
+		err := waitFor(ctx, options, func(ctx context.Context) (string, bool, error) {
+			objectStorage, _, err := o.Get(ctx, subscription.ID)
+			if err != nil {
+				return "", false, err
+			}
+
+			*subscription = *objectStorage
+			return objectStorage.Status, objectStorage.Status == objectStorageStatusActive, nil
+		})
+		if err != nil {
+			return replicaSet, err
+		}
+	}
+
+	return replicaSet, nil
+}
@@ -1,10 +1,12 @@
 package govultr
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"reflect"
 	"testing"
+	"time"
 )
 
 func TestObjectStorageServiceHandler_Create(t *testing.T) {
@@ -201,3 +203,90 @@ func TestObjectStorageServiceHandler_RegenerateKeys(t *testing.T) {
 		t.Errorf("ObjectStorage.RegenerateKeys returned %+v, expected %+v", s3Keys, expected)
 	}
 }
+
+func TestObjectStorageServiceHandler_CreateReplicated(t *testing.T) {
+	setup()
+	defer teardown()
+
+	regions := map[float64]string{2: "ewr", 4: "sjc"}
+	mux.HandleFunc("/v2/object-storage", func(writer http.ResponseWriter, request *http.Request) {
+		var body map[string]interface{}
+		_ = json.NewDecoder(request.Body).Decode(&body)
+		clusterID := body["cluster_id"].(float64)
+		fmt.Fprintf(writer, `{"object_storage": {"id": "%s", "cluster_id": %v, "label": %q, "status": "pending"}}`, regions[clusterID], clusterID, body["label"])
+	})
+
+	for id, clusterID := range map[string]int{"ewr": 2, "sjc": 4} {
+		id, clusterID := id, clusterID
+		mux.HandleFunc("/v2/object-storage/"+id, func(writer http.ResponseWriter, request *http.Request) {
+			fmt.Fprintf(writer, `{"object_storage": {"id": %q, "cluster_id": %d, "region": %q, "label": "media", "status": "active", "s3_hostname": "%s1.vultrobjects.com", "s3_access_key": "key-%s"}}`, id, clusterID, id, id, id) //nolint:lll
+		})
+	}
+
+	replicaSet, err := client.ObjectStorage.CreateReplicated(ctx, []int{2, 4}, "media", &WaitOptions{Interval: time.Millisecond})
+	if err != nil {
+		t.Errorf("ObjectStorage.CreateReplicated returned %+v", err)
+	}
+
+	expected := &ObjectStorageReplicaSet{
+		Label: "media",
+		Subscriptions: []ObjectStorage{
+			{ID: "ewr", ObjectStoreClusterID: 2, Region: "ewr", Label: "media", Status: "active", S3Keys: S3Keys{S3Hostname: "ewr1.vultrobjects.com", S3AccessKey: "key-ewr"}},
+			{ID: "sjc", ObjectStoreClusterID: 4, Region: "sjc", Label: "media", Status: "active", S3Keys: S3Keys{S3Hostname: "sjc1.vultrobjects.com", S3AccessKey: "key-sjc"}},
+		},
+	}
+
+	if !reflect.DeepEqual(replicaSet, expected) {
+		t.Errorf("ObjectStorage.CreateReplicated returned %+v, expected %+v", replicaSet, expected)
+	}
+}
+
+func TestObjectStorageServiceHandler_ClusterForRegion(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v2/object-storage/clusters", func(writer http.ResponseWriter, request *http.Request) {
+		response := `{"clusters": [
+			{"id": 2, "region": "ewr", "hostname": "nj1.vultrobjects.com", "deploy": "no"},
+			{"id": 9, "region": "ewr", "hostname": "EWR1.vultrobjects.com", "deploy": "yes"},
+			{"id": 4, "region": "sjc", "hostname": "sjc1.vultrobjects.com", "deploy": "no"}
+		], "meta": {"total": 3, "links": {"next": "", "prev": ""}}}`
+		fmt.Fprint(writer, response)
+	})
+
+	cluster, err := client.ObjectStorage.ClusterForRegion(ctx, "EWR")
+	if err != nil {
+		t.Errorf("ObjectStorage.ClusterForRegion returned %+v", err)
+	}
+
+	expected := &ObjectStorageCluster{ID: 9, Region: "ewr", Hostname: "EWR1.vultrobjects.com", Deploy: "yes"}
+	if !reflect.DeepEqual(cluster, expected) {
+		t.Errorf("ObjectStorage.ClusterForRegion returned %+v, expected %+v", cluster, expected)
+	}
+
+	if endpoint := cluster.Endpoint(); endpoint != "https://ewr1.vultrobjects.com" {
+		t.Errorf("ObjectStorageCluster.Endpoint returned %s, expected https://ewr1.vultrobjects.com", endpoint)
+	}
+
+	cluster, err = client.ObjectStorage.ClusterForRegion(ctx, "sjc")
+	if err != nil || cluster.ID != 4 {
+		t.Errorf("ObjectStorage.ClusterForRegion returned %+v, %+v, expected cluster 4", cluster, err)
+	}
+
+	if _, err = client.ObjectStorage.ClusterForRegion(ctx, "lax"); err == nil {
+		t.Error("ObjectStorage.ClusterForRegion expected an error for a region without a cluster")
+	}
+}
+
+func TestNormalizeS3Hostname(t *testing.T) {
+	for input, expected := range map[string]string{
+		"ewr1.vultrobjects.com":           "ewr1.vultrobjects.com",
+		"HTTPS://EWR1.vultrobjects.com/":  "ewr1.vultrobjects.com",
+		"https://ewr1.vultrobjects.com/b": "ewr1.vultrobjects.com",
+		" ewr1.vultrobjects.com. ":        "ewr1.vultrobjects.com",
+	} {
+		if hostname := NormalizeS3Hostname(input); hostname != expected {
+			t.Errorf("NormalizeS3Hostname(%q) returned %s, expected %s", input, hostname, expected)
+		}
+	}
+}
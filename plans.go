@@ -2,7 +2,10 @@ package govultr
 
 import (
 	"context"
+	"fmt"
 	"net/http"
+	"reflect"
+	"sort"
 
 	"github.com/google/go-querystring/query"
 )
@@ -12,8 +15,23 @@ import (
 type PlanService interface {
 	List(ctx context.Context, planType string, options *ListOptions) ([]Plan, *Meta, *http.Response, error)
 	ListBareMetal(ctx context.Context, options *ListOptions) ([]BareMetalPlan, *Meta, *http.Response, error)
+
+	CheckChanges(ctx context.Context, store PlanCatalogStore) ([]PlanEvent, error)
+	EstimateEgressCost(ctx context.Context, planID string, expectedGB int, overageRate float32) (*EgressEstimate, error)
 }
 
+// BandwidthOverageRate is the published Vultr price in USD per GB of egress
+// beyond the bandwidth included with a plan. The plans endpoints do not report
+// it, so it is provided as a default for EstimateEgressCost.
+const BandwidthOverageRate float32 = 0.01
+
+// Plan event types reported by DiffPlans and CheckChanges
+const (
+	PlanAdded   = "added"
+	PlanChanged = "changed"
+	PlanRetired = "retired"
+)
+
 // PlanServiceHandler handles interaction with the Plans methods for the Vultr API
 type PlanServiceHandler struct {
 	client *Client
@@ -49,6 +67,33 @@ type Plan struct {
 	Locations   []string `json:"locations"`
 }
 
+// PlanCatalogStore persists the plan catalog between calls to CheckChanges
+type PlanCatalogStore interface {
+	Load(ctx context.Context) ([]Plan, error)
+	Save(ctx context.Context, plans []Plan) error
+}
+
+// PlanEvent represents a difference between two plan catalogs. Previous is nil
+// for added plans and Current is nil for retired plans.
+type PlanEvent struct {
+	Type     string
+	PlanID   string
+	Previous *Plan
+	Current  *Plan
+}
+
+// EgressEstimate is the monthly cost of a plan at an expected amount of egress
+type EgressEstimate struct {
+	PlanID      string
+	IncludedGB  int
+	ExpectedGB  int
+	OverageGB   int
+	OverageRate float32
+	OverageCost float32
+	MonthlyCost float32
+	TotalCost   float32
+}
+
 type plansBase struct {
 	Plans []Plan `json:"plans"`
 	Meta  *Meta  `json:"meta"`
@@ -113,3 +158,122 @@ func (p *PlanServiceHandler) ListBareMetal(ctx context.Context, options *ListOpt
 
 	return bmPlans.Plans, bmPlans.Meta, resp, nil
 }
+
+// CheckChanges fetches the current plan catalog, compares it to the catalog
+// last saved in store and saves the current one. The first call against an
+// empty store reports every plan as added.
+func (p *PlanServiceHandler) CheckChanges(ctx context.Context, store PlanCatalogStore) ([]PlanEvent, error) {
+	previous, err := store.Load(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	current, err := collectPages(ctx, func(ctx context.Context, options *ListOptions) ([]Plan, *Meta, *http.Response, error) {
+		return p.List(ctx, "", options)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := store.Save(ctx, current); err != nil {
+		return nil, err
+	}
+
+	return DiffPlans(previous, current), nil
+}
+
+// DiffPlans compares two plan catalogs and returns an event for every plan that
+// was added, retired, or changed in any way, sorted by plan ID
+func DiffPlans(previous, current []Plan) []PlanEvent {
+	before := make(map[string]*Plan, len(previous))
+	for i := range previous {
+		before[previous[i].ID] = &previous[i]
+	}
+
+	after := make(map[string]*Plan, len(current))
+	for i := range current {
+		after[current[i].ID] = &current[i]
+	}
+
+	var events []PlanEvent
+	for id, plan := range before {
+		if next, ok := after[id]; !ok {
+			events = append(events, PlanEvent{Type: PlanRetired, PlanID: id, Previous: plan})
+		} else if !plansEqual(plan, next) {
+			events = append(events, PlanEvent{Type: PlanChanged, PlanID: id, Previous: plan, Current: next})
+		}
+	}
+
+	for id, plan := range after {
+		if _, ok := before[id]; !ok {
+			events = append(events, PlanEvent{Type: PlanAdded, PlanID: id, Current: plan})
+		}
+	}
+
+	sort.Slice(events, func(i, j int) bool {
+		return events[i].PlanID < events[j].PlanID
+	})
+
+	return events
+}
+
+// plansEqual compares two plans ignoring the order of their locations
+func plansEqual(a, b *Plan) bool {
+	x, y := *a, *b
+	x.Locations = append([]string(nil), a.Locations...)
+	y.Locations = append([]string(nil), b.Locations...)
+	sort.Strings(x.Locations)
+	sort.Strings(y.Locations)
+
+	return reflect.DeepEqual(x, y)
+}
+
+// EstimateEgressCost looks up a cloud or bare metal plan and estimates its
+// monthly cost when expectedGB of egress is used, charging overageRate per GB
+// for egress beyond the plan's included bandwidth. Pass BandwidthOverageRate
+// unless the account has different pricing. Bandwidth pooling across an
+// account's instances is not taken into account.
+func (p *PlanServiceHandler) EstimateEgressCost(ctx context.Context, planID string, expectedGB int, overageRate float32) (*EgressEstimate, error) { //nolint:lll
+	estimate := &EgressEstimate{PlanID: planID, ExpectedGB: expectedGB, OverageRate: overageRate}
+
+	plans, err := collectPages(ctx, func(ctx context.Context, options *ListOptions) ([]Plan, *Meta, *http.Response, error) {
+		return p.List(ctx, "", options)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	found := false
+	for i := range plans {
+		if plans[i].ID == planID {
+			estimate.IncludedGB, estimate.MonthlyCost, found = plans[i].Bandwidth, plans[i].MonthlyCost, true
+			break
+		}
+	}
+
+	if !found {
+		bmPlans, err := collectPages(ctx, p.ListBareMetal)
+		if err != nil {
+			return nil, err
+		}
+
+		for i := range bmPlans {
+			if bmPlans[i].ID == planID {
+				estimate.IncludedGB, estimate.MonthlyCost, found = bmPlans[i].Bandwidth, bmPlans[i].MonthlyCost, true
+				break
+			}
+		}
+	}
+
+	if !found {
+		return nil, fmt.Errorf("plan %s not found", planID)
+	}
+
+	if expectedGB > estimate.IncludedGB {
+		estimate.OverageGB = expectedGB - estimate.IncludedGB
+	}
+	estimate.OverageCost = float32(estimate.OverageGB) * estimate.OverageRate
+	estimate.TotalCost = estimate.MonthlyCost + estimate.OverageCost
+
+	return estimate, nil
+}
@@ -1,6 +1,7 @@
 package govultr
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"reflect"
@@ -95,3 +96,105 @@ func TestPlanServiceHandler_GetBareMetalList(t *testing.T) {
 		t.Errorf("Plan.List  meta returned %+v, expected %+v", meta, expectedMeta)
 	}
 }
+
+type memoryPlanStore struct {
+	plans []Plan
+}
+
+func (m *memoryPlanStore) Load(_ context.Context) ([]Plan, error) {
+	return m.plans, nil
+}
+
+func (m *memoryPlanStore) Save(_ context.Context, plans []Plan) error {
+	m.plans = plans
+	return nil
+}
+
+func TestPlanServiceHandler_CheckChanges(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v2/plans", func(writer http.ResponseWriter, request *http.Request) {
+		response := `{"plans": [
+			{"id": "vc2-1c-1gb", "monthly_cost": 6, "locations": ["sjc", "ewr"]},
+			{"id": "vc2-1c-2gb", "monthly_cost": 12, "locations": ["ewr"]},
+			{"id": "vc2-2c-4gb", "monthly_cost": 24, "locations": ["ewr"]}
+		], "meta": {"total": 3, "links": {"next": "", "prev": ""}}}`
+		fmt.Fprint(writer, response)
+	})
+
+	store := &memoryPlanStore{plans: []Plan{
+		{ID: "vc2-1c-0.5gb", MonthlyCost: 3.5, Locations: []string{"ewr"}},
+		{ID: "vc2-1c-1gb", MonthlyCost: 6, Locations: []string{"ewr", "sjc"}},
+		{ID: "vc2-1c-2gb", MonthlyCost: 10, Locations: []string{"ewr"}},
+	}}
+
+	events, err := client.Plan.CheckChanges(ctx, store)
+	if err != nil {
+		t.Errorf("Plan.CheckChanges returned %+v", err)
+	}
+
+	expected := []PlanEvent{
+		{Type: PlanRetired, PlanID: "vc2-1c-0.5gb", Previous: &Plan{ID: "vc2-1c-0.5gb", MonthlyCost: 3.5, Locations: []string{"ewr"}}},
+		{
+			Type:     PlanChanged,
+			PlanID:   "vc2-1c-2gb",
+			Previous: &Plan{ID: "vc2-1c-2gb", MonthlyCost: 10, Locations: []string{"ewr"}},
+			Current:  &Plan{ID: "vc2-1c-2gb", MonthlyCost: 12, Locations: []string{"ewr"}},
+		},
+		{Type: PlanAdded, PlanID: "vc2-2c-4gb", Current: &Plan{ID: "vc2-2c-4gb", MonthlyCost: 24, Locations: []string{"ewr"}}},
+	}
+
+	if !reflect.DeepEqual(events, expected) {
+		t.Errorf("Plan.CheckChanges returned %+v, expected %+v", events, expected)
+	}
+
+	if len(store.plans) != 3 {
+		t.Errorf("Plan.CheckChanges saved %d plans, expected 3", len(store.plans))
+	}
+}
+
+func TestPlanServiceHandler_EstimateEgressCost(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v2/plans", func(writer http.ResponseWriter, request *http.Request) {
+		fmt.Fprint(writer, `{"plans": [{"id": "vc2-1c-1gb", "bandwidth": 1024, "monthly_cost": 5}], "meta": {"total": 1}}`)
+	})
+
+	mux.HandleFunc("/v2/plans-metal", func(writer http.ResponseWriter, request *http.Request) {
+		fmt.Fprint(writer, `{"plans_metal": [{"id": "vbm-4c-32gb", "bandwidth": 5120, "monthly_cost": 120}], "meta": {"total": 1}}`)
+	})
+
+	estimate, err := client.Plan.EstimateEgressCost(ctx, "vc2-1c-1gb", 2024, 0.02)
+	if err != nil {
+		t.Errorf("Plan.EstimateEgressCost returned %+v", err)
+	}
+
+	expected := &EgressEstimate{
+		PlanID:      "vc2-1c-1gb",
+		IncludedGB:  1024,
+		ExpectedGB:  2024,
+		OverageGB:   1000,
+		OverageRate: 0.02,
+		OverageCost: 1000 * 0.02,
+		MonthlyCost: 5,
+		TotalCost:   5 + 1000*0.02,
+	}
+	if !reflect.DeepEqual(estimate, expected) {
+		t.Errorf("Plan.EstimateEgressCost returned %+v, expected %+v", estimate, expected)
+	}
+
+	estimate, err = client.Plan.EstimateEgressCost(ctx, "vbm-4c-32gb", 100, BandwidthOverageRate)
+	if err != nil {
+		t.Errorf("Plan.EstimateEgressCost returned %+v", err)
+	}
+
+	if estimate.OverageGB != 0 || estimate.TotalCost != 120 {
+		t.Errorf("Plan.EstimateEgressCost returned %+v, expected no overage", estimate)
+	}
+
+	if _, err = client.Plan.EstimateEgressCost(ctx, "missing", 0, BandwidthOverageRate); err == nil {
+		t.Error("Plan.EstimateEgressCost returned no error for an unknown plan")
+	}
+}
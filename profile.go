@@ -0,0 +1,149 @@
+package govultr
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ProfileConfigEnv is the environment variable overriding the location of the
+// profiles file
+const ProfileConfigEnv = "VULTR_CONFIG"
+
+// Profile represents a named client configuration. RateLimit is a duration
+// such as "500ms" as accepted by SetRateLimit.
+type Profile struct {
+	APIKey     string `json:"api_key"`
+	BaseURL    string `json:"base_url,omitempty"`
+	RateLimit  string `json:"rate_limit,omitempty"`
+	RetryLimit *int   `json:"retry_limit,omitempty"`
+}
+
+type profilesFile struct {
+	Profiles map[string]Profile `json:"profiles"`
+}
+
+// LoadProfile returns the named profile from the profiles file with any
+// values set in the environment applied on top. The file is read from the
+// path in VULTR_CONFIG, or vultr/profiles.json in the user config directory,
+// and is optional when the profile is fully defined by the environment.
+// Environment variables are named VULTR_PROFILE_<NAME>_<FIELD>, for example
+// VULTR_PROFILE_STAGING_API_KEY.
+func LoadProfile(name string) (*Profile, error) {
+	path, err := profilesPath()
+	if err != nil {
+		return nil, err
+	}
+
+	profile := Profile{}
+	found := false
+
+	data, err := os.ReadFile(path)
+	switch {
+	case err == nil:
+		file := profilesFile{}
+		if err := json.Unmarshal(data, &file); err != nil {
+			return nil, fmt.Errorf("parsing %s: %w", path, err)
+		}
+		profile, found = file.Profiles[name]
+	case !errors.Is(err, os.ErrNotExist):
+		return nil, err
+	}
+
+	prefix := "VULTR_PROFILE_" + strings.ToUpper(strings.ReplaceAll(name, "-", "_")) + "_"
+	for field, value := range map[string]*string{
+		"API_KEY":    &profile.APIKey,
+		"BASE_URL":   &profile.BaseURL,
+		"RATE_LIMIT": &profile.RateLimit,
+	} {
+		if env, ok := os.LookupEnv(prefix + field); ok {
+			*value = env
+			found = true
+		}
+	}
+
+	if env, ok := os.LookupEnv(prefix + "RETRY_LIMIT"); ok {
+		retryLimit, err := strconv.Atoi(env)
+		if err != nil {
+			return nil, fmt.Errorf("%sRETRY_LIMIT: %w", prefix, err)
+		}
+		profile.RetryLimit = &retryLimit
+		found = true
+	}
+
+	if !found {
+		return nil, fmt.Errorf("profile %q not found", name)
+	}
+
+	return &profile, nil
+}
+
+// NewFromProfile returns a Vultr API Client configured from the named profile
+func NewFromProfile(name string) (*Client, error) {
+	profile, err := LoadProfile(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return profile.NewClient()
+}
+
+// NewClient returns a Vultr API Client configured from the profile
+func (p *Profile) NewClient() (*Client, error) {
+	if p.APIKey == "" {
+		return nil, errors.New("profile has no API key")
+	}
+
+	client := NewClient(nil)
+	client.SetCredentialsProvider(staticCredentials(p.APIKey))
+
+	if p.BaseURL != "" {
+		if err := client.SetBaseURL(p.BaseURL); err != nil {
+			return nil, err
+		}
+	}
+
+	if p.RateLimit != "" {
+		rateLimit, err := time.ParseDuration(p.RateLimit)
+		if err != nil {
+			return nil, err
+		}
+		client.SetRateLimit(rateLimit)
+	}
+
+	if p.RetryLimit != nil {
+		client.SetRetryLimit(*p.RetryLimit)
+	}
+
+	return client, nil
+}
+
+func profilesPath() (string, error) {
+	if path := os.Getenv(ProfileConfigEnv); path != "" {
+		return path, nil
+	}
+
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, "vultr", "profiles.json"), nil
+}
+
+// staticCredentials is a CredentialsProvider for a fixed API key
+type staticCredentials string
+
+func (s staticCredentials) APIKey(_ context.Context) (string, error) {
+	return string(s), nil
+}
+
+func (s staticCredentials) Refresh(_ context.Context) (string, error) {
+	return "", errors.New("a static API key cannot be refreshed")
+}
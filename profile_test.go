@@ -0,0 +1,71 @@
+package govultr
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestLoadProfile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "profiles.json")
+	config := `{"profiles": {"staging": {"api_key": "file-key", "base_url": "https://staging.example.com", "rate_limit": "700ms"}}}`
+	if err := os.WriteFile(path, []byte(config), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv(ProfileConfigEnv, path)
+	t.Setenv("VULTR_PROFILE_STAGING_API_KEY", "env-key")
+	t.Setenv("VULTR_PROFILE_STAGING_RETRY_LIMIT", "5")
+
+	profile, err := LoadProfile("staging")
+	if err != nil {
+		t.Fatalf("LoadProfile returned %+v", err)
+	}
+
+	retryLimit := 5
+	expected := &Profile{
+		APIKey:     "env-key",
+		BaseURL:    "https://staging.example.com",
+		RateLimit:  "700ms",
+		RetryLimit: &retryLimit,
+	}
+
+	if !reflect.DeepEqual(profile, expected) {
+		t.Errorf("LoadProfile returned %+v, expected %+v", profile, expected)
+	}
+
+	if _, err = LoadProfile("production"); err == nil {
+		t.Error("LoadProfile returned no error for a missing profile")
+	}
+}
+
+func TestNewFromProfile(t *testing.T) {
+	t.Setenv(ProfileConfigEnv, filepath.Join(t.TempDir(), "missing.json"))
+	t.Setenv("VULTR_PROFILE_DEV_API_KEY", "dev-key")
+	t.Setenv("VULTR_PROFILE_DEV_BASE_URL", "https://dev.example.com")
+	t.Setenv("VULTR_PROFILE_DEV_RATE_LIMIT", "300ms")
+	t.Setenv("VULTR_PROFILE_DEV_RETRY_LIMIT", "2")
+
+	c, err := NewFromProfile("dev")
+	if err != nil {
+		t.Fatalf("NewFromProfile returned %+v", err)
+	}
+
+	if c.BaseURL.String() != "https://dev.example.com" {
+		t.Errorf("NewFromProfile base URL = %s, expected https://dev.example.com", c.BaseURL)
+	}
+
+	if c.client.RetryWaitMax != 300*time.Millisecond {
+		t.Errorf("NewFromProfile rate limit = %s, expected 300ms", c.client.RetryWaitMax)
+	}
+
+	if c.client.RetryMax != 2 {
+		t.Errorf("NewFromProfile retry limit = %d, expected 2", c.client.RetryMax)
+	}
+
+	if key, _ := c.credentials.APIKey(ctx); key != "dev-key" {
+		t.Errorf("NewFromProfile API key = %s, expected dev-key", key)
+	}
+}
@@ -0,0 +1,229 @@
+package govultr
+
+import (
+	"context"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-retryablehttp"
+)
+
+// Rate limit headers returned by the API
+const (
+	rateLimitLimitHeader     = "X-RateLimit-Limit"
+	rateLimitRemainingHeader = "X-RateLimit-Remaining"
+	rateLimitResetHeader     = "X-RateLimit-Reset"
+)
+
+// RateLimiter is the interface used to pace requests before they are sent to
+// the Vultr API. Implementations backed by a shared store, such as Redis, let
+// several processes using one API key cooperatively stay under the API limits.
+type RateLimiter interface {
+	// Wait blocks until a request may be sent or ctx is done
+	Wait(ctx context.Context) error
+}
+
+// RateLimitObserver is implemented by a RateLimiter that adjusts itself from the
+// rate limit headers of API responses. The client calls ObserveRateLimit after
+// every response when the limiter set with SetRateLimiter implements it.
+type RateLimitObserver interface {
+	ObserveRateLimit(header http.Header)
+}
+
+// TokenBucket is a concurrency-safe, in-memory RateLimiter that allows bursts
+// of up to burst requests and refills at rate requests per second
+type TokenBucket struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+
+	// No tokens are handed out before this time, set when the API reports
+	// none remaining
+	notBefore time.Time
+}
+
+// NewTokenBucket returns a full TokenBucket. rate must be greater than zero.
+func NewTokenBucket(rate float64, burst int) *TokenBucket {
+	return &TokenBucket{
+		rate:   rate,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+// Wait takes a token from the bucket, blocking until one is available
+func (t *TokenBucket) Wait(ctx context.Context) error {
+	for {
+		delay := t.reserve()
+		if delay <= 0 {
+			return nil
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// reserve takes a token if one is available, otherwise it returns how long
+// until the next token is added
+func (t *TokenBucket) reserve() time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := time.Now()
+	if now.Before(t.notBefore) {
+		return t.notBefore.Sub(now)
+	}
+
+	t.tokens += now.Sub(t.last).Seconds() * t.rate
+	if t.tokens > t.burst {
+		t.tokens = t.burst
+	}
+	t.last = now
+
+	if t.tokens >= 1 {
+		t.tokens--
+		return 0
+	}
+
+	return time.Duration((1 - t.tokens) / t.rate * float64(time.Second))
+}
+
+// ObserveRateLimit seeds the bucket from the X-RateLimit headers of a response.
+// The burst is set to the reported limit, the tokens are lowered to what the API
+// says remains, and when nothing remains no tokens are handed out until the
+// reported reset.
+func (t *TokenBucket) ObserveRateLimit(header http.Header) {
+	limit, errLimit := strconv.Atoi(header.Get(rateLimitLimitHeader))
+	remaining, errRemaining := strconv.Atoi(header.Get(rateLimitRemainingHeader))
+	reset, hasReset := parseRateLimitReset(header)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if errLimit == nil && limit > 0 {
+		t.burst = float64(limit)
+	}
+
+	if errRemaining == nil && float64(remaining) < t.tokens {
+		t.tokens = float64(remaining)
+	}
+
+	if errRemaining == nil && remaining == 0 && hasReset {
+		t.notBefore = time.Now().Add(reset)
+	}
+}
+
+// parseRateLimitReset returns the time until the rate limit resets. The
+// X-RateLimit-Reset header is either a number of seconds or a Unix timestamp.
+func parseRateLimitReset(header http.Header) (time.Duration, bool) {
+	reset, err := strconv.ParseInt(header.Get(rateLimitResetHeader), 10, 64)
+	if err != nil || reset < 0 {
+		return 0, false
+	}
+
+	// Anything past 2001 can only be a timestamp
+	if reset > 1e9 {
+		until := time.Until(time.Unix(reset, 0))
+		if until < 0 {
+			until = 0
+		}
+		return until, true
+	}
+
+	return time.Duration(reset) * time.Second, true
+}
+
+// SetRateLimitRetries retries requests rejected with 429 Too Many Requests up
+// to n times, separately from the retries of SetRetryLimit which then only
+// cover server errors and failed connections. Each retry waits for the
+// Retry-After or X-RateLimit-Reset header when one is returned, otherwise for
+// an exponential backoff with jitter between the SetRateLimit bounds.
+func (c *Client) SetRateLimitRetries(n int) {
+	c.rateLimitRetries = n
+}
+
+// retryPolicy is the retry policy of the underlying client. Responses are
+// retried when their ErrorCategory is retryable, except for 429s which are left
+// to sendRateLimited once SetRateLimitRetries has been called.
+func (c *Client) retryPolicy(ctx context.Context, resp *http.Response, err error) (bool, error) {
+	if err != nil || ctx.Err() != nil {
+		return retryablehttp.DefaultRetryPolicy(ctx, resp, err)
+	}
+
+	if c.rateLimitRetries >= 0 && resp.StatusCode == http.StatusTooManyRequests {
+		return false, nil
+	}
+	return statusCategory(resp.StatusCode).IsRetryable(), nil
+}
+
+// sendRateLimited sends a request, retrying 429s as set by SetRateLimitRetries
+// and passing the rate limit headers of each response to the rate limiter
+func (c *Client) sendRateLimited(ctx context.Context, rreq *retryablehttp.Request) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		res, err := c.send(ctx, rreq)
+		if err != nil {
+			return nil, err
+		}
+
+		if observer, ok := c.rateLimiter.(RateLimitObserver); ok {
+			observer.ObserveRateLimit(res.Header)
+		}
+
+		if res.StatusCode != http.StatusTooManyRequests || attempt >= c.rateLimitRetries {
+			return res, nil
+		}
+
+		wait := rateLimitBackoff(c.client.RetryWaitMin, c.client.RetryWaitMax, attempt, res)
+		drainAndClose(res.Body)
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// rateLimitBackoff is the retryablehttp.Backoff used by the client. It waits for
+// the Retry-After or X-RateLimit-Reset header of a 429 when present, the latter
+// capped at maxWait, otherwise for an exponential backoff with equal jitter so
+// clients rejected together do not retry together.
+func rateLimitBackoff(minWait, maxWait time.Duration, attemptNum int, resp *http.Response) time.Duration {
+	if resp != nil && resp.StatusCode == http.StatusTooManyRequests {
+		if resp.Header.Get("Retry-After") != "" {
+			return retryablehttp.DefaultBackoff(minWait, maxWait, attemptNum, resp)
+		}
+		if reset, ok := parseRateLimitReset(resp.Header); ok {
+			if reset > maxWait {
+				return maxWait
+			}
+			return reset
+		}
+	}
+
+	backoff := retryablehttp.DefaultBackoff(minWait, maxWait, attemptNum, nil)
+	if half := int64(backoff / 2); half > 0 {
+		backoff = time.Duration(half + rand.Int63n(half)) //nolint:gosec
+	}
+	return backoff
+}
+
+// SetRateLimiter paces every request through the given RateLimiter before it
+// is sent.
+func (c *Client) SetRateLimiter(limiter RateLimiter) {
+	c.rateLimiter = limiter
+}
@@ -0,0 +1,155 @@
+package govultr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-retryablehttp"
+)
+
+func TestTokenBucket_Wait(t *testing.T) {
+	bucket := NewTokenBucket(1000, 2)
+
+	start := time.Now()
+	for i := 0; i < 4; i++ {
+		if err := bucket.Wait(ctx); err != nil {
+			t.Fatalf("TokenBucket.Wait returned %+v", err)
+		}
+	}
+
+	if elapsed := time.Since(start); elapsed < time.Millisecond {
+		t.Errorf("TokenBucket.Wait allowed 4 requests in %s, expected at least %s", elapsed, time.Millisecond)
+	}
+
+	empty := NewTokenBucket(0.001, 0)
+	c, cancel := context.WithTimeout(ctx, time.Millisecond)
+	defer cancel()
+	if err := empty.Wait(c); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("TokenBucket.Wait returned %+v, expected %+v", err, context.DeadlineExceeded)
+	}
+}
+
+type countingLimiter struct {
+	calls int
+}
+
+func (c *countingLimiter) Wait(_ context.Context) error {
+	c.calls++
+	return nil
+}
+
+func TestClient_SetRateLimiter(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/", func(writer http.ResponseWriter, request *http.Request) {
+		fmt.Fprint(writer, `{}`)
+	})
+
+	limiter := &countingLimiter{}
+	client.SetRateLimiter(limiter)
+
+	for i := 0; i < 3; i++ {
+		req, _ := client.NewRequest(ctx, http.MethodGet, "/", nil)
+		if _, err := client.DoWithContext(ctx, req, nil); err != nil {
+			t.Fatalf("DoWithContext(): %v", err)
+		}
+	}
+
+	if limiter.calls != 3 {
+		t.Errorf("RateLimiter.Wait called %d times, expected 3", limiter.calls)
+	}
+}
+
+func TestTokenBucket_ObserveRateLimit(t *testing.T) {
+	bucket := NewTokenBucket(1000, 5)
+
+	header := http.Header{}
+	header.Set("X-RateLimit-Limit", "30")
+	header.Set("X-RateLimit-Remaining", "2")
+	bucket.ObserveRateLimit(header)
+
+	if bucket.burst != 30 || bucket.tokens != 2 {
+		t.Errorf("TokenBucket.ObserveRateLimit set burst %v and tokens %v, expected 30 and 2", bucket.burst, bucket.tokens)
+	}
+
+	header.Set("X-RateLimit-Remaining", "0")
+	header.Set("X-RateLimit-Reset", "60")
+	bucket.ObserveRateLimit(header)
+
+	if delay := bucket.reserve(); delay < 59*time.Second {
+		t.Errorf("TokenBucket.reserve returned %s after the limit was exhausted, expected about a minute", delay)
+	}
+}
+
+func TestClient_SetRateLimitRetries(t *testing.T) {
+	setup()
+	defer teardown()
+
+	requests := 0
+	mux.HandleFunc("/v2/ssh-keys/1", func(writer http.ResponseWriter, request *http.Request) {
+		requests++
+		if requests <= 2 {
+			writer.Header().Set("X-RateLimit-Reset", "0")
+			http.Error(writer, `{"error": "rate limit exceeded"}`, http.StatusTooManyRequests)
+			return
+		}
+		fmt.Fprint(writer, `{"ssh_key": {"id": "1"}}`)
+	})
+
+	client.SetRetryLimit(0)
+	client.SetRateLimitRetries(1)
+	if _, _, err := client.SSHKey.Get(ctx, "1"); err == nil {
+		t.Error("SSHKey.Get returned no error, expected the second 429")
+	}
+
+	if requests != 2 {
+		t.Errorf("SSHKey.Get sent %d requests, expected 2", requests)
+	}
+
+	requests = 0
+	client.SetRateLimitRetries(2)
+	if _, _, err := client.SSHKey.Get(ctx, "1"); err != nil {
+		t.Errorf("SSHKey.Get returned %+v", err)
+	}
+
+	if requests != 3 {
+		t.Errorf("SSHKey.Get sent %d requests, expected 3", requests)
+	}
+}
+
+func TestRateLimitBackoff(t *testing.T) {
+	for attempt := 0; attempt < 4; attempt++ {
+		full := retryablehttp.DefaultBackoff(100*time.Millisecond, time.Second, attempt, nil)
+		if wait := rateLimitBackoff(100*time.Millisecond, time.Second, attempt, nil); wait < full/2 || wait > full {
+			t.Errorf("rateLimitBackoff(%d) = %s, expected between %s and %s", attempt, wait, full/2, full)
+		}
+	}
+
+	resp := &http.Response{StatusCode: http.StatusTooManyRequests, Header: http.Header{}}
+	resp.Header.Set("Retry-After", "3")
+	if wait := rateLimitBackoff(time.Millisecond, time.Second, 0, resp); wait != 3*time.Second {
+		t.Errorf("rateLimitBackoff = %s with Retry-After 3, expected 3s", wait)
+	}
+
+	resp.Header = http.Header{}
+	resp.Header.Set(rateLimitResetHeader, "2")
+	if wait := rateLimitBackoff(time.Millisecond, time.Second, 0, resp); wait != time.Second {
+		t.Errorf("rateLimitBackoff = %s with X-RateLimit-Reset 2, expected the 1s maximum", wait)
+	}
+
+	resp.Header.Set(rateLimitResetHeader, strconv.FormatInt(time.Now().Add(time.Hour).Unix(), 10))
+	if wait := rateLimitBackoff(time.Millisecond, time.Second, 0, resp); wait != time.Second {
+		t.Errorf("rateLimitBackoff = %s with an X-RateLimit-Reset timestamp an hour away, expected the 1s maximum", wait)
+	}
+
+	resp.Header.Set(rateLimitResetHeader, "0")
+	if wait := rateLimitBackoff(time.Millisecond, time.Second, 0, resp); wait != 0 {
+		t.Errorf("rateLimitBackoff = %s with X-RateLimit-Reset 0, expected 0s", wait)
+	}
+}
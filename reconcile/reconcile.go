@@ -0,0 +1,395 @@
+// Package reconcile compares a desired state for Vultr resources against their
+// live state, producing a plan of typed changes that can be reviewed and then
+// applied on top of the govultr services.
+package reconcile
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/vultr/govultr/v3"
+)
+
+// Change actions
+const (
+	ActionCreate = "create"
+	ActionUpdate = "update"
+	ActionDelete = "delete"
+)
+
+// Resource types reported on a Change
+const (
+	ResourceInstance      = "instance"
+	ResourceDomainRecord  = "domain_record"
+	ResourceFirewallGroup = "firewall_group"
+	ResourceFirewallRule  = "firewall_rule"
+	ResourceLoadBalancer  = "load_balancer"
+)
+
+// Change represents a single difference between the desired and live state of
+// a resource. Field is only set for updates.
+type Change struct {
+	Resource string
+	ID       string
+	Action   string
+	Field    string
+	Current  string
+	Desired  string
+
+	apply func(ctx context.Context) error
+}
+
+func (c *Change) String() string {
+	switch c.Action {
+	case ActionCreate:
+		return fmt.Sprintf("create %s %s: %s", c.Resource, c.ID, c.Desired)
+	case ActionDelete:
+		return fmt.Sprintf("delete %s %s: %s", c.Resource, c.ID, c.Current)
+	}
+	return fmt.Sprintf("update %s %s %s: %q -> %q", c.Resource, c.ID, c.Field, c.Current, c.Desired)
+}
+
+// Plan holds the changes needed to bring live state in line with the desired
+// state, in the order Apply makes them
+type Plan struct {
+	Changes []Change
+}
+
+// Empty reports whether the live state already matches the desired state
+func (p *Plan) Empty() bool {
+	return len(p.Changes) == 0
+}
+
+func (p *Plan) String() string {
+	lines := make([]string, 0, len(p.Changes))
+	for i := range p.Changes {
+		lines = append(lines, p.Changes[i].String())
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Instance is the desired state of an existing instance. Empty strings and nil
+// slices leave a field unmanaged, an empty Tags slice removes all tags.
+type Instance struct {
+	ID              string
+	Label           string
+	Plan            string
+	Tags            []string
+	FirewallGroupID *string
+}
+
+// DNSZone is the desired set of records on a domain. Records are matched on
+// type, name and data. With Prune set records that are not desired, including
+// the default NS records, are deleted.
+type DNSZone struct {
+	Domain  string
+	Records []govultr.DomainRecordReq
+	Prune   bool
+}
+
+// FirewallGroup is the desired state of an existing firewall group. Rules are
+// matched on everything but their notes. With Prune set rules that are not
+// desired are deleted.
+type FirewallGroup struct {
+	ID          string
+	Description string
+	Rules       []govultr.FirewallRuleReq
+	Prune       bool
+}
+
+// LoadBalancer is the desired state of an existing load balancer. Empty
+// strings and nil slices leave a field unmanaged.
+type LoadBalancer struct {
+	ID        string
+	Label     string
+	Instances []string
+}
+
+// Reconciler plans and applies changes with a govultr client
+type Reconciler struct {
+	client *govultr.Client
+}
+
+// New returns a Reconciler that uses client for every API call
+func New(client *govultr.Client) *Reconciler {
+	return &Reconciler{client: client}
+}
+
+// Apply makes the changes in a plan in order, stopping at the first failure.
+// Only changes returned by the Plan methods can be applied, a plan containing
+// any other change is refused before anything is changed.
+func (r *Reconciler) Apply(ctx context.Context, plan *Plan) error {
+	for i := range plan.Changes {
+		if plan.Changes[i].apply == nil {
+			return fmt.Errorf("%s: change was not planned by a Reconciler", plan.Changes[i].String())
+		}
+	}
+
+	for i := range plan.Changes {
+		if err := plan.Changes[i].apply(ctx); err != nil {
+			return fmt.Errorf("%s: %w", plan.Changes[i].String(), err)
+		}
+	}
+	return nil
+}
+
+// PlanInstance returns the changes needed for an instance to match desired
+func (r *Reconciler) PlanInstance(ctx context.Context, desired *Instance) (*Plan, error) {
+	instance, _, err := r.client.Instance.Get(ctx, desired.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &Plan{}
+	update := func(field, current, want string, value interface{}) {
+		plan.Changes = append(plan.Changes, Change{
+			Resource: ResourceInstance,
+			ID:       desired.ID,
+			Action:   ActionUpdate,
+			Field:    field,
+			Current:  current,
+			Desired:  want,
+			apply:    r.patch(fmt.Sprintf("/v2/instances/%s", desired.ID), govultr.RequestBody{field: value}),
+		})
+	}
+
+	if desired.Label != "" && desired.Label != instance.Label {
+		update("label", instance.Label, desired.Label, desired.Label)
+	}
+
+	if desired.Tags != nil && !sameSet(desired.Tags, instance.Tags) {
+		update("tags", joinSorted(instance.Tags), joinSorted(desired.Tags), desired.Tags)
+	}
+
+	if desired.FirewallGroupID != nil && *desired.FirewallGroupID != instance.FirewallGroupID {
+		update("firewall_group_id", instance.FirewallGroupID, *desired.FirewallGroupID, *desired.FirewallGroupID)
+	}
+
+	if desired.Plan != "" && desired.Plan != instance.Plan {
+		update("plan", instance.Plan, desired.Plan, desired.Plan)
+	}
+
+	return plan, nil
+}
+
+// PlanDNSZone returns the changes needed for the records of a domain to match desired
+func (r *Reconciler) PlanDNSZone(ctx context.Context, desired *DNSZone) (*Plan, error) {
+	records, err := govultr.CollectPages(ctx, func(ctx context.Context, options *govultr.ListOptions) ([]govultr.DomainRecord, *govultr.Meta, *http.Response, error) { //nolint:lll
+		return r.client.DomainRecord.List(ctx, desired.Domain, options)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	live := make(map[string]*govultr.DomainRecord, len(records))
+	for i := range records {
+		live[recordKey(records[i].Type, records[i].Name, records[i].Data)] = &records[i]
+	}
+
+	plan := &Plan{}
+	wanted := make(map[string]bool, len(desired.Records))
+	for i := range desired.Records {
+		req := desired.Records[i]
+		key := recordKey(req.Type, req.Name, req.Data)
+		wanted[key] = true
+
+		record, ok := live[key]
+		if !ok {
+			plan.Changes = append(plan.Changes, Change{
+				Resource: ResourceDomainRecord,
+				ID:       desired.Domain,
+				Action:   ActionCreate,
+				Desired:  describeRecordReq(&req),
+				apply: func(ctx context.Context) error {
+					_, _, err := r.client.DomainRecord.Create(ctx, desired.Domain, &req)
+					return err
+				},
+			})
+			continue
+		}
+
+		priority := 0
+		if req.Priority != nil {
+			priority = *req.Priority
+		}
+
+		if (req.TTL != 0 && req.TTL != record.TTL) || (req.Priority != nil && priority != record.Priority) {
+			id := record.ID
+			plan.Changes = append(plan.Changes, Change{
+				Resource: ResourceDomainRecord,
+				ID:       id,
+				Action:   ActionUpdate,
+				Field:    "ttl/priority",
+				Current:  fmt.Sprintf("%d/%d", record.TTL, record.Priority),
+				Desired:  fmt.Sprintf("%d/%d", req.TTL, priority),
+				apply: func(ctx context.Context) error {
+					return r.client.DomainRecord.Update(ctx, desired.Domain, id, &req)
+				},
+			})
+		}
+	}
+
+	if desired.Prune {
+		for i := range records {
+			record := records[i]
+			if wanted[recordKey(record.Type, record.Name, record.Data)] {
+				continue
+			}
+
+			plan.Changes = append(plan.Changes, Change{
+				Resource: ResourceDomainRecord,
+				ID:       record.ID,
+				Action:   ActionDelete,
+				Current:  fmt.Sprintf("%s %s %s", record.Type, record.Name, record.Data),
+				apply: func(ctx context.Context) error {
+					return r.client.DomainRecord.Delete(ctx, desired.Domain, record.ID)
+				},
+			})
+		}
+	}
+
+	return plan, nil
+}
+
+// PlanFirewallGroup returns the changes needed for a firewall group to match
+// desired. Rule changes are those a dry run of ImportRuleSet reports, so rules
+// are matched the same way.
+func (r *Reconciler) PlanFirewallGroup(ctx context.Context, desired *FirewallGroup) (*Plan, error) {
+	group, _, err := r.client.FirewallGroup.Get(ctx, desired.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	doc, err := json.Marshal(govultr.FirewallRuleSet{Version: govultr.FirewallRuleSetVersion, Rules: desired.Rules})
+	if err != nil {
+		return nil, err
+	}
+
+	options := &govultr.FirewallSyncOptions{Prune: desired.Prune, DryRun: true}
+	ruleSync, err := r.client.FirewallRule.ImportRuleSet(ctx, desired.ID, doc, options)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &Plan{}
+	if desired.Description != "" && desired.Description != group.Description {
+		plan.Changes = append(plan.Changes, Change{
+			Resource: ResourceFirewallGroup,
+			ID:       desired.ID,
+			Action:   ActionUpdate,
+			Field:    "description",
+			Current:  group.Description,
+			Desired:  desired.Description,
+			apply: func(ctx context.Context) error {
+				return r.client.FirewallGroup.Update(ctx, desired.ID, &govultr.FirewallGroupReq{Description: desired.Description})
+			},
+		})
+	}
+
+	for i := range ruleSync.Created {
+		req := ruleSync.Created[i]
+		plan.Changes = append(plan.Changes, Change{
+			Resource: ResourceFirewallRule,
+			ID:       desired.ID,
+			Action:   ActionCreate,
+			Desired:  describeRule(req.IPType, req.Protocol, req.Subnet, req.SubnetSize, req.Port, req.Source),
+			apply: func(ctx context.Context) error {
+				_, _, err := r.client.FirewallRule.Create(ctx, desired.ID, &req)
+				return err
+			},
+		})
+	}
+
+	for i := range ruleSync.Deleted {
+		rule := ruleSync.Deleted[i]
+		plan.Changes = append(plan.Changes, Change{
+			Resource: ResourceFirewallRule,
+			ID:       fmt.Sprint(rule.ID),
+			Action:   ActionDelete,
+			Current:  describeRule(rule.IPType, rule.Protocol, rule.Subnet, rule.SubnetSize, rule.Port, rule.Source),
+			apply: func(ctx context.Context) error {
+				return r.client.FirewallRule.Delete(ctx, desired.ID, rule.ID)
+			},
+		})
+	}
+
+	return plan, nil
+}
+
+// PlanLoadBalancer returns the changes needed for a load balancer to match desired
+func (r *Reconciler) PlanLoadBalancer(ctx context.Context, desired *LoadBalancer) (*Plan, error) {
+	lb, _, err := r.client.LoadBalancer.Get(ctx, desired.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &Plan{}
+	path := fmt.Sprintf("/v2/load-balancers/%s", desired.ID)
+	if desired.Label != "" && desired.Label != lb.Label {
+		plan.Changes = append(plan.Changes, Change{
+			Resource: ResourceLoadBalancer,
+			ID:       desired.ID,
+			Action:   ActionUpdate,
+			Field:    "label",
+			Current:  lb.Label,
+			Desired:  desired.Label,
+			apply:    r.patch(path, govultr.RequestBody{"label": desired.Label}),
+		})
+	}
+
+	if desired.Instances != nil && !sameSet(desired.Instances, lb.Instances) {
+		plan.Changes = append(plan.Changes, Change{
+			Resource: ResourceLoadBalancer,
+			ID:       desired.ID,
+			Action:   ActionUpdate,
+			Field:    "instances",
+			Current:  joinSorted(lb.Instances),
+			Desired:  joinSorted(desired.Instances),
+			apply:    r.patch(path, govultr.RequestBody{"instances": desired.Instances}),
+		})
+	}
+
+	return plan, nil
+}
+
+// patch returns a function sending body as a partial update to path, so
+// fields that are not managed are left untouched
+func (r *Reconciler) patch(path string, body govultr.RequestBody) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		_, err := r.client.Raw(ctx, http.MethodPatch, path, body, nil)
+		return err
+	}
+}
+
+func recordKey(recordType, name, data string) string {
+	return strings.ToUpper(recordType) + " " + strings.ToLower(name) + " " + data
+}
+
+func describeRecordReq(req *govultr.DomainRecordReq) string {
+	description := fmt.Sprintf("%s %s %s", req.Type, req.Name, req.Data)
+	if req.TTL != 0 {
+		description += fmt.Sprintf(" ttl=%d", req.TTL)
+	}
+	if req.Priority != nil {
+		description += fmt.Sprintf(" priority=%d", *req.Priority)
+	}
+	return description
+}
+
+func describeRule(ipType, protocol, subnet string, subnetSize int, port, source string) string {
+	return strings.TrimSpace(fmt.Sprintf("%s %s %s/%d %s %s", ipType, protocol, subnet, subnetSize, port, source))
+}
+
+func sameSet(a, b []string) bool {
+	return joinSorted(a) == joinSorted(b)
+}
+
+func joinSorted(values []string) string {
+	sorted := append([]string(nil), values...)
+	sort.Strings(sorted)
+	return strings.Join(sorted, ",")
+}
@@ -0,0 +1,175 @@
+package reconcile
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"testing"
+
+	"github.com/vultr/govultr/v3"
+)
+
+func newTestClient(t *testing.T) (*govultr.Client, *http.ServeMux) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	client := govultr.NewClient(nil)
+	client.BaseURL, _ = url.Parse(server.URL)
+	return client, mux
+}
+
+func TestReconciler_Instance(t *testing.T) {
+	client, mux := newTestClient(t)
+
+	var patches []map[string]interface{}
+	mux.HandleFunc("/v2/instances/1", func(writer http.ResponseWriter, request *http.Request) {
+		if request.Method == http.MethodPatch {
+			body := map[string]interface{}{}
+			json.NewDecoder(request.Body).Decode(&body)
+			patches = append(patches, body)
+		}
+		fmt.Fprint(writer, `{"instance": {"id": "1", "label": "web", "plan": "vc2-1c-1gb", "tags": ["a", "b"], "firewall_group_id": ""}}`)
+	})
+
+	ctx := context.Background()
+	reconciler := New(client)
+	plan, err := reconciler.PlanInstance(ctx, &Instance{ID: "1", Label: "web-01", Tags: []string{"b", "a"}, Plan: "vc2-1c-1gb"})
+	if err != nil {
+		t.Fatalf("PlanInstance returned %+v", err)
+	}
+
+	if len(plan.Changes) != 1 {
+		t.Fatalf("PlanInstance returned %d changes, expected 1: %s", len(plan.Changes), plan)
+	}
+
+	if expected := `update instance 1 label: "web" -> "web-01"`; plan.String() != expected {
+		t.Errorf("PlanInstance returned %s, expected %s", plan, expected)
+	}
+
+	if err = reconciler.Apply(ctx, plan); err != nil {
+		t.Errorf("Apply returned %+v", err)
+	}
+
+	expected := []map[string]interface{}{{"label": "web-01"}}
+	if !reflect.DeepEqual(patches, expected) {
+		t.Errorf("Apply sent %+v, expected %+v", patches, expected)
+	}
+}
+
+func TestReconciler_DNSZone(t *testing.T) {
+	client, mux := newTestClient(t)
+
+	var calls []string
+	mux.HandleFunc("/v2/domains/example.com/records", func(writer http.ResponseWriter, request *http.Request) {
+		if request.Method == http.MethodPost {
+			calls = append(calls, "create")
+			fmt.Fprint(writer, `{"record": {"id": "new"}}`)
+			return
+		}
+		fmt.Fprint(writer, `{"records": [
+			{"id": "r1", "type": "A", "name": "www", "data": "192.0.2.1", "ttl": 300},
+			{"id": "r2", "type": "A", "name": "old", "data": "192.0.2.2", "ttl": 300}
+		], "meta": {"total": 2, "links": {"next": "", "prev": ""}}}`)
+	})
+
+	mux.HandleFunc("/v2/domains/example.com/records/", func(writer http.ResponseWriter, request *http.Request) {
+		calls = append(calls, request.Method+" "+request.URL.Path)
+		writer.WriteHeader(http.StatusNoContent)
+	})
+
+	ctx := context.Background()
+	reconciler := New(client)
+	plan, err := reconciler.PlanDNSZone(ctx, &DNSZone{
+		Domain: "example.com",
+		Records: []govultr.DomainRecordReq{
+			{Type: "A", Name: "www", Data: "192.0.2.1", TTL: 600},
+			{Type: "A", Name: "api", Data: "192.0.2.3"},
+		},
+		Prune: true,
+	})
+	if err != nil {
+		t.Fatalf("PlanDNSZone returned %+v", err)
+	}
+
+	var actions []string
+	for i := range plan.Changes {
+		actions = append(actions, plan.Changes[i].Action+" "+plan.Changes[i].ID)
+	}
+
+	expectedActions := []string{"update r1", "create example.com", "delete r2"}
+	if !reflect.DeepEqual(actions, expectedActions) {
+		t.Errorf("PlanDNSZone returned %+v, expected %+v", actions, expectedActions)
+	}
+
+	if err = reconciler.Apply(ctx, plan); err != nil {
+		t.Errorf("Apply returned %+v", err)
+	}
+
+	expectedCalls := []string{"PATCH /v2/domains/example.com/records/r1", "create", "DELETE /v2/domains/example.com/records/r2"}
+	if !reflect.DeepEqual(calls, expectedCalls) {
+		t.Errorf("Apply made calls %+v, expected %+v", calls, expectedCalls)
+	}
+}
+
+func TestReconciler_FirewallGroup(t *testing.T) {
+	client, mux := newTestClient(t)
+
+	mux.HandleFunc("/v2/firewalls/fw", func(writer http.ResponseWriter, request *http.Request) {
+		fmt.Fprint(writer, `{"firewall_group": {"id": "fw", "description": "web"}}`)
+	})
+
+	mux.HandleFunc("/v2/firewalls/fw/rules", func(writer http.ResponseWriter, request *http.Request) {
+		fmt.Fprint(writer, `{"firewall_rules": [
+			{"id": 1, "ip_type": "v4", "protocol": "tcp", "subnet": "0.0.0.0", "subnet_size": 0, "port": "443"},
+			{"id": 2, "ip_type": "v4", "protocol": "tcp", "subnet": "0.0.0.0", "subnet_size": 0, "port": "22"}
+		], "meta": {"total": 2, "links": {"next": "", "prev": ""}}}`)
+	})
+
+	plan, err := New(client).PlanFirewallGroup(context.Background(), &FirewallGroup{
+		ID: "fw",
+		Rules: []govultr.FirewallRuleReq{
+			{IPType: "v4", Protocol: "tcp", Subnet: "0.0.0.0", Port: "443"},
+			{IPType: "v4", Protocol: "tcp", Subnet: "0.0.0.0", Port: "80"},
+		},
+		Prune: true,
+	})
+	if err != nil {
+		t.Fatalf("PlanFirewallGroup returned %+v", err)
+	}
+
+	expected := "create firewall_rule fw: v4 tcp 0.0.0.0/0 80\ndelete firewall_rule 2: v4 tcp 0.0.0.0/0 22"
+	if plan.String() != expected {
+		t.Errorf("PlanFirewallGroup returned %q, expected %q", plan, expected)
+	}
+}
+
+func TestReconciler_ApplyUnplannedChange(t *testing.T) {
+	client, _ := newTestClient(t)
+
+	plan := &Plan{Changes: []Change{{Resource: ResourceInstance, ID: "1", Action: ActionUpdate, Field: "label"}}}
+	if err := New(client).Apply(context.Background(), plan); err == nil {
+		t.Error("Apply expected an error for a change not built by a Plan method")
+	}
+}
+
+func TestReconciler_LoadBalancer(t *testing.T) {
+	client, mux := newTestClient(t)
+
+	mux.HandleFunc("/v2/load-balancers/lb", func(writer http.ResponseWriter, request *http.Request) {
+		fmt.Fprint(writer, `{"load_balancer": {"id": "lb", "label": "web", "instances": ["b", "a"]}}`)
+	})
+
+	plan, err := New(client).PlanLoadBalancer(context.Background(), &LoadBalancer{ID: "lb", Label: "web", Instances: []string{"a", "b"}})
+	if err != nil {
+		t.Fatalf("PlanLoadBalancer returned %+v", err)
+	}
+
+	if !plan.Empty() {
+		t.Errorf("PlanLoadBalancer returned %s, expected no changes", plan)
+	}
+}
@@ -4,6 +4,9 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"slices"
+	"sort"
+	"strings"
 
 	"github.com/google/go-querystring/query"
 )
@@ -13,8 +16,18 @@ import (
 type RegionService interface {
 	Availability(ctx context.Context, regionID string, planType string) (*PlanAvailability, *http.Response, error)
 	List(ctx context.Context, options *ListOptions) ([]Region, *Meta, *http.Response, error)
+
+	GetRegionCapabilities(ctx context.Context, regionID string) (*RegionCapabilities, error)
+	ListRegionCapabilities(ctx context.Context) ([]RegionCapabilities, error)
 }
 
+// Region options reported by the API
+const (
+	RegionOptionKubernetes             = "kubernetes"
+	RegionOptionBlockStorageHighPerf   = "block_storage_high_perf"
+	RegionOptionBlockStorageStorageOpt = "block_storage_storage_opt"
+)
+
 var _ RegionService = &RegionServiceHandler{}
 
 // RegionServiceHandler handles interaction with the region methods for the Vultr API
@@ -31,6 +44,19 @@ type Region struct {
 	Options   []string `json:"options"`
 }
 
+// RegionCapabilities represents the products available in a region.
+// DatabaseEngines lists the engines with at least one Managed Database plan in
+// the region and BareMetalPlans the bare metal plans sold there.
+type RegionCapabilities struct {
+	Region                Region
+	Kubernetes            bool
+	BlockStorageTypes     []string
+	DatabaseEngines       []string
+	ObjectStorageClusters []ObjectStorageCluster
+	ContainerRegistry     bool
+	BareMetalPlans        []string
+}
+
 type regionBase struct {
 	Regions []Region `json:"regions"`
 	Meta    *Meta
@@ -91,3 +117,113 @@ func (r *RegionServiceHandler) Availability(ctx context.Context, regionID, planT
 
 	return plans, resp, nil
 }
+
+// GetRegionCapabilities returns the products available in a region
+func (r *RegionServiceHandler) GetRegionCapabilities(ctx context.Context, regionID string) (*RegionCapabilities, error) {
+	capabilities, err := r.ListRegionCapabilities(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range capabilities {
+		if strings.EqualFold(capabilities[i].Region.ID, regionID) {
+			return &capabilities[i], nil
+		}
+	}
+
+	return nil, fmt.Errorf("region %q not found", regionID)
+}
+
+// ListRegionCapabilities returns the products available in every region. One
+// listing is made per product regardless of the number of regions.
+func (r *RegionServiceHandler) ListRegionCapabilities(ctx context.Context) ([]RegionCapabilities, error) {
+	regions, err := collectPages(ctx, r.List)
+	if err != nil {
+		return nil, err
+	}
+
+	dbPlans, _, _, err := r.client.Database.ListPlans(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	clusters, err := collectPages(ctx, r.client.ObjectStorage.ListCluster)
+	if err != nil {
+		return nil, err
+	}
+
+	registryRegions, _, _, err := r.client.ContainerRegistry.ListRegions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	bmPlans, err := collectPages(ctx, r.client.Plan.ListBareMetal)
+	if err != nil {
+		return nil, err
+	}
+
+	capabilities := make([]RegionCapabilities, len(regions))
+	for i := range regions {
+		id := regions[i].ID
+		c := &capabilities[i]
+		c.Region = regions[i]
+		c.Kubernetes = slices.Contains(regions[i].Options, RegionOptionKubernetes)
+
+		if slices.Contains(regions[i].Options, RegionOptionBlockStorageHighPerf) {
+			c.BlockStorageTypes = append(c.BlockStorageTypes, BlockTypeHighPerf)
+		}
+		if slices.Contains(regions[i].Options, RegionOptionBlockStorageStorageOpt) {
+			c.BlockStorageTypes = append(c.BlockStorageTypes, BlockTypeStorageOpt)
+		}
+
+		engines := map[string]bool{}
+		for j := range dbPlans {
+			if !containsFold(dbPlans[j].Locations, id) {
+				continue
+			}
+			supported := dbPlans[j].SupportedEngines
+			for engine, ok := range map[string]*bool{
+				databaseEngineMySQL: supported.MySQL,
+				databaseEnginePG:    supported.PG,
+				databaseEngineRedis: supported.Redis,
+			} {
+				if ok != nil && *ok {
+					engines[engine] = true
+				}
+			}
+		}
+		for engine := range engines {
+			c.DatabaseEngines = append(c.DatabaseEngines, engine)
+		}
+		sort.Strings(c.DatabaseEngines)
+
+		for j := range clusters {
+			if strings.EqualFold(clusters[j].Region, id) {
+				c.ObjectStorageClusters = append(c.ObjectStorageClusters, clusters[j])
+			}
+		}
+
+		for j := range registryRegions {
+			if strings.EqualFold(registryRegions[j].Name, id) || strings.EqualFold(registryRegions[j].DataCenter.SiteCode, id) {
+				c.ContainerRegistry = true
+			}
+		}
+
+		for j := range bmPlans {
+			if containsFold(bmPlans[j].Locations, id) {
+				c.BareMetalPlans = append(c.BareMetalPlans, bmPlans[j].ID)
+			}
+		}
+	}
+
+	return capabilities, nil
+}
+
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
@@ -66,3 +66,72 @@ func TestRegionServiceHandler_Availability(t *testing.T) {
 		t.Errorf("Region.Availability returned %+v, expected %+v", region, expected)
 	}
 }
+
+func TestRegionServiceHandler_GetRegionCapabilities(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v2/regions", func(writer http.ResponseWriter, request *http.Request) {
+		fmt.Fprint(writer, `{"regions": [
+			{"id": "ewr", "options": ["ddos_protection", "block_storage_high_perf", "block_storage_storage_opt", "kubernetes"]},
+			{"id": "sao", "options": ["block_storage_storage_opt"]}
+		], "meta": {"total": 2, "links": {"next": "", "prev": ""}}}`)
+	})
+
+	mux.HandleFunc("/v2/databases/plans", func(writer http.ResponseWriter, request *http.Request) {
+		fmt.Fprint(writer, `{"plans": [
+			{"id": "vultr-dbaas-startup", "supported_engines": {"mysql": true, "pg": true, "redis": false}, "locations": ["ewr"]},
+			{"id": "vultr-dbaas-hobbyist", "supported_engines": {"mysql": false, "pg": false, "redis": true}, "locations": ["ewr", "sao"]}
+		], "meta": {"total": 2, "links": {"next": "", "prev": ""}}}`)
+	})
+
+	mux.HandleFunc("/v2/object-storage/clusters", func(writer http.ResponseWriter, request *http.Request) {
+		fmt.Fprint(writer, `{"clusters": [{"id": 2, "region": "ewr", "hostname": "ewr1.vultrobjects.com", "deploy": "yes"}], "meta": {"total": 1, "links": {"next": "", "prev": ""}}}`)
+	})
+
+	mux.HandleFunc("/v2/registry/region/list", func(writer http.ResponseWriter, request *http.Request) {
+		fmt.Fprint(writer, `{"regions": [{"id": 1, "name": "ewr"}], "meta": {"total": 1, "links": {"next": "", "prev": ""}}}`)
+	})
+
+	mux.HandleFunc("/v2/plans-metal", func(writer http.ResponseWriter, request *http.Request) {
+		fmt.Fprint(writer, `{"plans_metal": [{"id": "vbm-4c-32gb", "locations": ["ewr"]}], "meta": {"total": 1, "links": {"next": "", "prev": ""}}}`)
+	})
+
+	capabilities, err := client.Region.GetRegionCapabilities(ctx, "ewr")
+	if err != nil {
+		t.Fatalf("Region.GetRegionCapabilities returned %+v", err)
+	}
+
+	expected := &RegionCapabilities{
+		Region:                Region{ID: "ewr", Options: []string{"ddos_protection", "block_storage_high_perf", "block_storage_storage_opt", "kubernetes"}},
+		Kubernetes:            true,
+		BlockStorageTypes:     []string{BlockTypeHighPerf, BlockTypeStorageOpt},
+		DatabaseEngines:       []string{"mysql", "pg", "redis"},
+		ObjectStorageClusters: []ObjectStorageCluster{{ID: 2, Region: "ewr", Hostname: "ewr1.vultrobjects.com", Deploy: "yes"}},
+		ContainerRegistry:     true,
+		BareMetalPlans:        []string{"vbm-4c-32gb"},
+	}
+
+	if !reflect.DeepEqual(capabilities, expected) {
+		t.Errorf("Region.GetRegionCapabilities returned %+v, expected %+v", capabilities, expected)
+	}
+
+	capabilities, err = client.Region.GetRegionCapabilities(ctx, "sao")
+	if err != nil {
+		t.Fatalf("Region.GetRegionCapabilities returned %+v", err)
+	}
+
+	expected = &RegionCapabilities{
+		Region:            Region{ID: "sao", Options: []string{"block_storage_storage_opt"}},
+		BlockStorageTypes: []string{BlockTypeStorageOpt},
+		DatabaseEngines:   []string{"redis"},
+	}
+
+	if !reflect.DeepEqual(capabilities, expected) {
+		t.Errorf("Region.GetRegionCapabilities returned %+v, expected %+v", capabilities, expected)
+	}
+
+	if _, err = client.Region.GetRegionCapabilities(ctx, "xyz"); err == nil {
+		t.Error("Region.GetRegionCapabilities returned no error for an unknown region")
+	}
+}
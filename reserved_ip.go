@@ -4,12 +4,20 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"net/netip"
+	"sort"
 
 	"github.com/google/go-querystring/query"
 )
 
 const ripPath = "/v2/reserved-ips"
 
+// Reserved IP types accepted by the ReservedIPReq IPType field
+const (
+	ReservedIPTypeV4 = "v4"
+	ReservedIPTypeV6 = "v6"
+)
+
 // ReservedIPService is the interface to interact with the reserved IP endpoints on the Vultr API
 // Link : https://www.vultr.com/api/#tag/reserved-ip
 type ReservedIPService interface {
@@ -17,6 +25,8 @@ type ReservedIPService interface {
 	Update(ctx context.Context, id string, ripUpdate *ReservedIPUpdateReq) (*ReservedIP, *http.Response, error)
 	Get(ctx context.Context, id string) (*ReservedIP, *http.Response, error)
 	Delete(ctx context.Context, id string) error
+	DeleteGuarded(ctx context.Context, id string, options *ReservedIPDeleteOptions) error
+	ReserveMany(ctx context.Context, region, ipType string, count int) (*ReservedIPBatch, error)
 	List(ctx context.Context, options *ListOptions) ([]ReservedIP, *Meta, *http.Response, error)
 
 	Convert(ctx context.Context, ripConvert *ReservedIPConvertReq) (*ReservedIP, *http.Response, error)
@@ -54,6 +64,37 @@ type ReservedIPUpdateReq struct {
 	Label *string `json:"label"`
 }
 
+// ReservedIPDeleteOptions represents the options for a guarded delete of a Reserved IP
+type ReservedIPDeleteOptions struct {
+	// Force deletes the Reserved IP even when it is attached to an instance
+	Force bool
+}
+
+// ReservedIPResult is the outcome of a single reservation made by ReserveMany
+type ReservedIPResult struct {
+	ReservedIP *ReservedIP
+	Error      error
+}
+
+// ReservedIPBatch holds the results of ReserveMany. Contiguous reports whether
+// the successfully reserved subnets form a single unbroken range.
+type ReservedIPBatch struct {
+	Results    []ReservedIPResult
+	Contiguous bool
+}
+
+// ReservedIPAttachedError is returned by DeleteGuarded when the Reserved IP is
+// still attached to an instance
+type ReservedIPAttachedError struct {
+	ID         string
+	Subnet     string
+	InstanceID string
+}
+
+func (e *ReservedIPAttachedError) Error() string {
+	return fmt.Sprintf("reserved IP %s (%s) is attached to instance %s", e.ID, e.Subnet, e.InstanceID)
+}
+
 type reservedIPsBase struct {
 	ReservedIPs []ReservedIP `json:"reserved_ips"`
 	Meta        *Meta        `json:"meta"`
@@ -69,6 +110,75 @@ type ReservedIPConvertReq struct {
 	Label     string `json:"label,omitempty"`
 }
 
+// Prefix returns the reserved subnet as a netip.Prefix
+func (r *ReservedIP) Prefix() (netip.Prefix, error) {
+	addr, err := netip.ParseAddr(r.Subnet)
+	if err != nil {
+		return netip.Prefix{}, err
+	}
+
+	return addr.Prefix(r.SubnetSize)
+}
+
+// Contains reports whether ip is an address within the reserved subnet
+func (r *ReservedIP) Contains(ip string) bool {
+	prefix, err := r.Prefix()
+	if err != nil {
+		return false
+	}
+
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return false
+	}
+
+	return prefix.Contains(addr)
+}
+
+// Address returns the nth usable address in the reserved subnet, starting at 0.
+// The network and broadcast addresses of IPv4 subnets larger than a /31, and
+// the subnet-router anycast address of IPv6 subnets larger than a /127, are
+// skipped. The API routes the whole subnet to an instance on Attach so this
+// can be used to pick the specific address to configure on it.
+func (r *ReservedIP) Address(n uint64) (netip.Addr, error) {
+	prefix, err := r.Prefix()
+	if err != nil {
+		return netip.Addr{}, err
+	}
+
+	hostBits := prefix.Addr().BitLen() - prefix.Bits()
+	offset := n
+	if hostBits > 1 {
+		offset++
+	}
+
+	last := ^uint64(0)
+	if hostBits < 64 {
+		last = uint64(1)<<hostBits - 1
+		if prefix.Addr().Is4() && hostBits > 1 {
+			last--
+		}
+	}
+
+	if offset > last || offset < n {
+		return netip.Addr{}, fmt.Errorf("address %d is outside of reserved subnet %s", n, prefix)
+	}
+
+	b := prefix.Masked().Addr().As16()
+	for i := len(b) - 1; i >= 0 && offset > 0; i-- {
+		sum := uint64(b[i]) + offset&0xff
+		b[i] = byte(sum)
+		offset = offset>>8 + sum>>8
+	}
+
+	addr := netip.AddrFrom16(b)
+	if prefix.Addr().Is4() {
+		addr = addr.Unmap()
+	}
+
+	return addr, nil
+}
+
 // Create adds the specified reserved IP to your Vultr account
 func (r *ReservedIPServiceHandler) Create(ctx context.Context, ripCreate *ReservedIPReq) (*ReservedIP, *http.Response, error) {
 	req, err := r.client.NewRequest(ctx, http.MethodPost, ripPath, ripCreate)
@@ -131,6 +241,85 @@ func (r *ReservedIPServiceHandler) Delete(ctx context.Context, id string) error
 	return err
 }
 
+// DeleteGuarded removes the specified reserved IP from your Vultr account only if
+// it is not attached to an instance, returning a *ReservedIPAttachedError that
+// reports the attachment otherwise. Set Force to delete it regardless.
+func (r *ReservedIPServiceHandler) DeleteGuarded(ctx context.Context, id string, options *ReservedIPDeleteOptions) error {
+	if options == nil || !options.Force {
+		rip, _, err := r.Get(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if rip.InstanceID != "" {
+			return &ReservedIPAttachedError{ID: rip.ID, Subnet: rip.Subnet, InstanceID: rip.InstanceID}
+		}
+	}
+
+	return r.Delete(ctx, id)
+}
+
+// ReserveMany reserves count IPs of ipType in a region. Failed reservations
+// are reported in the results rather than stopping the batch. The API cannot
+// request adjacent addresses so contiguity is reported but not guaranteed.
+func (r *ReservedIPServiceHandler) ReserveMany(ctx context.Context, region, ipType string, count int) (*ReservedIPBatch, error) {
+	if count < 1 {
+		return nil, fmt.Errorf("reserve many requires a count of at least 1, got %d", count)
+	}
+
+	batch := &ReservedIPBatch{Results: make([]ReservedIPResult, 0, count)}
+	var prefixes []netip.Prefix
+	for i := 0; i < count; i++ {
+		if err := ctx.Err(); err != nil {
+			return batch, err
+		}
+
+		rip, _, err := r.Create(ctx, &ReservedIPReq{Region: region, IPType: ipType})
+		batch.Results = append(batch.Results, ReservedIPResult{ReservedIP: rip, Error: err})
+		if err != nil {
+			continue
+		}
+
+		prefix, err := rip.Prefix()
+		if err != nil {
+			return batch, err
+		}
+		prefixes = append(prefixes, prefix.Masked())
+	}
+
+	batch.Contiguous = contiguousPrefixes(prefixes)
+	return batch, nil
+}
+
+// contiguousPrefixes reports whether the prefixes cover a single unbroken range
+func contiguousPrefixes(prefixes []netip.Prefix) bool {
+	if len(prefixes) == 0 {
+		return false
+	}
+
+	sort.Slice(prefixes, func(i, j int) bool {
+		return prefixes[i].Addr().Less(prefixes[j].Addr())
+	})
+
+	for i := 1; i < len(prefixes); i++ {
+		b := prefixes[i-1].Addr().As16()
+		for bit := prefixes[i-1].Addr().BitLen() - prefixes[i-1].Bits(); bit > 0; bit-- {
+			b[15-(bit-1)/8] |= 1 << ((bit - 1) % 8)
+		}
+
+		last := netip.AddrFrom16(b)
+		if prefixes[i-1].Addr().Is4() {
+			last = last.Unmap()
+		}
+
+		if last.Next() != prefixes[i].Addr() {
+			return false
+		}
+	}
+
+	return true
+}
+
 // List lists all the reserved IPs associated with your Vultr account
 func (r *ReservedIPServiceHandler) List(ctx context.Context, options *ListOptions) ([]ReservedIP, *Meta, *http.Response, error) { //nolint:dupl,lll
 	req, err := r.client.NewRequest(ctx, http.MethodGet, ripPath, nil)
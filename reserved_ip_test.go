@@ -1,8 +1,10 @@
 package govultr
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
+	"net/netip"
 	"reflect"
 	"testing"
 )
@@ -276,3 +278,144 @@ func TestReservedIPServiceHandler_List(t *testing.T) {
 		t.Errorf("ReservedIP.List returned %+v, expected %+v", ips, expected)
 	}
 }
+
+func TestReservedIP_Address(t *testing.T) {
+	tests := []struct {
+		rip      ReservedIP
+		n        uint64
+		expected string
+		err      bool
+	}{
+		{ReservedIP{Subnet: "192.0.2.10", SubnetSize: 32}, 0, "192.0.2.10", false},
+		{ReservedIP{Subnet: "192.0.2.10", SubnetSize: 32}, 1, "", true},
+		{ReservedIP{Subnet: "192.0.2.0", SubnetSize: 30}, 1, "192.0.2.2", false},
+		{ReservedIP{Subnet: "192.0.2.0", SubnetSize: 30}, 2, "", true},
+		{ReservedIP{Subnet: "2001:db8:1000::", SubnetSize: 64}, 0, "2001:db8:1000::1", false},
+		{ReservedIP{Subnet: "2001:db8:1000::5", SubnetSize: 128}, 0, "2001:db8:1000::5", false},
+		{ReservedIP{Subnet: "2001:db8:1000::5", SubnetSize: 128}, 1, "", true},
+		{ReservedIP{Subnet: "2001:db8:1000::", SubnetSize: 64}, 255, "2001:db8:1000::100", false},
+		{ReservedIP{Subnet: "2001:db8:1000::", SubnetSize: 64}, ^uint64(0), "", true},
+		{ReservedIP{Subnet: "not-an-ip", SubnetSize: 64}, 0, "", true},
+	}
+
+	for _, tt := range tests {
+		addr, err := tt.rip.Address(tt.n)
+		if (err != nil) != tt.err {
+			t.Errorf("ReservedIP.Address(%d) for %s/%d returned error %v", tt.n, tt.rip.Subnet, tt.rip.SubnetSize, err)
+			continue
+		}
+
+		if err == nil && addr.String() != tt.expected {
+			t.Errorf("ReservedIP.Address(%d) returned %s, expected %s", tt.n, addr, tt.expected)
+		}
+	}
+}
+
+func TestReservedIP_Contains(t *testing.T) {
+	rip := ReservedIP{Subnet: "2001:db8:1000::", SubnetSize: 64}
+
+	if !rip.Contains("2001:db8:1000::abcd") {
+		t.Error("ReservedIP.Contains expected address to be within subnet")
+	}
+
+	if rip.Contains("2001:db8:1001::1") {
+		t.Error("ReservedIP.Contains expected address to be outside of subnet")
+	}
+}
+
+func TestReservedIPServiceHandler_DeleteGuarded(t *testing.T) {
+	setup()
+	defer teardown()
+
+	deleted := false
+	mux.HandleFunc("/v2/reserved-ips/12345", func(writer http.ResponseWriter, request *http.Request) {
+		if request.Method == http.MethodDelete {
+			deleted = true
+			return
+		}
+		fmt.Fprint(writer, `{"reserved_ip": {"id": "12345", "subnet": "192.0.2.10", "subnet_size": 32, "instance_id": "abc"}}`)
+	})
+
+	err := client.ReservedIP.DeleteGuarded(ctx, "12345", nil)
+
+	var attached *ReservedIPAttachedError
+	if !errors.As(err, &attached) || attached.InstanceID != "abc" {
+		t.Errorf("ReservedIP.DeleteGuarded returned %+v, expected a ReservedIPAttachedError for instance abc", err)
+	}
+
+	if deleted {
+		t.Error("ReservedIP.DeleteGuarded deleted an attached reserved IP")
+	}
+
+	if err = client.ReservedIP.DeleteGuarded(ctx, "12345", &ReservedIPDeleteOptions{Force: true}); err != nil {
+		t.Errorf("ReservedIP.DeleteGuarded returned %+v", err)
+	}
+
+	if !deleted {
+		t.Error("ReservedIP.DeleteGuarded did not delete with Force set")
+	}
+}
+
+func TestReservedIPServiceHandler_ReserveMany(t *testing.T) {
+	setup()
+	defer teardown()
+
+	subnets := []string{"192.0.2.11", "192.0.2.10"}
+	mux.HandleFunc("/v2/reserved-ips", func(writer http.ResponseWriter, request *http.Request) {
+		if len(subnets) == 0 {
+			http.Error(writer, `{"error": "no addresses available"}`, http.StatusBadRequest)
+			return
+		}
+
+		fmt.Fprintf(writer, `{"reserved_ip": {"id": %q, "region": "ewr", "ip_type": "v4", "subnet": %q, "subnet_size": 32}}`, subnets[0], subnets[0])
+		subnets = subnets[1:]
+	})
+
+	batch, err := client.ReservedIP.ReserveMany(ctx, "ewr", ReservedIPTypeV4, 3)
+	if err != nil {
+		t.Errorf("ReservedIP.ReserveMany returned %+v", err)
+	}
+
+	if len(batch.Results) != 3 {
+		t.Fatalf("ReservedIP.ReserveMany returned %d results, expected 3", len(batch.Results))
+	}
+
+	if batch.Results[0].ReservedIP.Subnet != "192.0.2.11" || batch.Results[1].ReservedIP.Subnet != "192.0.2.10" {
+		t.Errorf("ReservedIP.ReserveMany returned %+v, expected 192.0.2.11 and 192.0.2.10", batch.Results)
+	}
+
+	if batch.Results[2].Error == nil {
+		t.Error("ReservedIP.ReserveMany returned no error for the failed reservation")
+	}
+
+	if !batch.Contiguous {
+		t.Error("ReservedIP.ReserveMany returned non-contiguous, expected contiguous")
+	}
+
+	if _, err := client.ReservedIP.ReserveMany(ctx, "ewr", "v4", -1); err == nil {
+		t.Error("ReservedIP.ReserveMany expected an error for a negative count")
+	}
+}
+
+func TestContiguousPrefixes(t *testing.T) {
+	tests := []struct {
+		prefixes []string
+		expected bool
+	}{
+		{[]string{"2001:db8:0:1::/64", "2001:db8::/64"}, true},
+		{[]string{"2001:db8::/64", "2001:db8:0:2::/64"}, false},
+		{[]string{"192.0.2.255/32", "192.0.3.0/32"}, true},
+		{[]string{"192.0.2.1/32", "192.0.2.3/32"}, false},
+	}
+
+	for _, tt := range tests {
+		var prefixes []netip.Prefix
+		for _, p := range tt.prefixes {
+			prefixes = append(prefixes, netip.MustParsePrefix(p))
+		}
+
+		if actual := contiguousPrefixes(prefixes); actual != tt.expected {
+			t.Errorf("contiguousPrefixes(%v) returned %v, expected %v", tt.prefixes, actual, tt.expected)
+		}
+	}
+}
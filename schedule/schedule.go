@@ -0,0 +1,290 @@
+// Package schedule runs Vultr instance power actions and snapshots at
+// specified times on top of the govultr services.
+package schedule
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/vultr/govultr/v3"
+)
+
+const defaultInterval = time.Minute
+
+// Actions that can be scheduled against an instance
+const (
+	ActionStart    = "start"
+	ActionHalt     = "halt"
+	ActionReboot   = "reboot"
+	ActionSnapshot = "snapshot"
+
+	// Batch actions run against every instance in InstanceIDs with one request
+	ActionMassStart = "mass_start"
+	ActionMassHalt  = "mass_halt"
+)
+
+// Job statuses
+const (
+	StatusPending = "pending"
+	StatusDone    = "done"
+	StatusFailed  = "failed"
+)
+
+// Job represents a single action to run against an instance at a given time
+type Job struct {
+	ID         string
+	InstanceID string
+	Action     string
+	At         time.Time
+
+	// InstanceIDs are the instances ActionMassStart and ActionMassHalt apply to
+	InstanceIDs []string
+
+	// Description is used as the snapshot description for ActionSnapshot
+	Description string
+
+	Status     string
+	Error      string
+	SnapshotID string
+}
+
+// Store is the interface used to persist jobs so they survive restarts
+type Store interface {
+	Save(ctx context.Context, job Job) error
+	Delete(ctx context.Context, jobID string) error
+	Load(ctx context.Context) ([]Job, error)
+}
+
+// Scheduler holds queued jobs and runs them once they are due
+type Scheduler struct {
+	client *govultr.Client
+	store  Store
+
+	// Interval is how often Run checks for due jobs. Defaults to one minute.
+	Interval time.Duration
+
+	mu   sync.Mutex
+	jobs map[string]*Job
+}
+
+// New returns a Scheduler that runs jobs with client. store may be nil if the
+// jobs do not need to be persisted.
+func New(client *govultr.Client, store Store) *Scheduler {
+	return &Scheduler{
+		client:   client,
+		store:    store,
+		Interval: defaultInterval,
+		jobs:     make(map[string]*Job),
+	}
+}
+
+// Add queues a job, replacing any queued job with the same ID
+func (s *Scheduler) Add(ctx context.Context, job Job) error {
+	if err := validate(&job); err != nil {
+		return err
+	}
+
+	job.Status = StatusPending
+	if err := s.save(ctx, &job); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	s.jobs[job.ID] = &job
+	s.mu.Unlock()
+
+	return nil
+}
+
+// validate returns an error if job cannot be queued
+func validate(job *Job) error {
+	if job.ID == "" {
+		return errors.New("job ID is required")
+	}
+
+	switch job.Action {
+	case ActionStart, ActionHalt, ActionReboot, ActionSnapshot:
+		if job.InstanceID == "" {
+			return errors.New("instance ID is required")
+		}
+	case ActionMassStart, ActionMassHalt:
+		if len(job.InstanceIDs) == 0 {
+			return errors.New("instance IDs are required")
+		}
+	default:
+		return fmt.Errorf("unsupported action %q", job.Action)
+	}
+
+	return nil
+}
+
+// AddPowerWindow queues jobs halting the instances at haltAt and starting them
+// again at startAt using the batch power endpoints. The jobs are given the IDs
+// id + "-halt" and id + "-start". Vultr continues to bill halted instances, so
+// windows are useful for quieting fleets rather than reducing cost. Both jobs
+// are validated before either is queued, and the halt job is cancelled again
+// if the start job cannot be queued so instances are never left halted.
+func (s *Scheduler) AddPowerWindow(ctx context.Context, id string, instanceIDs []string, haltAt, startAt time.Time) error {
+	if !startAt.After(haltAt) {
+		return errors.New("start time must be after halt time")
+	}
+
+	halt := Job{ID: id + "-halt", Action: ActionMassHalt, InstanceIDs: instanceIDs, At: haltAt}
+	start := Job{ID: id + "-start", Action: ActionMassStart, InstanceIDs: instanceIDs, At: startAt}
+	for _, job := range []*Job{&halt, &start} {
+		if err := validate(job); err != nil {
+			return err
+		}
+	}
+
+	if err := s.Add(ctx, halt); err != nil {
+		return err
+	}
+
+	if err := s.Add(ctx, start); err != nil {
+		if errCancel := s.Cancel(ctx, halt.ID); errCancel != nil {
+			return errors.Join(err, fmt.Errorf("cancelling halt job %s: %w", halt.ID, errCancel))
+		}
+		return err
+	}
+
+	return nil
+}
+
+// Cancel removes a queued job
+func (s *Scheduler) Cancel(ctx context.Context, jobID string) error {
+	if s.store != nil {
+		if err := s.store.Delete(ctx, jobID); err != nil {
+			return err
+		}
+	}
+
+	s.mu.Lock()
+	delete(s.jobs, jobID)
+	s.mu.Unlock()
+
+	return nil
+}
+
+// Jobs returns all known jobs ordered by the time they are due
+func (s *Scheduler) Jobs() []Job {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	jobs := make([]Job, 0, len(s.jobs))
+	for _, job := range s.jobs {
+		jobs = append(jobs, *job)
+	}
+
+	sort.Slice(jobs, func(i, j int) bool {
+		return jobs[i].At.Before(jobs[j].At)
+	})
+
+	return jobs
+}
+
+// Run loads any persisted jobs then runs jobs as they become due until ctx is done
+func (s *Scheduler) Run(ctx context.Context) error {
+	if s.store != nil {
+		jobs, err := s.store.Load(ctx)
+		if err != nil {
+			return err
+		}
+
+		s.mu.Lock()
+		for i := range jobs {
+			if _, ok := s.jobs[jobs[i].ID]; !ok {
+				s.jobs[jobs[i].ID] = &jobs[i]
+			}
+		}
+		s.mu.Unlock()
+	}
+
+	interval := s.Interval
+	if interval <= 0 {
+		interval = defaultInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.RunDue(ctx, time.Now()); err != nil {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunDue runs every pending job due at or before now. A failed action marks
+// its job as failed; only an error persisting a job is returned.
+func (s *Scheduler) RunDue(ctx context.Context, now time.Time) error {
+	for _, job := range s.Jobs() {
+		if job.Status != StatusPending || job.At.After(now) {
+			continue
+		}
+
+		job := job
+		if err := s.run(ctx, &job); err != nil {
+			job.Status = StatusFailed
+			job.Error = err.Error()
+		} else {
+			job.Status = StatusDone
+		}
+
+		s.mu.Lock()
+		if _, ok := s.jobs[job.ID]; ok {
+			s.jobs[job.ID] = &job
+		}
+		s.mu.Unlock()
+
+		if err := s.save(ctx, &job); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *Scheduler) run(ctx context.Context, job *Job) error {
+	switch job.Action {
+	case ActionStart:
+		return s.client.Instance.Start(ctx, job.InstanceID)
+	case ActionHalt:
+		return s.client.Instance.Halt(ctx, job.InstanceID)
+	case ActionReboot:
+		return s.client.Instance.Reboot(ctx, job.InstanceID)
+	case ActionMassStart:
+		return s.client.Instance.MassStart(ctx, job.InstanceIDs)
+	case ActionMassHalt:
+		return s.client.Instance.MassHalt(ctx, job.InstanceIDs)
+	case ActionSnapshot:
+		snapshot, _, err := s.client.Snapshot.Create(ctx, &govultr.SnapshotReq{
+			InstanceID:  job.InstanceID,
+			Description: job.Description,
+		})
+		if err != nil {
+			return err
+		}
+		job.SnapshotID = snapshot.ID
+		return nil
+	}
+
+	return fmt.Errorf("unsupported action %q", job.Action)
+}
+
+func (s *Scheduler) save(ctx context.Context, job *Job) error {
+	if s.store == nil {
+		return nil
+	}
+	return s.store.Save(ctx, *job)
+}
@@ -0,0 +1,160 @@
+package schedule
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/vultr/govultr/v3"
+)
+
+type memoryStore struct {
+	jobs map[string]Job
+
+	// failSave makes Save fail for the job with this ID
+	failSave string
+}
+
+func (m *memoryStore) Save(_ context.Context, job Job) error {
+	if job.ID == m.failSave {
+		return fmt.Errorf("saving job %s failed", job.ID)
+	}
+	m.jobs[job.ID] = job
+	return nil
+}
+
+func (m *memoryStore) Delete(_ context.Context, jobID string) error {
+	delete(m.jobs, jobID)
+	return nil
+}
+
+func (m *memoryStore) Load(_ context.Context) ([]Job, error) {
+	jobs := make([]Job, 0, len(m.jobs))
+	for _, job := range m.jobs {
+		jobs = append(jobs, job)
+	}
+	return jobs, nil
+}
+
+func TestScheduler_RunDue(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client := govultr.NewClient(nil)
+	client.BaseURL, _ = url.Parse(server.URL)
+
+	var calls []string
+	mux.HandleFunc("/v2/instances/1/reboot", func(writer http.ResponseWriter, request *http.Request) {
+		calls = append(calls, "reboot")
+		writer.WriteHeader(http.StatusNoContent)
+	})
+	mux.HandleFunc("/v2/snapshots", func(writer http.ResponseWriter, request *http.Request) {
+		calls = append(calls, "snapshot")
+		fmt.Fprint(writer, `{"snapshot": {"id": "snap-1"}}`)
+	})
+
+	ctx := context.Background()
+	store := &memoryStore{jobs: make(map[string]Job)}
+	scheduler := New(client, store)
+
+	now := time.Date(2024, 1, 1, 2, 0, 0, 0, time.UTC)
+	jobs := []Job{
+		{ID: "a", InstanceID: "1", Action: ActionSnapshot, At: now.Add(-2 * time.Minute), Description: "pre-maintenance"},
+		{ID: "b", InstanceID: "1", Action: ActionReboot, At: now.Add(-time.Minute)},
+		{ID: "c", InstanceID: "1", Action: ActionHalt, At: now.Add(time.Hour)},
+	}
+	for _, job := range jobs {
+		if err := scheduler.Add(ctx, job); err != nil {
+			t.Fatalf("Scheduler.Add returned %+v", err)
+		}
+	}
+
+	if err := scheduler.RunDue(ctx, now); err != nil {
+		t.Errorf("Scheduler.RunDue returned %+v", err)
+	}
+
+	if expected := []string{"snapshot", "reboot"}; !reflect.DeepEqual(calls, expected) {
+		t.Errorf("Scheduler.RunDue made calls %+v, expected %+v", calls, expected)
+	}
+
+	statuses := map[string]string{}
+	for _, job := range scheduler.Jobs() {
+		statuses[job.ID] = job.Status
+	}
+
+	expected := map[string]string{"a": StatusDone, "b": StatusDone, "c": StatusPending}
+	if !reflect.DeepEqual(statuses, expected) {
+		t.Errorf("Scheduler.Jobs statuses %+v, expected %+v", statuses, expected)
+	}
+
+	if store.jobs["a"].SnapshotID != "snap-1" {
+		t.Errorf("Stored snapshot job has snapshot ID %q, expected %q", store.jobs["a"].SnapshotID, "snap-1")
+	}
+
+	if err := scheduler.Add(ctx, Job{ID: "d", InstanceID: "1", Action: "explode"}); err == nil {
+		t.Error("Scheduler.Add expected an error for an unsupported action")
+	}
+}
+
+func TestScheduler_AddPowerWindow(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client := govultr.NewClient(nil)
+	client.BaseURL, _ = url.Parse(server.URL)
+
+	var calls []string
+	for _, action := range []string{"halt", "start"} {
+		action := action
+		mux.HandleFunc("/v2/instances/"+action, func(writer http.ResponseWriter, request *http.Request) {
+			calls = append(calls, action)
+			writer.WriteHeader(http.StatusNoContent)
+		})
+	}
+
+	ctx := context.Background()
+	scheduler := New(client, nil)
+
+	haltAt := time.Date(2024, 1, 1, 20, 0, 0, 0, time.UTC)
+	startAt := haltAt.Add(12 * time.Hour)
+	if err := scheduler.AddPowerWindow(ctx, "nightly", []string{"1", "2"}, haltAt, startAt); err != nil {
+		t.Fatalf("Scheduler.AddPowerWindow returned %+v", err)
+	}
+
+	if err := scheduler.RunDue(ctx, haltAt); err != nil {
+		t.Errorf("Scheduler.RunDue returned %+v", err)
+	}
+
+	if err := scheduler.RunDue(ctx, startAt); err != nil {
+		t.Errorf("Scheduler.RunDue returned %+v", err)
+	}
+
+	if expected := []string{"halt", "start"}; !reflect.DeepEqual(calls, expected) {
+		t.Errorf("Scheduler.RunDue made calls %+v, expected %+v", calls, expected)
+	}
+
+	if err := scheduler.AddPowerWindow(ctx, "bad", []string{"1"}, startAt, haltAt); err == nil {
+		t.Error("Scheduler.AddPowerWindow expected an error for a start before the halt")
+	}
+
+	store := &memoryStore{jobs: map[string]Job{}, failSave: "weekend-start"}
+	scheduler = New(client, store)
+	if err := scheduler.AddPowerWindow(ctx, "weekend", []string{"1"}, haltAt, startAt); err == nil {
+		t.Error("Scheduler.AddPowerWindow expected an error when the start job cannot be saved")
+	}
+
+	if len(store.jobs) != 0 || len(scheduler.Jobs()) != 0 {
+		t.Errorf("Scheduler.AddPowerWindow left jobs %+v queued, expected the halt job to be cancelled", scheduler.Jobs())
+	}
+
+	if err := scheduler.AddPowerWindow(ctx, "empty", nil, haltAt, startAt); err == nil || len(scheduler.Jobs()) != 0 {
+		t.Errorf("Scheduler.AddPowerWindow returned %+v and queued %+v, expected an error and no jobs", err, scheduler.Jobs())
+	}
+}
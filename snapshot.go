@@ -2,20 +2,40 @@ package govultr
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	gopath "path"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/google/go-querystring/query"
 )
 
+// Snapshot consistency levels recorded by CreateConsistent
+const (
+	SnapshotConsistencyCrash       = "crash-consistent"
+	SnapshotConsistencyApplication = "application-consistent"
+)
+
+const snapshotStatusComplete = "complete"
+
 // SnapshotService is the interface to interact with Snapshot endpoints on the Vultr API
 // Link : https://www.vultr.com/api/#tag/snapshot
 type SnapshotService interface {
 	Create(ctx context.Context, snapshotReq *SnapshotReq) (*Snapshot, *http.Response, error)
 	CreateFromURL(ctx context.Context, snapshotURLReq *SnapshotURLReq) (*Snapshot, *http.Response, error)
+	CreateConsistent(ctx context.Context, snapshotReq *SnapshotConsistentReq) (*Snapshot, *http.Response, error)
+	CreateWithMetadata(ctx context.Context, instanceID string, metadata *SnapshotMetadata) (*Snapshot, *http.Response, error)
 	Get(ctx context.Context, snapshotID string) (*Snapshot, *http.Response, error)
+	WaitForStatus(ctx context.Context, snapshotID, status string, options *WaitOptions) (*Snapshot, *http.Response, error)
 	Delete(ctx context.Context, snapshotID string) error
 	List(ctx context.Context, options *ListOptions) ([]Snapshot, *Meta, *http.Response, error)
+	ListFiltered(ctx context.Context, filter *SnapshotFilter) ([]Snapshot, error)
+
+	RestoreInPlace(ctx context.Context, instanceID, snapshotID string, options *SnapshotRestoreOptions) (*SnapshotRestore, error)
 }
 
 // SnapshotServiceHandler handles interaction with the snapshot methods for the Vultr API
@@ -41,12 +61,64 @@ type SnapshotReq struct {
 	Description string `json:"description,omitempty"`
 }
 
+// SnapshotRestoreOptions are used by RestoreInPlace. Wait applies to both the
+// safety snapshot and the restore.
+type SnapshotRestoreOptions struct {
+	SkipSafetySnapshot bool
+	Wait               *WaitOptions
+}
+
+// SnapshotRestore is the outcome of RestoreInPlace. SafetySnapshot is the
+// snapshot taken of the instance before it was overwritten and is set even
+// when the restore itself fails.
+type SnapshotRestore struct {
+	Instance       *Instance
+	SafetySnapshot *Snapshot
+}
+
 // SnapshotURLReq struct is used to create snapshots from a URL.
 type SnapshotURLReq struct {
 	URL         string `json:"url"`
 	Description string `json:"description,omitempty"`
 }
 
+// SnapshotFilter is used to filter and sort snapshots with ListFiltered. Zero
+// values are ignored. Sizes are in bytes.
+type SnapshotFilter struct {
+	MinAge  time.Duration
+	MaxAge  time.Duration
+	MinSize int
+	MaxSize int
+
+	// DescriptionGlob is matched with path.Match, so * and ? do not match a /
+	// in the description
+	DescriptionGlob string
+	SortBySizeDesc  bool
+}
+
+// SnapshotHook is run around snapshot creation, e.g. to flush and freeze
+// application writes over SSH or an application API
+type SnapshotHook func(ctx context.Context) error
+
+// SnapshotConsistentReq struct is used to create snapshots wrapped in hooks.
+type SnapshotConsistentReq struct {
+	InstanceID  string
+	Description string
+	PreHook     SnapshotHook
+	PostHook    SnapshotHook
+}
+
+// SnapshotMetadata is structured metadata stored as JSON in a snapshot
+// description, standing in for tags until snapshots support them
+type SnapshotMetadata struct {
+	SourceInstance string            `json:"source_instance,omitempty"`
+	GitSHA         string            `json:"git_sha,omitempty"`
+	Purpose        string            `json:"purpose,omitempty"`
+	ContentHash    string            `json:"content_hash,omitempty"`
+	Consistency    string            `json:"consistency,omitempty"`
+	Extra          map[string]string `json:"extra,omitempty"`
+}
+
 type snapshotsBase struct {
 	Snapshots []Snapshot `json:"snapshots"`
 	Meta      *Meta      `json:"meta"`
@@ -92,6 +164,177 @@ func (s *SnapshotServiceHandler) CreateFromURL(ctx context.Context, snapshotURLR
 	return snapshot.Snapshot, resp, nil
 }
 
+// CreateConsistent makes a snapshot of a provided server, running PreHook before
+// and PostHook after the snapshot is requested. PostHook always runs once
+// PreHook has been attempted so a partially quiesced application is released.
+// The consistency level achieved is appended to the snapshot description:
+// application-consistent when PreHook succeeded, crash-consistent otherwise. A
+// failed hook does not stop the snapshot; its error is returned joined with any
+// other error alongside the snapshot.
+func (s *SnapshotServiceHandler) CreateConsistent(ctx context.Context, snapshotReq *SnapshotConsistentReq) (*Snapshot, *http.Response, error) { //nolint:lll
+	consistency := SnapshotConsistencyCrash
+	var errPre error
+	if snapshotReq.PreHook != nil {
+		if errPre = snapshotReq.PreHook(ctx); errPre == nil {
+			consistency = SnapshotConsistencyApplication
+		} else {
+			errPre = fmt.Errorf("snapshot pre hook: %w", errPre)
+		}
+	}
+
+	description := strings.TrimSpace(fmt.Sprintf("%s [%s]", snapshotReq.Description, consistency))
+	snapshot, resp, err := s.Create(ctx, &SnapshotReq{
+		InstanceID:  snapshotReq.InstanceID,
+		Description: description,
+	})
+	err = errors.Join(errPre, err)
+
+	if snapshotReq.PostHook != nil {
+		if errPost := snapshotReq.PostHook(ctx); errPost != nil {
+			err = errors.Join(err, fmt.Errorf("snapshot post hook: %w", errPost))
+		}
+	}
+
+	return snapshot, resp, err
+}
+
+// CreateWithMetadata makes a snapshot of a provided server with the metadata
+// stored in its description. When ContentHash is set and a snapshot with the
+// same hash already exists that snapshot is returned instead of a new one.
+func (s *SnapshotServiceHandler) CreateWithMetadata(ctx context.Context, instanceID string, metadata *SnapshotMetadata) (*Snapshot, *http.Response, error) { //nolint:lll
+	if metadata.ContentHash != "" {
+		snapshots, err := collectPages(ctx, s.List)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		for i := range snapshots {
+			if existing, ok := snapshots[i].Metadata(); ok && existing.ContentHash == metadata.ContentHash {
+				return &snapshots[i], nil, nil
+			}
+		}
+	}
+
+	description, err := json.Marshal(metadata)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return s.Create(ctx, &SnapshotReq{InstanceID: instanceID, Description: string(description)})
+}
+
+// Metadata parses the metadata stored in the snapshot description by
+// CreateWithMetadata. The second value is false if there is none.
+func (s *Snapshot) Metadata() (*SnapshotMetadata, bool) {
+	if !strings.HasPrefix(s.Description, "{") {
+		return nil, false
+	}
+
+	metadata := new(SnapshotMetadata)
+	if err := json.Unmarshal([]byte(s.Description), metadata); err != nil {
+		return nil, false
+	}
+
+	return metadata, true
+}
+
+// FilterSnapshots returns the snapshots with metadata accepted by match
+func FilterSnapshots(snapshots []Snapshot, match func(metadata *SnapshotMetadata) bool) []Snapshot {
+	var filtered []Snapshot
+	for i := range snapshots {
+		if metadata, ok := snapshots[i].Metadata(); ok && match(metadata) {
+			filtered = append(filtered, snapshots[i])
+		}
+	}
+	return filtered
+}
+
+// Consistency returns the consistency level recorded in the snapshot description
+// by CreateConsistent or in its metadata, or an empty string if there is none
+func (s *Snapshot) Consistency() string {
+	if metadata, ok := s.Metadata(); ok {
+		return metadata.Consistency
+	}
+
+	for _, level := range []string{SnapshotConsistencyApplication, SnapshotConsistencyCrash} {
+		if strings.HasSuffix(s.Description, "["+level+"]") {
+			return level
+		}
+	}
+	return ""
+}
+
+// Created returns the time the snapshot was created
+func (s *Snapshot) Created() (time.Time, error) {
+	if created, err := time.Parse(time.RFC3339, s.DateCreated); err == nil {
+		return created, nil
+	}
+	return time.Parse(time.DateTime, s.DateCreated)
+}
+
+// ListFiltered returns every snapshot accepted by the filter, or every snapshot
+// if filter is nil. A description without glob characters is filtered by the
+// API, anything else client side.
+func (s *SnapshotServiceHandler) ListFiltered(ctx context.Context, filter *SnapshotFilter) ([]Snapshot, error) {
+	if filter == nil {
+		filter = &SnapshotFilter{}
+	}
+
+	list := s.List
+	if filter.DescriptionGlob != "" && !strings.ContainsAny(filter.DescriptionGlob, `*?[\`) {
+		list = func(ctx context.Context, options *ListOptions) ([]Snapshot, *Meta, *http.Response, error) {
+			options.Description = filter.DescriptionGlob
+			return s.List(ctx, options)
+		}
+	}
+
+	snapshots, err := collectPages(ctx, list)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	var filtered []Snapshot
+	for i := range snapshots {
+		snapshot := snapshots[i]
+		if filter.MinSize > 0 && snapshot.Size < filter.MinSize || filter.MaxSize > 0 && snapshot.Size > filter.MaxSize {
+			continue
+		}
+
+		if filter.DescriptionGlob != "" {
+			matched, err := gopath.Match(filter.DescriptionGlob, snapshot.Description)
+			if err != nil {
+				return nil, err
+			}
+			if !matched {
+				continue
+			}
+		}
+
+		if filter.MinAge > 0 || filter.MaxAge > 0 {
+			created, err := snapshot.Created()
+			if err != nil {
+				return nil, err
+			}
+
+			age := now.Sub(created)
+			if filter.MinAge > 0 && age < filter.MinAge || filter.MaxAge > 0 && age > filter.MaxAge {
+				continue
+			}
+		}
+
+		filtered = append(filtered, snapshot)
+	}
+
+	if filter.SortBySizeDesc {
+		sort.SliceStable(filtered, func(i, j int) bool {
+			return filtered[i].Size > filtered[j].Size
+		})
+	}
+
+	return filtered, nil
+}
+
 // Get a specific snapshot
 func (s *SnapshotServiceHandler) Get(ctx context.Context, snapshotID string) (*Snapshot, *http.Response, error) {
 	uri := fmt.Sprintf("/v2/snapshots/%s", snapshotID)
@@ -110,6 +353,16 @@ func (s *SnapshotServiceHandler) Get(ctx context.Context, snapshotID string) (*S
 	return snapshot.Snapshot, resp, nil
 }
 
+// WaitForStatus polls a snapshot until its status matches status, such as
+// "complete"
+func (s *SnapshotServiceHandler) WaitForStatus(ctx context.Context, snapshotID, status string, options *WaitOptions) (*Snapshot, *http.Response, error) { //nolint:lll
+	return waitForStatus(ctx, options, status, func(ctx context.Context) (*Snapshot, *http.Response, error) {
+		return s.Get(ctx, snapshotID)
+	}, func(r *Snapshot) []string {
+		return []string{r.Status}
+	})
+}
+
 // Delete a snapshot.
 func (s *SnapshotServiceHandler) Delete(ctx context.Context, snapshotID string) error {
 	uri := fmt.Sprintf("/v2/snapshots/%s", snapshotID)
@@ -146,3 +399,72 @@ func (s *SnapshotServiceHandler) List(ctx context.Context, options *ListOptions)
 
 	return snapshots.Snapshots, snapshots.Meta, resp, nil
 }
+
+// RestoreInPlace overwrites an instance with a snapshot and waits for it to be
+// running again. Unless SkipSafetySnapshot is set, a snapshot of the instance
+// is taken and waited on first so the restore can be undone. Restoring
+// destroys the data on the instance, so the delete policy is consulted first
+// unless ctx comes from ContextWithForceDelete.
+func (s *SnapshotServiceHandler) RestoreInPlace(ctx context.Context, instanceID, snapshotID string, options *SnapshotRestoreOptions) (*SnapshotRestore, error) { //nolint:lll
+	if options == nil {
+		options = &SnapshotRestoreOptions{}
+	}
+
+	instance, _, err := s.client.Instance.Get(ctx, instanceID)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.client.guardsDelete(ctx) {
+		labels := appendLabels(appendLabels(nil, instance.Label), instance.Tags...)
+		if err = s.client.deletePolicy("instances", instance.ID, labels); err != nil {
+			return nil, err
+		}
+	}
+
+	snapshot, _, err := s.Get(ctx, snapshotID)
+	if err != nil {
+		return nil, err
+	}
+
+	if snapshot.Status != snapshotStatusComplete {
+		return nil, fmt.Errorf("snapshot %s is %s, not %s", snapshotID, snapshot.Status, snapshotStatusComplete)
+	}
+
+	restore := &SnapshotRestore{}
+	if !options.SkipSafetySnapshot {
+		description := fmt.Sprintf("pre-restore of %s to %s at %s", instanceID, snapshotID, time.Now().UTC().Format(time.RFC3339))
+		safety, _, err := s.Create(ctx, &SnapshotReq{InstanceID: instanceID, Description: description})
+		if err != nil {
+			return nil, fmt.Errorf("safety snapshot: %w", err)
+		}
+		restore.SafetySnapshot = safety
+
+		err = waitFor(ctx, options.Wait, func(ctx context.Context) (string, bool, error) {
+			var errGet error
+			safety, _, errGet = s.Get(ctx, safety.ID)
+			if errGet != nil {
+				return "", false, errGet
+			}
+			return safety.Status, safety.Status == snapshotStatusComplete, nil
+		})
+		if err != nil {
+			return restore, fmt.Errorf("safety snapshot: %w", err)
+		}
+		restore.SafetySnapshot = safety
+	}
+
+	if _, err = s.client.Instance.Restore(ctx, instanceID, &RestoreReq{SnapshotID: snapshotID}); err != nil {
+		return restore, err
+	}
+
+	instance, _, err = waitForRebuild(ctx, options.Wait, false, func(ctx context.Context) (*Instance, *http.Response, error) {
+		return s.client.Instance.Get(ctx, instanceID)
+	})
+	if err != nil {
+		return restore, err
+	}
+
+	restore.Instance = instance
+	return restore, nil
+}
@@ -1,10 +1,14 @@
 package govultr
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"reflect"
 	"testing"
+	"time"
 )
 
 func TestSnapshotServiceHandler_Create(t *testing.T) {
@@ -157,3 +161,246 @@ func TestSnapshotServiceHandler_List(t *testing.T) {
 		t.Errorf("Snapshot.list meta returned %+v, expected %+v", meta, expectedMeta)
 	}
 }
+
+func TestSnapshotServiceHandler_CreateConsistent(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v2/snapshots", func(writer http.ResponseWriter, request *http.Request) {
+		var req SnapshotReq
+		if err := json.NewDecoder(request.Body).Decode(&req); err != nil {
+			t.Errorf("Snapshot.CreateConsistent sent an invalid body: %v", err)
+		}
+		fmt.Fprintf(writer, `{"snapshot": {"id": "5359435d28b9a", "description": %q}}`, req.Description)
+	})
+
+	var hooks []string
+	snap := &SnapshotConsistentReq{
+		InstanceID:  "12345",
+		Description: "Test snapshot",
+		PreHook: func(ctx context.Context) error {
+			hooks = append(hooks, "pre")
+			return nil
+		},
+		PostHook: func(ctx context.Context) error {
+			hooks = append(hooks, "post")
+			return nil
+		},
+	}
+
+	snapshot, _, err := client.Snapshot.CreateConsistent(ctx, snap)
+	if err != nil {
+		t.Errorf("Snapshot.CreateConsistent returned error: %v", err)
+	}
+
+	if expected := "Test snapshot [application-consistent]"; snapshot.Description != expected {
+		t.Errorf("Snapshot.CreateConsistent description %q, expected %q", snapshot.Description, expected)
+	}
+
+	if snapshot.Consistency() != SnapshotConsistencyApplication {
+		t.Errorf("Snapshot.Consistency returned %q, expected %q", snapshot.Consistency(), SnapshotConsistencyApplication)
+	}
+
+	if expected := []string{"pre", "post"}; !reflect.DeepEqual(hooks, expected) {
+		t.Errorf("Snapshot.CreateConsistent ran hooks %+v, expected %+v", hooks, expected)
+	}
+
+	errFreeze := errors.New("could not freeze")
+	snap.PreHook = func(ctx context.Context) error {
+		return errFreeze
+	}
+
+	snapshot, _, err = client.Snapshot.CreateConsistent(ctx, snap)
+	if !errors.Is(err, errFreeze) {
+		t.Errorf("Snapshot.CreateConsistent returned error %v, expected %v", err, errFreeze)
+	}
+
+	if snapshot.Consistency() != SnapshotConsistencyCrash {
+		t.Errorf("Snapshot.Consistency returned %q, expected %q", snapshot.Consistency(), SnapshotConsistencyCrash)
+	}
+}
+
+func TestSnapshotServiceHandler_CreateWithMetadata(t *testing.T) {
+	setup()
+	defer teardown()
+
+	created := 0
+	mux.HandleFunc("/v2/snapshots", func(writer http.ResponseWriter, request *http.Request) {
+		if request.Method == http.MethodPost {
+			created++
+			var req SnapshotReq
+			_ = json.NewDecoder(request.Body).Decode(&req)
+			fmt.Fprintf(writer, `{"snapshot": {"id": "new", "description": %q}}`, req.Description)
+			return
+		}
+
+		response := `{"snapshots": [
+			{"id": "plain", "description": "nightly"},
+			{"id": "existing", "description": "{\"source_instance\":\"12345\",\"content_hash\":\"sha256:abc\",\"purpose\":\"release\"}"}
+		], "meta": {"total": 2, "links": {"next": "", "prev": ""}}}`
+		fmt.Fprint(writer, response)
+	})
+
+	snapshot, _, err := client.Snapshot.CreateWithMetadata(ctx, "12345", &SnapshotMetadata{SourceInstance: "12345", ContentHash: "sha256:abc"})
+	if err != nil {
+		t.Errorf("Snapshot.CreateWithMetadata returned error: %v", err)
+	}
+
+	if snapshot.ID != "existing" || created != 0 {
+		t.Errorf("Snapshot.CreateWithMetadata returned %+v, expected the existing snapshot", snapshot)
+	}
+
+	snapshot, _, err = client.Snapshot.CreateWithMetadata(ctx, "12345", &SnapshotMetadata{SourceInstance: "12345", GitSHA: "d34db33f"})
+	if err != nil {
+		t.Errorf("Snapshot.CreateWithMetadata returned error: %v", err)
+	}
+
+	metadata, ok := snapshot.Metadata()
+	expected := &SnapshotMetadata{SourceInstance: "12345", GitSHA: "d34db33f"}
+	if !ok || !reflect.DeepEqual(metadata, expected) {
+		t.Errorf("Snapshot.Metadata returned %+v, expected %+v", metadata, expected)
+	}
+
+	snapshots, _, _, _ := client.Snapshot.List(ctx, nil)
+	filtered := FilterSnapshots(snapshots, func(metadata *SnapshotMetadata) bool {
+		return metadata.Purpose == "release"
+	})
+
+	if len(filtered) != 1 || filtered[0].ID != "existing" {
+		t.Errorf("FilterSnapshots returned %+v, expected the existing snapshot", filtered)
+	}
+}
+
+func TestSnapshotServiceHandler_ListFiltered(t *testing.T) {
+	setup()
+	defer teardown()
+
+	now := time.Now().UTC()
+	mux.HandleFunc("/v2/snapshots", func(writer http.ResponseWriter, request *http.Request) {
+		response := fmt.Sprintf(`{"snapshots": [
+			{"id": "1", "date_created": %q, "description": "nightly web", "size": 10},
+			{"id": "2", "date_created": %q, "description": "nightly db", "size": 30},
+			{"id": "3", "date_created": %q, "description": "nightly cache", "size": 20},
+			{"id": "4", "date_created": %q, "description": "manual", "size": 40}
+		], "meta": {"total": 4, "links": {"next": "", "prev": ""}}}`,
+			now.Add(-48*time.Hour).Format(time.RFC3339),
+			now.Add(-72*time.Hour).Format(time.RFC3339),
+			now.Add(-time.Hour).Format(time.RFC3339),
+			now.Add(-96*time.Hour).Format(time.RFC3339),
+		)
+		fmt.Fprint(writer, response)
+	})
+
+	filter := &SnapshotFilter{MinAge: 24 * time.Hour, DescriptionGlob: "nightly *", SortBySizeDesc: true}
+	snapshots, err := client.Snapshot.ListFiltered(ctx, filter)
+	if err != nil {
+		t.Errorf("Snapshot.ListFiltered returned %+v", err)
+	}
+
+	var ids []string
+	for i := range snapshots {
+		ids = append(ids, snapshots[i].ID)
+	}
+
+	expected := []string{"2", "1"}
+	if !reflect.DeepEqual(ids, expected) {
+		t.Errorf("Snapshot.ListFiltered returned %+v, expected %+v", ids, expected)
+	}
+
+	snapshots, err = client.Snapshot.ListFiltered(ctx, nil)
+	if err != nil || len(snapshots) != 4 {
+		t.Errorf("Snapshot.ListFiltered returned %+v and %d snapshots, expected all 4 for a nil filter", err, len(snapshots))
+	}
+}
+
+func TestSnapshotServiceHandler_ListFilteredDescription(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v2/snapshots", func(writer http.ResponseWriter, request *http.Request) {
+		if description := request.URL.Query().Get("description"); description != "manual" {
+			t.Errorf("Snapshot.ListFiltered sent description %q, expected manual", description)
+		}
+		response := `{"snapshots": [{"id": "4", "date_created": "2014-04-18 12:40:40", "description": "manual", "size": 40}], "meta": {"total": 1, "links": {"next": "", "prev": ""}}}`
+		fmt.Fprint(writer, response)
+	})
+
+	snapshots, err := client.Snapshot.ListFiltered(ctx, &SnapshotFilter{DescriptionGlob: "manual", MaxSize: 50})
+	if err != nil {
+		t.Errorf("Snapshot.ListFiltered returned %+v", err)
+	}
+
+	if len(snapshots) != 1 || snapshots[0].ID != "4" {
+		t.Errorf("Snapshot.ListFiltered returned %+v, expected snapshot 4", snapshots)
+	}
+}
+
+func TestSnapshotServiceHandler_RestoreInPlace(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var calls []string
+	restoring := false
+	mux.HandleFunc("/v2/instances/i", func(writer http.ResponseWriter, request *http.Request) {
+		serverStatus := "ok"
+		if restoring {
+			restoring = false
+			serverStatus = "locked"
+			calls = append(calls, "restoring")
+		}
+		fmt.Fprintf(writer, `{"instance": {"id": "i", "label": "web", "tags": ["production"], "status": "active", "server_status": %q}}`, serverStatus)
+	})
+
+	mux.HandleFunc("/v2/snapshots/snap", func(writer http.ResponseWriter, request *http.Request) {
+		fmt.Fprint(writer, `{"snapshot": {"id": "snap", "status": "complete"}}`)
+	})
+
+	mux.HandleFunc("/v2/snapshots", func(writer http.ResponseWriter, request *http.Request) {
+		calls = append(calls, "snapshot")
+		fmt.Fprint(writer, `{"snapshot": {"id": "safe", "status": "pending"}}`)
+	})
+
+	safetyGets := 0
+	mux.HandleFunc("/v2/snapshots/safe", func(writer http.ResponseWriter, request *http.Request) {
+		safetyGets++
+		if safetyGets == 1 {
+			fmt.Fprint(writer, `{"snapshot": {"id": "safe", "status": "pending"}}`)
+			return
+		}
+		fmt.Fprint(writer, `{"snapshot": {"id": "safe", "status": "complete"}}`)
+	})
+
+	mux.HandleFunc("/v2/instances/i/restore", func(writer http.ResponseWriter, request *http.Request) {
+		body := RestoreReq{}
+		json.NewDecoder(request.Body).Decode(&body)
+		calls = append(calls, "restore "+body.SnapshotID)
+		restoring = true
+		writer.WriteHeader(http.StatusAccepted)
+	})
+
+	client.SetDeleteProtection("production")
+	options := &SnapshotRestoreOptions{Wait: &WaitOptions{Interval: time.Millisecond}}
+
+	var protected *DeleteProtectedError
+	if _, err := client.Snapshot.RestoreInPlace(ctx, "i", "snap", options); !errors.As(err, &protected) {
+		t.Errorf("Snapshot.RestoreInPlace returned %+v, expected a DeleteProtectedError", err)
+	}
+
+	if len(calls) != 0 {
+		t.Errorf("Snapshot.RestoreInPlace made calls %+v on a protected instance", calls)
+	}
+
+	restore, err := client.Snapshot.RestoreInPlace(ContextWithForceDelete(ctx), "i", "snap", options)
+	if err != nil {
+		t.Fatalf("Snapshot.RestoreInPlace returned %+v", err)
+	}
+
+	if restore.SafetySnapshot.ID != "safe" || restore.SafetySnapshot.Status != "complete" || restore.Instance.ID != "i" {
+		t.Errorf("Snapshot.RestoreInPlace returned %+v", restore)
+	}
+
+	expected := []string{"snapshot", "restore snap", "restoring"}
+	if !reflect.DeepEqual(calls, expected) {
+		t.Errorf("Snapshot.RestoreInPlace made calls %+v, expected %+v", calls, expected)
+	}
+}
@@ -18,6 +18,8 @@ type StartupScriptService interface { //nolint:dupl
 	Update(ctx context.Context, scriptID string, scriptReq *StartupScriptReq) error
 	Delete(ctx context.Context, scriptID string) error
 	List(ctx context.Context, options *ListOptions) ([]StartupScript, *Meta, *http.Response, error)
+
+	ListUsage(ctx context.Context, scriptID string) ([]Instance, error)
 }
 
 // StartupScriptServiceHandler handles interaction with the startup script methods for the Vultr API
@@ -51,6 +53,13 @@ type startupScriptBase struct {
 	StartupScript *StartupScript `json:"startup_script"`
 }
 
+// StartupScriptTag returns the tag used to correlate instances with the startup
+// script they were created with. Add it to InstanceCreateReq.Tags alongside
+// ScriptID so ListUsage can find the instance later.
+func StartupScriptTag(scriptID string) string {
+	return "startup-script:" + scriptID
+}
+
 var _ StartupScriptService = &StartupScriptServiceHandler{}
 
 // Create a startup script
@@ -134,3 +143,13 @@ func (s *StartupScriptServiceHandler) List(ctx context.Context, options *ListOpt
 
 	return scripts.StartupScripts, scripts.Meta, resp, nil
 }
+
+// ListUsage retrieves every instance tagged with StartupScriptTag for the given
+// script. The API does not record which startup script an instance was created
+// with, so only instances tagged at creation can be found.
+func (s *StartupScriptServiceHandler) ListUsage(ctx context.Context, scriptID string) ([]Instance, error) {
+	return collectPages(ctx, func(ctx context.Context, options *ListOptions) ([]Instance, *Meta, *http.Response, error) {
+		options.Tag = StartupScriptTag(scriptID)
+		return s.client.Instance.List(ctx, options)
+	})
+}
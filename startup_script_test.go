@@ -146,3 +146,25 @@ func TestStartupScriptServiceHandler_List(t *testing.T) {
 		t.Errorf("StartupScript.List meta returned %+v, expected %+v", meta, expectedMeta)
 	}
 }
+
+func TestStartupScriptServiceHandler_ListUsage(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v2/instances", func(writer http.ResponseWriter, request *http.Request) {
+		if tag := request.URL.Query().Get("tag"); tag != "startup-script:abc123" {
+			t.Errorf("StartupScript.ListUsage filtered on tag %q, expected %q", tag, "startup-script:abc123")
+		}
+		fmt.Fprint(writer, `{"instances": [{"id": "1", "tags": ["startup-script:abc123"]}], "meta": {"total": 1, "links": {"next": "", "prev": ""}}}`)
+	})
+
+	instances, err := client.StartupScript.ListUsage(ctx, "abc123")
+	if err != nil {
+		t.Errorf("StartupScript.ListUsage returned %+v", err)
+	}
+
+	expected := []Instance{{ID: "1", Tags: []string{"startup-script:abc123"}}}
+	if !reflect.DeepEqual(instances, expected) {
+		t.Errorf("StartupScript.ListUsage returned %+v, expected %+v", instances, expected)
+	}
+}
@@ -0,0 +1,175 @@
+package govultr
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const maxStreamLineBytes = 1 << 20
+
+// StreamEvent represents a single event read from a streaming endpoint. For
+// server-sent events all fields are populated from the event stream, for any
+// other content type each line of the response body is an event with only
+// Data set.
+type StreamEvent struct {
+	ID    string
+	Event string
+	Data  string
+	Retry time.Duration
+}
+
+// StreamHandler defines the type of the function called by Stream for each
+// event. Returning an error stops the stream and Stream returns that error.
+type StreamHandler func(event *StreamEvent) error
+
+// Stream sends an API request to an endpoint that streams its response, such
+// as server-sent events or chunked long-polling, and calls handler with each
+// event as it arrives. The request is only retried once with refreshed
+// credentials when it is rejected as unauthorized and is not subject to the
+// client timeout, so it runs until the server ends the response, ctx is done
+// or handler returns an error.
+func (c *Client) Stream(ctx context.Context, r *http.Request, handler StreamHandler) error {
+	if c.rateLimiter != nil {
+		if err := c.rateLimiter.Wait(ctx); err != nil {
+			return err
+		}
+	}
+
+	streamClient := *c.client.HTTPClient
+	streamClient.Timeout = 0
+
+	r = r.WithContext(ctx)
+	r.Header.Set("Accept", "text/event-stream")
+
+	if c.credentials != nil {
+		apiKey, err := c.credentials.APIKey(ctx)
+		if err != nil {
+			return err
+		}
+		r.Header.Set("Authorization", "Bearer "+apiKey)
+	}
+
+	res, err := c.sendStream(&streamClient, r)
+	if err == nil && res.StatusCode == http.StatusUnauthorized && c.credentials != nil {
+		drainAndClose(res.Body)
+
+		apiKey, errRefresh := c.refreshCredentials(ctx)
+		if errRefresh != nil {
+			return errRefresh
+		}
+
+		if r.GetBody != nil {
+			if r.Body, err = r.GetBody(); err != nil {
+				return err
+			}
+		}
+		r.Header.Set("Authorization", "Bearer "+apiKey)
+		res, err = c.sendStream(&streamClient, r)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	defer drainAndClose(res.Body)
+
+	if res.StatusCode < http.StatusOK || res.StatusCode > http.StatusNoContent {
+		body, err := io.ReadAll(res.Body)
+		if err != nil {
+			return err
+		}
+		return newErrorResponse(res, body)
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(res.Header.Get("Content-Type"))
+
+	scanner := bufio.NewScanner(res.Body)
+	scanner.Buffer(make([]byte, 0, 4096), maxStreamLineBytes)
+
+	if mediaType == "text/event-stream" {
+		err = readEventStream(scanner, handler)
+	} else {
+		err = readLineStream(scanner, handler)
+	}
+
+	if err != nil && ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	return err
+}
+
+// sendStream sends a streaming request once
+func (c *Client) sendStream(streamClient *http.Client, r *http.Request) (*http.Response, error) {
+	res, err := streamClient.Do(r)
+
+	if c.onRequestCompleted != nil {
+		c.onRequestCompleted(r, res)
+	}
+
+	return res, err
+}
+
+func readLineStream(scanner *bufio.Scanner, handler StreamHandler) error {
+	for scanner.Scan() {
+		if line := scanner.Text(); line != "" {
+			if err := handler(&StreamEvent{Data: line}); err != nil {
+				return err
+			}
+		}
+	}
+	return scanner.Err()
+}
+
+func readEventStream(scanner *bufio.Scanner, handler StreamHandler) error {
+	var id string
+	var event *StreamEvent
+	var data []string
+
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			if event != nil && data != nil {
+				event.ID = id
+				event.Data = strings.Join(data, "\n")
+				if err := handler(event); err != nil {
+					return err
+				}
+			}
+			event, data = nil, nil
+			continue
+		}
+
+		if strings.HasPrefix(line, ":") {
+			continue
+		}
+
+		if event == nil {
+			event = &StreamEvent{}
+		}
+
+		field, value, _ := strings.Cut(line, ":")
+		value = strings.TrimPrefix(value, " ")
+
+		switch field {
+		case "id":
+			id = value
+		case "event":
+			event.Event = value
+		case "data":
+			data = append(data, value)
+		case "retry":
+			if ms, err := strconv.Atoi(value); err == nil {
+				event.Retry = time.Duration(ms) * time.Millisecond
+			}
+		}
+	}
+
+	return scanner.Err()
+}
@@ -0,0 +1,139 @@
+package govultr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestClient_Stream(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v2/events", func(writer http.ResponseWriter, request *http.Request) {
+		if accept := request.Header.Get("Accept"); accept != "text/event-stream" {
+			t.Errorf("Stream Accept header = %s, expected text/event-stream", accept)
+		}
+
+		writer.Header().Set("Content-Type", "text/event-stream; charset=utf-8")
+		fmt.Fprint(writer, ": keep-alive\n\n")
+		fmt.Fprint(writer, "id: 1\nevent: status\ndata: pending\n\n")
+		writer.(http.Flusher).Flush()
+		fmt.Fprint(writer, "event: output\ndata: line one\ndata: line two\nretry: 500\n\n")
+	})
+
+	req, _ := client.NewRequest(ctx, http.MethodGet, "/v2/events", nil)
+
+	var events []StreamEvent
+	err := client.Stream(ctx, req, func(event *StreamEvent) error {
+		events = append(events, *event)
+		return nil
+	})
+	if err != nil {
+		t.Errorf("Client.Stream returned %+v", err)
+	}
+
+	expected := []StreamEvent{
+		{ID: "1", Event: "status", Data: "pending"},
+		{ID: "1", Event: "output", Data: "line one\nline two", Retry: 500 * time.Millisecond},
+	}
+
+	if !reflect.DeepEqual(events, expected) {
+		t.Errorf("Client.Stream returned %+v, expected %+v", events, expected)
+	}
+}
+
+func TestClient_StreamLines(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v2/feed", func(writer http.ResponseWriter, request *http.Request) {
+		writer.Header().Set("Content-Type", "application/x-ndjson")
+		fmt.Fprint(writer, "{\"n\":1}\n\n{\"n\":2}\n{\"n\":3}\n")
+	})
+
+	req, _ := client.NewRequest(ctx, http.MethodGet, "/v2/feed", nil)
+
+	stop := errors.New("stop")
+	var data []string
+	err := client.Stream(ctx, req, func(event *StreamEvent) error {
+		data = append(data, event.Data)
+		if len(data) == 2 {
+			return stop
+		}
+		return nil
+	})
+	if !errors.Is(err, stop) {
+		t.Errorf("Client.Stream returned %+v, expected the handler error", err)
+	}
+
+	expected := []string{`{"n":1}`, `{"n":2}`}
+	if !reflect.DeepEqual(data, expected) {
+		t.Errorf("Client.Stream returned %+v, expected %+v", data, expected)
+	}
+}
+
+func TestClient_StreamCancel(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v2/events", func(writer http.ResponseWriter, request *http.Request) {
+		writer.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprint(writer, "data: first\n\n")
+		writer.(http.Flusher).Flush()
+		<-request.Context().Done()
+	})
+
+	cctx, cancel := context.WithCancel(ctx)
+	req, _ := client.NewRequest(cctx, http.MethodGet, "/v2/events", nil)
+
+	err := client.Stream(cctx, req, func(event *StreamEvent) error {
+		cancel()
+		return nil
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Client.Stream returned %+v, expected context.Canceled", err)
+	}
+}
+
+func TestClient_StreamCredentials(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var seen []string
+	mux.HandleFunc("/v2/events", func(writer http.ResponseWriter, request *http.Request) {
+		auth := request.Header.Get("Authorization")
+		seen = append(seen, auth)
+		if auth != "Bearer new-key" && auth != "Bearer profile-key" {
+			writer.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprint(writer, `{"error": "Invalid API token."}`)
+			return
+		}
+		fmt.Fprint(writer, "done\n")
+	})
+
+	profileClient, err := (&Profile{APIKey: "profile-key", BaseURL: server.URL}).NewClient()
+	if err != nil {
+		t.Fatalf("Profile.NewClient returned %+v", err)
+	}
+
+	req, _ := profileClient.NewRequest(ctx, http.MethodGet, "/v2/events", nil)
+	if err = profileClient.Stream(ctx, req, func(event *StreamEvent) error { return nil }); err != nil {
+		t.Errorf("Client.Stream returned %+v", err)
+	}
+
+	client.SetCredentialsProvider(&rotatingCredentials{keys: []string{"old-key", "new-key"}})
+	req, _ = client.NewRequest(ctx, http.MethodGet, "/v2/events", nil)
+	if err = client.Stream(ctx, req, func(event *StreamEvent) error { return nil }); err != nil {
+		t.Errorf("Client.Stream returned %+v", err)
+	}
+
+	expected := []string{"Bearer profile-key", "Bearer old-key", "Bearer new-key"}
+	if !reflect.DeepEqual(seen, expected) {
+		t.Errorf("Authorization headers were %+v, expected %+v", seen, expected)
+	}
+}
@@ -0,0 +1,100 @@
+package govultr
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"sync/atomic"
+
+	"github.com/hashicorp/go-retryablehttp"
+)
+
+// Tracer starts a span for each call made to the Vultr API, such as an
+// OpenTelemetry tracer wrapped in a few lines:
+//
+//	func (t otelTracer) Start(ctx context.Context, req *http.Request, info *govultr.SpanInfo) (context.Context, govultr.Span) {
+//		ctx, span := t.tracer.Start(ctx, info.Name, trace.WithSpanKind(trace.SpanKindClient))
+//		otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(req.Header))
+//		return ctx, otelSpan{span}
+//	}
+//
+// Start may add headers to req to propagate the trace.
+type Tracer interface {
+	Start(ctx context.Context, req *http.Request, info *SpanInfo) (context.Context, Span)
+}
+
+// Span is a span started by a Tracer. End is called once when the call
+// completes, with StatusCode, Retries and Err set on info.
+type Span interface {
+	End(info *SpanInfo)
+}
+
+// SpanInfo describes the API call a span covers. Service is the API resource
+// the path belongs to, e.g. "instances" for /v2/instances/{id}. StatusCode is
+// 0 when no response was received and Retries counts requests sent after the
+// first one.
+type SpanInfo struct {
+	Name       string
+	Service    string
+	Method     string
+	Path       string
+	StatusCode int
+	Retries    int
+	Err        error
+}
+
+// SetTracer starts a span with tracer for every request made to the Vultr API,
+// covering request and response hooks, rate limiting and retries.
+func (c *Client) SetTracer(tracer Tracer) {
+	c.tracer = tracer
+	c.client.RequestLogHook = countAttempt
+}
+
+type attemptsKey struct{}
+
+// countAttempt counts the requests sent for a traced call
+func countAttempt(_ retryablehttp.Logger, r *http.Request, _ int) {
+	if attempts, ok := r.Context().Value(attemptsKey{}).(*atomic.Int32); ok {
+		attempts.Add(1)
+	}
+}
+
+// traced runs send inside a span when a tracer is set
+func (c *Client) traced(ctx context.Context, r *http.Request, send func(ctx context.Context, r *http.Request) (*http.Response, error)) (*http.Response, error) { //nolint:lll
+	if c.tracer == nil {
+		return send(ctx, r)
+	}
+
+	info := &SpanInfo{Service: spanService(r.URL.Path), Method: r.Method, Path: r.URL.Path}
+	info.Name = "govultr " + info.Service + " " + info.Method
+
+	ctx, span := c.tracer.Start(ctx, r, info)
+	attempts := new(atomic.Int32)
+	ctx = context.WithValue(ctx, attemptsKey{}, attempts)
+
+	res, err := send(ctx, r.WithContext(ctx))
+
+	var errResp *ErrorResponse
+	if res != nil {
+		info.StatusCode = res.StatusCode
+	} else if errors.As(err, &errResp) {
+		info.StatusCode = errResp.StatusCode
+	}
+	if n := int(attempts.Load()); n > 1 {
+		info.Retries = n - 1
+	}
+	info.Err = err
+	span.End(info)
+
+	return res, err
+}
+
+// spanService returns the first segment of an API path after its version
+func spanService(path string) string {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	if len(segments) > 1 && strings.HasPrefix(segments[0], "v") {
+		return segments[1]
+	}
+	return segments[0]
+}
@@ -0,0 +1,70 @@
+package govultr
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type testTracer struct {
+	spans []*SpanInfo
+}
+
+type testSpan struct {
+	tracer *testTracer
+}
+
+func (t *testTracer) Start(ctx context.Context, req *http.Request, info *SpanInfo) (context.Context, Span) {
+	req.Header.Set("Traceparent", "00-trace-span-01")
+	return ctx, &testSpan{tracer: t}
+}
+
+func (s *testSpan) End(info *SpanInfo) {
+	s.tracer.spans = append(s.tracer.spans, info)
+}
+
+func TestClient_SetTracer(t *testing.T) {
+	setup()
+	defer teardown()
+
+	attempts := 0
+	mux.HandleFunc("/v2/instances/abc", func(writer http.ResponseWriter, request *http.Request) {
+		if parent := request.Header.Get("Traceparent"); parent != "00-trace-span-01" {
+			t.Errorf("tracer set Traceparent %q, expected 00-trace-span-01", parent)
+		}
+
+		attempts++
+		if attempts == 1 {
+			http.Error(writer, `{"error": "unavailable", "status": 503}`, http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprint(writer, `{"instance": {"id": "abc"}}`)
+	})
+
+	mux.HandleFunc("/v2/instances/missing", func(writer http.ResponseWriter, request *http.Request) {
+		http.Error(writer, `{"error": "instance not found", "status": 404}`, http.StatusNotFound)
+	})
+
+	tracer := &testTracer{}
+	client.SetTracer(tracer)
+	client.client.RetryWaitMin = time.Millisecond
+	client.client.RetryWaitMax = time.Millisecond
+
+	if _, _, err := client.Instance.Get(ctx, "abc"); err != nil {
+		t.Fatalf("Instance.Get returned %+v", err)
+	}
+
+	_, _, errMissing := client.Instance.Get(ctx, "missing")
+
+	expected := []*SpanInfo{
+		{Name: "govultr instances GET", Service: "instances", Method: http.MethodGet, Path: "/v2/instances/abc", StatusCode: http.StatusOK, Retries: 1},
+		{Name: "govultr instances GET", Service: "instances", Method: http.MethodGet, Path: "/v2/instances/missing", StatusCode: http.StatusNotFound, Err: errMissing},
+	}
+
+	if !reflect.DeepEqual(tracer.spans, expected) {
+		t.Errorf("tracer ended spans %+v, expected %+v", tracer.spans, expected)
+	}
+}
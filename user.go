@@ -2,14 +2,32 @@ package govultr
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
+	"slices"
+	"strings"
 
 	"github.com/google/go-querystring/query"
 )
 
 const path = "/v2/users"
 
+// Actions reported in a UserChange
+const (
+	UserChangeCreate     = "create"
+	UserChangeUpdate     = "update"
+	UserChangeDisableAPI = "disable_api"
+	UserChangeDelete     = "delete"
+)
+
+// How Sync treats users that are not in the desired spec
+const (
+	UserPruneNone = iota
+	UserPruneDisableAPI
+	UserPruneDelete
+)
+
 // UserService is the interface to interact with the user management endpoints on the Vultr API
 // Link : https://www.vultr.com/api/#tag/users
 type UserService interface { //nolint:dupl
@@ -18,6 +36,9 @@ type UserService interface { //nolint:dupl
 	Update(ctx context.Context, userID string, userReq *UserReq) error
 	Delete(ctx context.Context, userID string) error
 	List(ctx context.Context, options *ListOptions) ([]User, *Meta, *http.Response, error)
+
+	ListAPIEnabled(ctx context.Context) ([]User, error)
+	Sync(ctx context.Context, desired []UserSpec, options *UserSyncOptions) ([]UserChange, error)
 }
 
 var _ UserService = &UserServiceHandler{}
@@ -46,6 +67,31 @@ type UserReq struct {
 	Password   string   `json:"password,omitempty"`
 }
 
+// UserSpec is the desired state of a user, matched to existing users by email.
+// Password is only used when the user is created.
+type UserSpec struct {
+	Email      string
+	Name       string
+	APIEnabled bool
+	ACL        []string
+	Password   string
+}
+
+// UserSyncOptions are used by Sync. Prune is one of UserPruneNone,
+// UserPruneDisableAPI or UserPruneDelete. The API has no way to disable a
+// user's console login, so UserPruneDisableAPI only revokes API access.
+type UserSyncOptions struct {
+	DryRun bool
+	Prune  int
+}
+
+// UserChange is a change made, or with DryRun planned, by Sync
+type UserChange struct {
+	Action string
+	Email  string
+	UserID string
+}
+
 type usersBase struct {
 	Users []User `json:"users"`
 	Meta  *Meta  `json:"meta"`
@@ -136,3 +182,129 @@ func (u *UserServiceHandler) List(ctx context.Context, options *ListOptions) ([]
 
 	return users.Users, users.Meta, resp, nil
 }
+
+// ListAPIEnabled retrieves every user on your Vultr account that has API access
+// enabled, for use in compliance scans. The API does not expose two-factor or
+// login session details for users, so API access is the only security
+// metadata available to filter on.
+func (u *UserServiceHandler) ListAPIEnabled(ctx context.Context) ([]User, error) {
+	all, err := collectPages(ctx, u.List)
+	if err != nil {
+		return nil, err
+	}
+
+	var users []User
+	for i := range all {
+		if all[i].APIEnabled != nil && *all[i].APIEnabled {
+			users = append(users, all[i])
+		}
+	}
+
+	return users, nil
+}
+
+// Sync creates the users in desired that do not exist, updates the name, API
+// access and ACLs of those that differ and prunes users not in desired as set
+// by options. Every change is returned, and with DryRun nothing is applied.
+// Errors from individual changes do not stop the sync and are returned joined.
+func (u *UserServiceHandler) Sync(ctx context.Context, desired []UserSpec, options *UserSyncOptions) ([]UserChange, error) {
+	if options == nil {
+		options = &UserSyncOptions{}
+	}
+
+	users, err := collectPages(ctx, u.List)
+	if err != nil {
+		return nil, err
+	}
+
+	existing := make(map[string]*User, len(users))
+	for i := range users {
+		existing[strings.ToLower(users[i].Email)] = &users[i]
+	}
+
+	var changes []UserChange
+	var errs []error
+	apply := func(change UserChange, do func() error) {
+		changes = append(changes, change)
+		if options.DryRun {
+			return
+		}
+		if err := do(); err != nil {
+			errs = append(errs, fmt.Errorf("%s %s: %w", change.Action, change.Email, err))
+		}
+	}
+
+	wanted := make(map[string]bool, len(desired))
+	for i := range desired {
+		spec := &desired[i]
+		email := strings.ToLower(spec.Email)
+		wanted[email] = true
+		apiEnabled := spec.APIEnabled
+
+		user, ok := existing[email]
+		if !ok {
+			apply(UserChange{Action: UserChangeCreate, Email: spec.Email}, func() error {
+				_, _, err := u.Create(ctx, &UserReq{
+					Email:      spec.Email,
+					Name:       spec.Name,
+					APIEnabled: &apiEnabled,
+					ACL:        spec.ACL,
+					Password:   spec.Password,
+				})
+				return err
+			})
+			continue
+		}
+
+		if user.Name == spec.Name && user.APIEnabled != nil && *user.APIEnabled == apiEnabled && sameACL(user.ACL, spec.ACL) {
+			continue
+		}
+
+		apply(UserChange{Action: UserChangeUpdate, Email: user.Email, UserID: user.ID}, func() error {
+			// acls is always sent so an empty list removes every permission
+			acl := spec.ACL
+			if acl == nil {
+				acl = []string{}
+			}
+			_, err := u.client.Raw(ctx, http.MethodPatch, fmt.Sprintf("%s/%s", path, user.ID), RequestBody{
+				"name":        spec.Name,
+				"api_enabled": apiEnabled,
+				"acls":        acl,
+			}, nil)
+			return err
+		})
+	}
+
+	for i := range users {
+		user := &users[i]
+		if wanted[strings.ToLower(user.Email)] {
+			continue
+		}
+
+		switch options.Prune {
+		case UserPruneDisableAPI:
+			if user.APIEnabled != nil && !*user.APIEnabled {
+				continue
+			}
+			apply(UserChange{Action: UserChangeDisableAPI, Email: user.Email, UserID: user.ID}, func() error {
+				disabled := false
+				return u.Update(ctx, user.ID, &UserReq{APIEnabled: &disabled})
+			})
+		case UserPruneDelete:
+			apply(UserChange{Action: UserChangeDelete, Email: user.Email, UserID: user.ID}, func() error {
+				return u.Delete(ctx, user.ID)
+			})
+		}
+	}
+
+	return changes, errors.Join(errs...)
+}
+
+// sameACL reports whether two ACLs grant the same permissions
+func sameACL(a, b []string) bool {
+	a = slices.Clone(a)
+	b = slices.Clone(b)
+	slices.Sort(a)
+	slices.Sort(b)
+	return slices.Equal(slices.Compact(a), slices.Compact(b))
+}
@@ -1,6 +1,7 @@
 package govultr
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"reflect"
@@ -172,3 +173,101 @@ func TestUserServiceHandler_Get(t *testing.T) {
 		t.Errorf("User.List users returned %+v, expected %+v", user, expected)
 	}
 }
+
+func TestUserServiceHandler_ListAPIEnabled(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v2/users", func(writer http.ResponseWriter, request *http.Request) {
+		if request.URL.Query().Get("cursor") == "" {
+			fmt.Fprint(writer, `{"users": [{"id": "1", "api_enabled": true}, {"id": "2", "api_enabled": false}], "meta": {"total": 3, "links": {"next": "page2", "prev": ""}}}`) //nolint:lll
+			return
+		}
+		fmt.Fprint(writer, `{"users": [{"id": "3", "api_enabled": true}], "meta": {"total": 3, "links": {"next": "", "prev": "page1"}}}`)
+	})
+
+	users, err := client.User.ListAPIEnabled(ctx)
+	if err != nil {
+		t.Errorf("User.ListAPIEnabled returned error: %v", err)
+	}
+
+	expected := []User{
+		{ID: "1", APIEnabled: BoolToBoolPtr(true)},
+		{ID: "3", APIEnabled: BoolToBoolPtr(true)},
+	}
+
+	if !reflect.DeepEqual(users, expected) {
+		t.Errorf("User.ListAPIEnabled returned %+v, expected %+v", users, expected)
+	}
+}
+
+func TestUserServiceHandler_Sync(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var calls []string
+	mux.HandleFunc("/v2/users", func(writer http.ResponseWriter, request *http.Request) {
+		if request.Method == http.MethodPost {
+			body := UserReq{}
+			json.NewDecoder(request.Body).Decode(&body)
+			calls = append(calls, "create "+body.Email)
+			fmt.Fprint(writer, `{"user": {"id": "d"}}`)
+			return
+		}
+		fmt.Fprint(writer, `{"users": [
+			{"id": "a", "name": "A", "email": "a@example.com", "api_enabled": true, "acls": ["billing", "support"]},
+			{"id": "b", "name": "B", "email": "b@example.com", "api_enabled": false, "acls": ["billing"]},
+			{"id": "c", "name": "C", "email": "c@example.com", "api_enabled": true, "acls": []}
+		], "meta": {"total": 3, "links": {"next": "", "prev": ""}}}`)
+	})
+
+	mux.HandleFunc("/v2/users/", func(writer http.ResponseWriter, request *http.Request) {
+		body := map[string]interface{}{}
+		json.NewDecoder(request.Body).Decode(&body)
+		calls = append(calls, fmt.Sprintf("%s %s %v", request.Method, request.URL.Path, body))
+		writer.WriteHeader(http.StatusNoContent)
+	})
+
+	desired := []UserSpec{
+		{Email: "A@example.com", Name: "A", APIEnabled: true, ACL: []string{"support", "billing"}},
+		{Email: "b@example.com", Name: "B", ACL: nil},
+		{Email: "d@example.com", Name: "D", ACL: []string{"dns"}, Password: "secret"},
+	}
+
+	expected := []UserChange{
+		{Action: UserChangeUpdate, Email: "b@example.com", UserID: "b"},
+		{Action: UserChangeCreate, Email: "d@example.com"},
+		{Action: UserChangeDelete, Email: "c@example.com", UserID: "c"},
+	}
+
+	changes, err := client.User.Sync(ctx, desired, &UserSyncOptions{DryRun: true, Prune: UserPruneDelete})
+	if err != nil {
+		t.Errorf("User.Sync returned %+v", err)
+	}
+
+	if !reflect.DeepEqual(changes, expected) {
+		t.Errorf("User.Sync returned %+v, expected %+v", changes, expected)
+	}
+
+	if len(calls) != 0 {
+		t.Errorf("User.Sync made calls %+v on a dry run", calls)
+	}
+
+	changes, err = client.User.Sync(ctx, desired, &UserSyncOptions{Prune: UserPruneDelete})
+	if err != nil {
+		t.Errorf("User.Sync returned %+v", err)
+	}
+
+	if !reflect.DeepEqual(changes, expected) {
+		t.Errorf("User.Sync returned %+v, expected %+v", changes, expected)
+	}
+
+	expectedCalls := []string{
+		"PATCH /v2/users/b map[acls:[] api_enabled:false name:B]",
+		"create d@example.com",
+		"DELETE /v2/users/c map[]",
+	}
+	if !reflect.DeepEqual(calls, expectedCalls) {
+		t.Errorf("User.Sync made calls %+v, expected %+v", calls, expectedCalls)
+	}
+}
@@ -4,20 +4,30 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"net/netip"
 
 	"github.com/google/go-querystring/query"
 )
 
-const vpcPath = "/v2/vpcs"
+const (
+	vpcPath = "/v2/vpcs"
+
+	vpcStatusPending = "pending"
+	vpcStatusReady   = "ready"
+)
 
 // VPCService is the interface to interact with the VPC endpoints on the Vultr API
 // Link : https://www.vultr.com/api/#tag/vpcs
 type VPCService interface {
 	Create(ctx context.Context, createReq *VPCReq) (*VPC, *http.Response, error)
+	CreateAndWait(ctx context.Context, createReq *VPCReq, options *WaitOptions) (*VPC, *http.Response, error)
 	Get(ctx context.Context, vpcID string) (*VPC, *http.Response, error)
 	Update(ctx context.Context, vpcID string, description string) error
+	UpdateAndGet(ctx context.Context, vpcID string, updateReq *VPCUpdateReq) (*VPC, *http.Response, error)
 	Delete(ctx context.Context, vpcID string) error
 	List(ctx context.Context, options *ListOptions) ([]VPC, *Meta, *http.Response, error)
+
+	GetAddressMap(ctx context.Context, vpcID string) (*VPCAddressMap, error)
 }
 
 // VPCServiceHandler handles interaction with the VPC methods for the Vultr API
@@ -43,6 +53,26 @@ type VPCReq struct {
 	V4SubnetMask int    `json:"v4_subnet_mask"`
 }
 
+// VPCUpdateReq represents the mutable fields of a VPC. The description is
+// currently the only field the API allows to be changed.
+type VPCUpdateReq struct {
+	Description string `json:"description"`
+}
+
+// VPCAddress represents a private IP address assigned to a resource on a VPC
+type VPCAddress struct {
+	IPAddress    string
+	MacAddress   string
+	ResourceType string
+	ResourceID   string
+}
+
+// VPCAddressMap represents every private IP address in use on a VPC
+type VPCAddressMap struct {
+	VPC       *VPC
+	Addresses []VPCAddress
+}
+
 type vpcsBase struct {
 	VPCs []VPC `json:"vpcs"`
 	Meta *Meta `json:"meta"`
@@ -68,6 +98,34 @@ func (n *VPCServiceHandler) Create(ctx context.Context, createReq *VPCReq) (*VPC
 	return vpc.VPC, resp, nil
 }
 
+// CreateAndWait creates a new VPC and polls until it can be read back with
+// its subnet assigned
+func (n *VPCServiceHandler) CreateAndWait(ctx context.Context, createReq *VPCReq, options *WaitOptions) (*VPC, *http.Response, error) { //nolint:lll
+	vpc, resp, err := n.Create(ctx, createReq)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	vpcID := vpc.ID
+	err = waitFor(ctx, options, func(ctx context.Context) (string, bool, error) {
+		var errGet error
+		vpc, resp, errGet = n.Get(ctx, vpcID)
+		if errGet != nil {
+			return "", false, errGet
+		}
+
+		if vpc.V4Subnet == "" {
+			return vpcStatusPending, false, nil
+		}
+		return vpcStatusReady, true, nil
+	})
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return vpc, resp, nil
+}
+
 // Get gets the VPC of the requested ID
 func (n *VPCServiceHandler) Get(ctx context.Context, vpcID string) (*VPC, *http.Response, error) {
 	uri := fmt.Sprintf("%s/%s", vpcPath, vpcID)
@@ -99,6 +157,21 @@ func (n *VPCServiceHandler) Update(ctx context.Context, vpcID, description strin
 	return err
 }
 
+// UpdateAndGet updates a VPC and returns it as read back from the API
+func (n *VPCServiceHandler) UpdateAndGet(ctx context.Context, vpcID string, updateReq *VPCUpdateReq) (*VPC, *http.Response, error) { //nolint:lll
+	uri := fmt.Sprintf("%s/%s", vpcPath, vpcID)
+	req, err := n.client.NewRequest(ctx, http.MethodPut, uri, updateReq)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if resp, err := n.client.DoWithContext(ctx, req, nil); err != nil {
+		return nil, resp, err
+	}
+
+	return n.Get(ctx, vpcID)
+}
+
 // Delete deletes a VPC. Before deleting, a VPC must be disabled from all instances
 func (n *VPCServiceHandler) Delete(ctx context.Context, vpcID string) error {
 	uri := fmt.Sprintf("%s/%s", vpcPath, vpcID)
@@ -132,3 +205,147 @@ func (n *VPCServiceHandler) List(ctx context.Context, options *ListOptions) ([]V
 
 	return vpcs.VPCs, vpcs.Meta, resp, nil
 }
+
+// GetAddressMap returns the private IP addresses of all instances and bare
+// metal servers attached to a VPC. Each resource in the VPC region is queried
+// so this can take a while on large accounts.
+func (n *VPCServiceHandler) GetAddressMap(ctx context.Context, vpcID string) (*VPCAddressMap, error) {
+	vpc, _, err := n.Get(ctx, vpcID)
+	if err != nil {
+		return nil, err
+	}
+
+	instances, err := collectPages(ctx, func(ctx context.Context, options *ListOptions) ([]Instance, *Meta, *http.Response, error) {
+		options.Region = vpc.Region
+		return n.client.Instance.List(ctx, options)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	addressMap := &VPCAddressMap{VPC: vpc}
+	for i := range instances {
+		vpcs, _, _, err := n.client.Instance.ListVPCInfo(ctx, instances[i].ID, &ListOptions{PerPage: 100})
+		if err != nil {
+			return nil, err
+		}
+		addressMap.add(vpcs, "instance", instances[i].ID)
+	}
+
+	servers, err := collectPages(ctx, n.client.BareMetalServer.List)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range servers {
+		if servers[i].Region != vpc.Region {
+			continue
+		}
+
+		vpcs, _, err := n.client.BareMetalServer.ListVPCInfo(ctx, servers[i].ID)
+		if err != nil {
+			return nil, err
+		}
+		addressMap.add(vpcs, "bare_metal", servers[i].ID)
+	}
+
+	return addressMap, nil
+}
+
+func (m *VPCAddressMap) add(vpcs []VPCInfo, resourceType, resourceID string) {
+	for i := range vpcs {
+		if vpcs[i].ID == m.VPC.ID {
+			m.Addresses = append(m.Addresses, VPCAddress{
+				IPAddress:    vpcs[i].IPAddress,
+				MacAddress:   vpcs[i].MacAddress,
+				ResourceType: resourceType,
+				ResourceID:   resourceID,
+			})
+		}
+	}
+}
+
+// Conflicts returns the addresses that are assigned to more than one resource
+// or that fall within one of the externally managed CIDR ranges in reserved
+func (m *VPCAddressMap) Conflicts(reserved []string) ([]VPCAddress, error) {
+	prefixes, err := parsePrefixes(reserved)
+	if err != nil {
+		return nil, err
+	}
+
+	count := make(map[string]int, len(m.Addresses))
+	for i := range m.Addresses {
+		count[m.Addresses[i].IPAddress]++
+	}
+
+	var conflicts []VPCAddress
+	for i := range m.Addresses {
+		addr, err := netip.ParseAddr(m.Addresses[i].IPAddress)
+		if err != nil {
+			return nil, err
+		}
+
+		if count[m.Addresses[i].IPAddress] > 1 || containsAddr(prefixes, addr) {
+			conflicts = append(conflicts, m.Addresses[i])
+		}
+	}
+
+	return conflicts, nil
+}
+
+// NextFree returns the lowest address in the VPC subnet that is not assigned
+// and not within one of the CIDR ranges in reserved. The network address, the
+// first host address used as the gateway and the broadcast address are never
+// returned.
+func (m *VPCAddressMap) NextFree(reserved []string) (netip.Addr, error) {
+	prefixes, err := parsePrefixes(reserved)
+	if err != nil {
+		return netip.Addr{}, err
+	}
+
+	subnet, err := netip.ParseAddr(m.VPC.V4Subnet)
+	if err != nil {
+		return netip.Addr{}, err
+	}
+
+	prefix, err := subnet.Prefix(m.VPC.V4SubnetMask)
+	if err != nil {
+		return netip.Addr{}, err
+	}
+
+	used := make(map[netip.Addr]bool, len(m.Addresses))
+	for i := range m.Addresses {
+		if addr, err := netip.ParseAddr(m.Addresses[i].IPAddress); err == nil {
+			used[addr] = true
+		}
+	}
+
+	for addr := prefix.Addr().Next().Next(); prefix.Contains(addr.Next()); addr = addr.Next() {
+		if !used[addr] && !containsAddr(prefixes, addr) {
+			return addr, nil
+		}
+	}
+
+	return netip.Addr{}, fmt.Errorf("no free address in VPC %s (%s)", m.VPC.ID, prefix)
+}
+
+func parsePrefixes(cidrs []string) ([]netip.Prefix, error) {
+	prefixes := make([]netip.Prefix, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		prefix, err := netip.ParsePrefix(cidr)
+		if err != nil {
+			return nil, err
+		}
+		prefixes = append(prefixes, prefix)
+	}
+	return prefixes, nil
+}
+
+func containsAddr(prefixes []netip.Prefix, addr netip.Addr) bool {
+	for _, prefix := range prefixes {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
@@ -1,10 +1,12 @@
 package govultr
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"reflect"
 	"testing"
+	"time"
 )
 
 func TestVPCServiceHandler_Create(t *testing.T) {
@@ -152,3 +154,145 @@ func TestVPCServiceHandler_Get(t *testing.T) {
 		t.Errorf("VPC.Get returned %+v, expected %+v", vpc, expected)
 	}
 }
+
+func TestVPCServiceHandler_GetAddressMap(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v2/vpcs/net1", func(writer http.ResponseWriter, request *http.Request) {
+		response := `{"vpc": {"id": "net1", "region": "ewr", "v4_subnet": "10.99.0.0", "v4_subnet_mask": 24}}`
+		fmt.Fprint(writer, response)
+	})
+
+	mux.HandleFunc("/v2/instances", func(writer http.ResponseWriter, request *http.Request) {
+		if region := request.URL.Query().Get("region"); region != "ewr" {
+			t.Errorf("Instance.List region = %s, expected ewr", region)
+		}
+		fmt.Fprint(writer, `{"instances": [{"id": "i1"}, {"id": "i2"}], "meta": {"total": 2}}`)
+	})
+
+	mux.HandleFunc("/v2/instances/i1/vpcs", func(writer http.ResponseWriter, request *http.Request) {
+		fmt.Fprint(writer, `{"vpcs": [{"id": "net1", "mac_address": "5a:00:00:00:00:01", "ip_address": "10.99.0.2"}], "meta": {"total": 1}}`)
+	})
+
+	mux.HandleFunc("/v2/instances/i2/vpcs", func(writer http.ResponseWriter, request *http.Request) {
+		fmt.Fprint(writer, `{"vpcs": [{"id": "other", "ip_address": "10.1.0.3"}, {"id": "net1", "ip_address": "10.99.0.4"}], "meta": {"total": 2}}`)
+	})
+
+	mux.HandleFunc("/v2/bare-metals", func(writer http.ResponseWriter, request *http.Request) {
+		fmt.Fprint(writer, `{"bare_metals": [{"id": "b1", "region": "ewr"}, {"id": "b2", "region": "lax"}], "meta": {"total": 2}}`)
+	})
+
+	mux.HandleFunc("/v2/bare-metals/b1/vpcs", func(writer http.ResponseWriter, request *http.Request) {
+		fmt.Fprint(writer, `{"vpcs": [{"id": "net1", "ip_address": "10.99.0.4"}]}`)
+	})
+
+	addressMap, err := client.VPC.GetAddressMap(ctx, "net1")
+	if err != nil {
+		t.Errorf("VPC.GetAddressMap returned %+v", err)
+	}
+
+	expected := []VPCAddress{
+		{IPAddress: "10.99.0.2", MacAddress: "5a:00:00:00:00:01", ResourceType: "instance", ResourceID: "i1"},
+		{IPAddress: "10.99.0.4", ResourceType: "instance", ResourceID: "i2"},
+		{IPAddress: "10.99.0.4", ResourceType: "bare_metal", ResourceID: "b1"},
+	}
+
+	if !reflect.DeepEqual(addressMap.Addresses, expected) {
+		t.Errorf("VPC.GetAddressMap returned %+v, expected %+v", addressMap.Addresses, expected)
+	}
+
+	conflicts, err := addressMap.Conflicts([]string{"10.99.0.0/31"})
+	if err != nil {
+		t.Errorf("VPCAddressMap.Conflicts returned %+v", err)
+	}
+
+	if !reflect.DeepEqual(conflicts, expected[1:]) {
+		t.Errorf("VPCAddressMap.Conflicts returned %+v, expected %+v", conflicts, expected[1:])
+	}
+
+	conflicts, _ = addressMap.Conflicts([]string{"10.99.0.2/32"})
+	if len(conflicts) != 3 {
+		t.Errorf("VPCAddressMap.Conflicts returned %+v, expected all addresses", conflicts)
+	}
+
+	next, err := addressMap.NextFree([]string{"10.99.0.3/32"})
+	if err != nil {
+		t.Errorf("VPCAddressMap.NextFree returned %+v", err)
+	}
+
+	if next.String() != "10.99.0.5" {
+		t.Errorf("VPCAddressMap.NextFree returned %s, expected 10.99.0.5", next)
+	}
+
+	if _, err = addressMap.NextFree([]string{"10.99.0.0/24"}); err == nil {
+		t.Error("VPCAddressMap.NextFree expected an error when every address is reserved")
+	}
+}
+
+func TestVPCServiceHandler_CreateAndWait(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v2/vpcs", func(writer http.ResponseWriter, request *http.Request) {
+		fmt.Fprint(writer, `{"vpc": {"id": "net1", "region": "ewr", "description": "test1"}}`)
+	})
+
+	gets := 0
+	mux.HandleFunc("/v2/vpcs/net1", func(writer http.ResponseWriter, request *http.Request) {
+		gets++
+		if gets == 1 {
+			fmt.Fprint(writer, `{"vpc": {"id": "net1", "region": "ewr", "description": "test1"}}`)
+			return
+		}
+		fmt.Fprint(writer, `{"vpc": {"id": "net1", "region": "ewr", "description": "test1", "v4_subnet": "10.99.0.0", "v4_subnet_mask": 24}}`)
+	})
+
+	var statuses []string
+	options := &WaitOptions{
+		Interval: time.Millisecond,
+		Progress: func(status string, _ time.Duration) { statuses = append(statuses, status) },
+	}
+
+	vpc, _, err := client.VPC.CreateAndWait(ctx, &VPCReq{Region: "ewr", Description: "test1"}, options)
+	if err != nil {
+		t.Errorf("VPC.CreateAndWait returned %+v", err)
+	}
+
+	expected := &VPC{ID: "net1", Region: "ewr", Description: "test1", V4Subnet: "10.99.0.0", V4SubnetMask: 24}
+	if !reflect.DeepEqual(vpc, expected) {
+		t.Errorf("VPC.CreateAndWait returned %+v, expected %+v", vpc, expected)
+	}
+
+	expectedStatuses := []string{"pending", "ready"}
+	if !reflect.DeepEqual(statuses, expectedStatuses) {
+		t.Errorf("VPC.CreateAndWait reported %+v, expected %+v", statuses, expectedStatuses)
+	}
+}
+
+func TestVPCServiceHandler_UpdateAndGet(t *testing.T) {
+	setup()
+	defer teardown()
+
+	description := "test1"
+	mux.HandleFunc("/v2/vpcs/net1", func(writer http.ResponseWriter, request *http.Request) {
+		if request.Method == http.MethodPut {
+			body := VPCUpdateReq{}
+			json.NewDecoder(request.Body).Decode(&body)
+			description = body.Description
+			writer.WriteHeader(http.StatusNoContent)
+			return
+		}
+		fmt.Fprintf(writer, `{"vpc": {"id": "net1", "region": "ewr", "description": %q}}`, description)
+	})
+
+	vpc, _, err := client.VPC.UpdateAndGet(ctx, "net1", &VPCUpdateReq{Description: "updated"})
+	if err != nil {
+		t.Errorf("VPC.UpdateAndGet returned %+v", err)
+	}
+
+	expected := &VPC{ID: "net1", Region: "ewr", Description: "updated"}
+	if !reflect.DeepEqual(vpc, expected) {
+		t.Errorf("VPC.UpdateAndGet returned %+v, expected %+v", vpc, expected)
+	}
+}
@@ -0,0 +1,134 @@
+package govultr
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	defaultWaitInterval    = 10 * time.Second
+	defaultWaitTimeout     = 30 * time.Minute
+	defaultWaitMaxInterval = 2 * time.Minute
+)
+
+// WaitOptions controls how the wait helpers poll the API for a resource to
+// reach its desired state
+type WaitOptions struct {
+	// Interval is the time between status checks. Defaults to 10 seconds.
+	Interval time.Duration
+
+	// Timeout is the maximum time to wait before giving up. Defaults to 30 minutes.
+	Timeout time.Duration
+
+	// Backoff multiplies the interval after every status check when greater
+	// than 1, up to MaxInterval. Defaults to a fixed interval.
+	Backoff float64
+
+	// MaxInterval caps the interval when Backoff is set. Defaults to 2 minutes.
+	MaxInterval time.Duration
+
+	// Progress is called after every status check with the latest status
+	// and the time elapsed since the wait started
+	Progress WaitProgressFunc
+}
+
+// WaitProgressFunc defines the type of the wait progress callback function
+type WaitProgressFunc func(status string, elapsed time.Duration)
+
+func (w *WaitOptions) interval() time.Duration {
+	if w == nil || w.Interval <= 0 {
+		return defaultWaitInterval
+	}
+	return w.Interval
+}
+
+// nextInterval returns the interval to wait after one of length current
+func (w *WaitOptions) nextInterval(current time.Duration) time.Duration {
+	if w == nil || w.Backoff <= 1 {
+		return current
+	}
+
+	maxInterval := w.MaxInterval
+	if maxInterval <= 0 {
+		maxInterval = defaultWaitMaxInterval
+	}
+
+	next := time.Duration(float64(current) * w.Backoff)
+	if next > maxInterval || next <= 0 {
+		return max(maxInterval, current)
+	}
+	return next
+}
+
+func (w *WaitOptions) timeout() time.Duration {
+	if w == nil || w.Timeout <= 0 {
+		return defaultWaitTimeout
+	}
+	return w.Timeout
+}
+
+// waitFor calls check until it reports done, returns an error, or the wait
+// times out. The first check is made immediately.
+func waitFor(ctx context.Context, opts *WaitOptions, check func(ctx context.Context) (string, bool, error)) error {
+	ctx, cancel := context.WithTimeout(ctx, opts.timeout())
+	defer cancel()
+
+	interval := opts.interval()
+	timer := time.NewTimer(interval)
+	defer timer.Stop()
+
+	start := time.Now()
+	for {
+		status, done, err := check(ctx)
+		if err != nil {
+			return err
+		}
+
+		if opts != nil && opts.Progress != nil {
+			opts.Progress(status, time.Since(start))
+		}
+
+		if done {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("gave up waiting: %w", ctx.Err())
+		case <-timer.C:
+		}
+
+		interval = opts.nextInterval(interval)
+		timer.Reset(interval)
+	}
+}
+
+// waitForStatus polls get until one of the statuses of the resource matches
+// status, ignoring case
+func waitForStatus[T any](ctx context.Context, options *WaitOptions, status string, get func(ctx context.Context) (*T, *http.Response, error), statuses func(*T) []string) (*T, *http.Response, error) { //nolint:lll
+	var resource *T
+	var resp *http.Response
+	err := waitFor(ctx, options, func(ctx context.Context) (string, bool, error) {
+		var errGet error
+		resource, resp, errGet = get(ctx)
+		if errGet != nil {
+			return "", false, errGet
+		}
+
+		current := statuses(resource)
+		for _, s := range current {
+			if strings.EqualFold(s, status) {
+				return strings.Join(current, "/"), true, nil
+			}
+		}
+		return strings.Join(current, "/"), false, nil
+	})
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return resource, resp, nil
+}
@@ -0,0 +1,33 @@
+package govultr
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWaitOptions_NextInterval(t *testing.T) {
+	options := &WaitOptions{Backoff: 2, MaxInterval: 5 * time.Second}
+
+	interval := time.Second
+	var intervals []time.Duration
+	for i := 0; i < 4; i++ {
+		interval = options.nextInterval(interval)
+		intervals = append(intervals, interval)
+	}
+
+	expected := []time.Duration{2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second}
+	for i := range expected {
+		if intervals[i] != expected[i] {
+			t.Errorf("WaitOptions.nextInterval returned %v, expected %v", intervals, expected)
+			break
+		}
+	}
+
+	if next := (&WaitOptions{}).nextInterval(time.Second); next != time.Second {
+		t.Errorf("WaitOptions.nextInterval returned %v without backoff, expected 1s", next)
+	}
+
+	if next := (&WaitOptions{Backoff: 10}).nextInterval(time.Minute); next != defaultWaitMaxInterval {
+		t.Errorf("WaitOptions.nextInterval returned %v, expected %v", next, defaultWaitMaxInterval)
+	}
+}